@@ -23,6 +23,11 @@
 // receivers that are built using confighttp.HTTPServerSettings or
 // configgrpc.GRPCServerSettings.
 //
+// When the TLS client certificate of the connection is verified, the confighttp
+// and configgrpc helpers also set the client.TLSAuthData of the certificate as
+// the client.AuthData, and its attributes in the client.Metadata when the
+// metadata is included.
+//
 // Authenticators are responsible for obtaining a client.Info from the current
 // context, enhancing the client.Info with an implementation of client.AuthData,
 // and storing a new client.Info into the context that it passes down. The
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import (
+	"crypto/x509"
+	"strings"
+)
+
+// The attributes of the TLSAuthData, also used as the request metadata keys when the metadata is included.
+const (
+	// TLSSubjectAttribute is the subject of the client certificate, a string in the RFC 2253 format.
+	TLSSubjectAttribute = "tls.client.subject"
+	// TLSDNSNamesAttribute is the list of the DNS names of the subject alternative names.
+	TLSDNSNamesAttribute = "tls.client.dns_names"
+	// TLSEmailAddressesAttribute is the list of the email addresses of the subject alternative names.
+	TLSEmailAddressesAttribute = "tls.client.email_addresses"
+	// TLSIPAddressesAttribute is the list of the IP addresses of the subject alternative names.
+	TLSIPAddressesAttribute = "tls.client.ip_addresses"
+	// TLSURIsAttribute is the list of the URIs of the subject alternative names.
+	TLSURIsAttribute = "tls.client.uris"
+	// TLSSPIFFEIDAttribute is the SPIFFE ID of the client, the URI of the subject alternative names with the
+	// spiffe scheme. It is only set when the certificate is a SPIFFE X.509-SVID.
+	TLSSPIFFEIDAttribute = "tls.client.spiffe_id"
+)
+
+const tlsAttributePrefix = "tls.client."
+
+// TLSAuthData is the AuthData of the clients authenticated by a verified TLS client certificate. The confighttp
+// and configgrpc servers set it in the Info when the TLS configuration verifies the client certificates, it is
+// replaced by the AuthData of the authenticator configured on the server, if any.
+//
+// The string attributes are returned as a string, the list attributes as a []string. The attributes
+// which are not present in the certificate are omitted.
+type TLSAuthData struct {
+	attributes map[string][]string
+	names      []string
+}
+
+var _ AuthData = (*TLSAuthData)(nil)
+
+// NewTLSAuthData returns the TLSAuthData of the given verified client certificate.
+func NewTLSAuthData(cert *x509.Certificate) *TLSAuthData {
+	d := &TLSAuthData{attributes: map[string][]string{}}
+	d.add(TLSSubjectAttribute, cert.Subject.String())
+	d.add(TLSDNSNamesAttribute, cert.DNSNames...)
+	d.add(TLSEmailAddressesAttribute, cert.EmailAddresses...)
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	d.add(TLSIPAddressesAttribute, ips...)
+	uris := make([]string, 0, len(cert.URIs))
+	var spiffeIDs []string
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+		if strings.EqualFold(uri.Scheme, "spiffe") {
+			spiffeIDs = append(spiffeIDs, uri.String())
+		}
+	}
+	d.add(TLSURIsAttribute, uris...)
+	// An X.509-SVID contains exactly one URI SAN, the SPIFFE ID.
+	if len(spiffeIDs) == 1 {
+		d.add(TLSSPIFFEIDAttribute, spiffeIDs[0])
+	}
+	return d
+}
+
+func (d *TLSAuthData) add(name string, values ...string) {
+	if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+		return
+	}
+	d.attributes[name] = values
+	d.names = append(d.names, name)
+}
+
+// GetAttribute returns the value of the given attribute, or nil if it is not present.
+func (d *TLSAuthData) GetAttribute(name string) any {
+	values, ok := d.attributes[name]
+	if !ok {
+		return nil
+	}
+	switch name {
+	case TLSSubjectAttribute, TLSSPIFFEIDAttribute:
+		return values[0]
+	default:
+		return append([]string(nil), values...)
+	}
+}
+
+// GetAttributeNames returns the names of the attributes present in the certificate.
+func (d *TLSAuthData) GetAttributeNames() []string {
+	return append([]string(nil), d.names...)
+}
+
+// SetTLSMetadata sets the attributes of the given TLSAuthData in the request metadata md, replacing the values
+// sent by the client under the same keys, so that they cannot be forged. The data may be nil, the keys are then
+// only removed from the metadata.
+func SetTLSMetadata(md map[string][]string, data *TLSAuthData) {
+	for k := range md {
+		if strings.HasPrefix(strings.ToLower(k), tlsAttributePrefix) {
+			delete(md, k)
+		}
+	}
+	if data == nil {
+		return
+	}
+	for _, name := range data.names {
+		md[name] = append([]string(nil), data.attributes[name]...)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLSAuthData(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "collector", Organization: []string{"OpenTelemetry"}},
+		DNSNames:       []string{"collector.example.com", "collector"},
+		EmailAddresses: []string{"otel@example.com"},
+		IPAddresses:    []net.IP{net.IPv4(10, 0, 0, 1)},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/ns/prod/sa/collector"}},
+	}
+	data := NewTLSAuthData(cert)
+
+	assert.Equal(t, []string{
+		TLSSubjectAttribute,
+		TLSDNSNamesAttribute,
+		TLSEmailAddressesAttribute,
+		TLSIPAddressesAttribute,
+		TLSURIsAttribute,
+		TLSSPIFFEIDAttribute,
+	}, data.GetAttributeNames())
+	assert.Equal(t, "CN=collector,O=OpenTelemetry", data.GetAttribute(TLSSubjectAttribute))
+	assert.Equal(t, []string{"collector.example.com", "collector"}, data.GetAttribute(TLSDNSNamesAttribute))
+	assert.Equal(t, []string{"otel@example.com"}, data.GetAttribute(TLSEmailAddressesAttribute))
+	assert.Equal(t, []string{"10.0.0.1"}, data.GetAttribute(TLSIPAddressesAttribute))
+	assert.Equal(t, []string{"spiffe://example.org/ns/prod/sa/collector"}, data.GetAttribute(TLSURIsAttribute))
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/collector", data.GetAttribute(TLSSPIFFEIDAttribute))
+	assert.Nil(t, data.GetAttribute("unknown"))
+}
+
+func TestTLSAuthDataWithoutSPIFFEID(t *testing.T) {
+	tests := []struct {
+		name string
+		uris []*url.URL
+	}{
+		{
+			name: "no URI",
+		},
+		{
+			name: "other scheme",
+			uris: []*url.URL{{Scheme: "https", Host: "example.org"}},
+		},
+		{
+			name: "several SPIFFE IDs",
+			uris: []*url.URL{
+				{Scheme: "spiffe", Host: "example.org", Path: "/a"},
+				{Scheme: "spiffe", Host: "example.org", Path: "/b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := NewTLSAuthData(&x509.Certificate{URIs: tt.uris})
+			assert.Nil(t, data.GetAttribute(TLSSPIFFEIDAttribute))
+			assert.Nil(t, data.GetAttribute(TLSSubjectAttribute))
+			assert.NotContains(t, data.GetAttributeNames(), TLSSPIFFEIDAttribute)
+		})
+	}
+}
+
+func TestSetTLSMetadata(t *testing.T) {
+	md := map[string][]string{
+		"Tls.client.subject": {"CN=forged"},
+		"tls.client.uris":    {"spiffe://forged"},
+		"X-Tenant":           {"acme"},
+	}
+	SetTLSMetadata(md, NewTLSAuthData(&x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}))
+	assert.Equal(t, map[string][]string{
+		TLSSubjectAttribute: {"CN=collector"},
+		"X-Tenant":          {"acme"},
+	}, md)
+
+	SetTLSMetadata(md, nil)
+	assert.Equal(t, map[string][]string{"X-Tenant": {"acme"}}, md)
+}
@@ -91,6 +91,7 @@ var replaceModules = []string{
 	"/internal/componentalias",
 	"/internal/memorylimiter",
 	"/internal/fanoutconsumer",
+	"/internal/persistentqueue",
 	"/internal/sharedcomponent",
 	"/internal/telemetry",
 	"/internal/testutil",
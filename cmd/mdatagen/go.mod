@@ -75,6 +75,8 @@ require (
 	github.com/modern-go/reflect2 v1.0.3-0.20250322232337-35a7c28c31ee // indirect
 	github.com/pierrec/lz4/v4 v4.1.26 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/quic-go/qpack v0.6.0 // indirect
+	github.com/quic-go/quic-go v0.59.1 // indirect
 	github.com/rs/cors v1.11.1 // indirect
 	github.com/spf13/pflag v1.0.10 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
@@ -240,3 +242,9 @@ replace go.opentelemetry.io/collector/receiver/receiverhelper => ../../receiver/
 replace go.opentelemetry.io/collector/scraper/scraperhelper => ../../scraper/scraperhelper
 
 replace go.opentelemetry.io/collector/scraper/xscraper => ../../scraper/xscraper
+
+replace go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper => ../../exporter/exporterhelper/xexporterhelper
+
+replace go.opentelemetry.io/collector/consumer/consumererror/xconsumererror => ../../consumer/consumererror/xconsumererror
+
+replace go.opentelemetry.io/collector/internal/persistentqueue => ../../internal/persistentqueue
@@ -15,6 +15,11 @@ type Level int
 
 type CompressionParams struct {
 	Level Level `mapstructure:"level"`
+	// DictionaryFile is the path of a zstd dictionary used to compress the payloads, e.g. trained with
+	// the `zstd-dictionary` command of the collector. The payloads are identified by the ID of the
+	// dictionary, the receiving side must be configured with the same dictionary to decompress them.
+	// Only supported by zstd.
+	DictionaryFile string `mapstructure:"dictionary_file,omitempty"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -56,6 +61,9 @@ func (ct *Type) UnmarshalText(in []byte) error {
 }
 
 func (ct *Type) ValidateParams(p CompressionParams) error {
+	if p.DictionaryFile != "" && *ct != TypeZstd {
+		return fmt.Errorf("unsupported parameters {DictionaryFile:%s} for compression type %q, dictionaries are only supported by %q", p.DictionaryFile, *ct, TypeZstd)
+	}
 	switch *ct {
 	case TypeGzip, TypeZlib, TypeDeflate:
 		if p.Level == zlib.DefaultCompression ||
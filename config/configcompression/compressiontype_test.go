@@ -97,6 +97,7 @@ func TestValidateParams(t *testing.T) {
 		name             string
 		compressionName  []byte
 		compressionLevel Level
+		dictionaryFile   string
 		shouldError      bool
 	}{
 		{
@@ -172,6 +173,19 @@ func TestValidateParams(t *testing.T) {
 			compressionLevel: 1,
 			shouldError:      true,
 		},
+		{
+			name:            "ValidZstdDictionary",
+			compressionName: []byte("zstd"),
+			dictionaryFile:  "otlp.dict",
+			shouldError:     false,
+		},
+		{
+			name:             "InvalidGzipDictionary",
+			compressionName:  []byte("gzip"),
+			compressionLevel: zlib.DefaultCompression,
+			dictionaryFile:   "otlp.dict",
+			shouldError:      true,
+		},
 		{
 			name:             "Invalid",
 			compressionName:  []byte("ggip"),
@@ -181,7 +195,7 @@ func TestValidateParams(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			compressionParams := CompressionParams{Level: tt.compressionLevel}
+			compressionParams := CompressionParams{Level: tt.compressionLevel, DictionaryFile: tt.dictionaryFile}
 			temp := Type(tt.compressionName)
 			err := temp.ValidateParams(compressionParams)
 			if tt.shouldError {
@@ -11,6 +11,8 @@ import (
 	"math"
 	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +43,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/extension/extensionauth"
+	grpczstd "go.opentelemetry.io/collector/internal/grpccompression/zstd"
 )
 
 var errMetadataNotFound = errors.New("no request metadata found")
@@ -86,6 +89,9 @@ type ClientConfig struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.Type `mapstructure:"compression,omitempty"`
 
+	// Advanced configuration options for the Compression, the compression level is not supported.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params,omitempty"`
+
 	// TLS struct exposes TLS client configuration.
 	TLS configtls.ClientConfig `mapstructure:"tls,omitempty"`
 
@@ -222,6 +228,10 @@ type ServerConfig struct {
 	// Middlewares for the gRPC server.
 	Middlewares []configmiddleware.Config `mapstructure:"middlewares,omitempty"`
 
+	// CompressionDictionaryFiles are the paths of the zstd dictionaries used to decompress the payloads of
+	// the clients configured with "compression_params::dictionary_file".
+	CompressionDictionaryFiles []string `mapstructure:"compression_dictionary_files,omitempty"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -264,6 +274,15 @@ func (cc *ClientConfig) Validate() error {
 		}
 	}
 
+	if cc.Compression.IsCompressed() {
+		if err := cc.Compression.ValidateParams(cc.CompressionParams); err != nil {
+			return err
+		}
+		if cc.CompressionParams.Level != 0 {
+			return fmt.Errorf("unsupported parameters {Level:%+v}, the compression level is not supported by gRPC", cc.CompressionParams.Level)
+		}
+	}
+
 	return nil
 }
 
@@ -382,7 +401,7 @@ func (cc *ClientConfig) getGrpcDialOptions(
 ) ([]grpc.DialOption, error) {
 	var opts []grpc.DialOption
 	if cc.Compression.IsCompressed() {
-		cp, err := getGRPCCompressionName(cc.Compression)
+		cp, err := getGRPCCompressionName(cc.Compression, cc.CompressionParams)
 		if err != nil {
 			return nil, err
 		}
@@ -550,6 +569,14 @@ func (sc *ServerConfig) getGrpcServerOptions(
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 
+	// The compressors using the dictionaries are registered globally, the server decompresses the payloads
+	// with any of them.
+	for _, file := range sc.CompressionDictionaryFiles {
+		if _, err := registerZstdDictionary(file); err != nil {
+			return nil, err
+		}
+	}
+
 	if sc.MaxRecvMsgSizeMiB > 0 && sc.MaxRecvMsgSizeMiB*1024*1024 > 0 {
 		opts = append(opts, grpc.MaxRecvMsgSize(sc.MaxRecvMsgSizeMiB*1024*1024))
 	}
@@ -640,7 +667,10 @@ func (sc *ServerConfig) getGrpcServerOptions(
 }
 
 // getGRPCCompressionName returns compression name registered in grpc.
-func getGRPCCompressionName(compressionType configcompression.Type) (string, error) {
+func getGRPCCompressionName(compressionType configcompression.Type, compressionParams configcompression.CompressionParams) (string, error) {
+	if compressionType == configcompression.TypeZstd && compressionParams.DictionaryFile != "" {
+		return registerZstdDictionary(compressionParams.DictionaryFile)
+	}
 	switch compressionType {
 	case configcompression.TypeGzip:
 		return gzip.Name, nil
@@ -653,6 +683,21 @@ func getGRPCCompressionName(compressionType configcompression.Type) (string, err
 	}
 }
 
+// registerZstdDictionary registers the compressor using the zstd dictionary stored in file, and returns its
+// name. The payloads are compressed with a compressor named after the dictionary ID, the servers without the
+// dictionary reject them instead of failing to decompress them.
+func registerZstdDictionary(file string) (string, error) {
+	dict, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return "", fmt.Errorf("failed to load the zstd dictionary: %w", err)
+	}
+	name, err := grpczstd.RegisterDictionary(dict)
+	if err != nil {
+		return "", fmt.Errorf("failed to load the zstd dictionary %q: %w", file, err)
+	}
+	return name, nil
+}
+
 // enhanceWithClientInformation intercepts the incoming RPC, replacing the incoming context with one that includes
 // a client.Info, potentially with the peer's address.
 func enhanceWithClientInformation(includeMetadata bool) grpc.UnaryServerInterceptor {
@@ -667,12 +712,17 @@ func enhanceStreamWithClientInformation(includeMetadata bool) grpc.StreamServerI
 	}
 }
 
-// contextWithClient attempts to add the peer address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
+// contextWithClient attempts to add the peer address and the identity of its verified TLS certificate to the
+// client.Info from the context. When no client.Info exists in the context, one is created.
 func contextWithClient(ctx context.Context, includeMetadata bool) context.Context {
 	cl := client.FromContext(ctx)
+	var tlsData *client.TLSAuthData
 	if p, ok := peer.FromContext(ctx); ok {
 		cl.Addr = p.Addr
+		tlsData = tlsAuthData(p)
+		if tlsData != nil {
+			cl.Auth = tlsData
+		}
 	}
 	if includeMetadata {
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -680,12 +730,23 @@ func contextWithClient(ctx context.Context, includeMetadata bool) context.Contex
 			if len(md[client.MetadataHostName]) == 0 && len(md[":authority"]) > 0 {
 				copiedMD[client.MetadataHostName] = md[":authority"]
 			}
+			client.SetTLSMetadata(copiedMD, tlsData)
 			cl.Metadata = client.NewMetadata(copiedMD)
 		}
 	}
 	return client.NewContext(ctx, cl)
 }
 
+// tlsAuthData returns the client.TLSAuthData of the verified client certificate of the peer, or nil if the
+// client certificate was not verified.
+func tlsAuthData(p *peer.Peer) *client.TLSAuthData {
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return client.NewTLSAuthData(tlsInfo.State.VerifiedChains[0][0])
+}
+
 func authUnaryServerInterceptor(server extensionauth.Server) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		headers, ok := metadata.FromIncomingContext(ctx)
@@ -5,7 +5,11 @@ package configgrpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -13,10 +17,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/dict"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -862,7 +869,69 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	assert.NotNil(t, resp)
 }
 
+func writeZstdDictionary(t *testing.T, id uint32) string {
+	var samples [][]byte
+	for i := range 20 {
+		samples = append(samples, fmt.Appendf(nil, `{"resource":{"attributes":{"service.name":"checkout","host.name":"node-%d"}}}`, i))
+	}
+	d, err := dict.BuildZstdDict(samples, dict.Options{MaxDictSize: 4096, HashBytes: 6, ZstdDictID: id})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "otlp.dict")
+	require.NoError(t, os.WriteFile(file, d, 0o600))
+	return file
+}
+
+func TestZstdDictionaryCompression(t *testing.T) {
+	dictFile := writeZstdDictionary(t, 2001)
+	srv, addr := (&grpcTraceServer{}).startTestServer(t, configoptional.Some(ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		CompressionDictionaryFiles: []string{dictFile},
+	}))
+	defer srv.Stop()
+	assert.NotNil(t, encoding.GetCompressor("zstd-dict-2001"))
+
+	cc := ClientConfig{
+		Endpoint:          addr,
+		Compression:       configcompression.TypeZstd,
+		CompressionParams: configcompression.CompressionParams{DictionaryFile: dictFile},
+		TLS: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	require.NoError(t, cc.Validate())
+	_, err := sendTestRequest(t, cc)
+	require.NoError(t, err)
+}
+
+func TestZstdDictionaryErrors(t *testing.T) {
+	cc := ClientConfig{
+		Endpoint:          "localhost:1234",
+		Compression:       configcompression.TypeGzip,
+		CompressionParams: configcompression.CompressionParams{DictionaryFile: "otlp.dict"},
+	}
+	require.ErrorContains(t, cc.Validate(), `dictionaries are only supported by "zstd"`)
+
+	cc.Compression = configcompression.TypeZstd
+	cc.CompressionParams = configcompression.CompressionParams{Level: 3}
+	require.ErrorContains(t, cc.Validate(), "the compression level is not supported by gRPC")
+
+	cc.CompressionParams = configcompression.CompressionParams{DictionaryFile: "/doesnt/exist"}
+	require.NoError(t, cc.Validate())
+	_, err := cc.ToClientConn(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "failed to load the zstd dictionary")
+
+	invalidDict := filepath.Join(t.TempDir(), "invalid.dict")
+	require.NoError(t, os.WriteFile(invalidDict, []byte("not a dictionary"), 0o600))
+	sc := ServerConfig{CompressionDictionaryFiles: []string{invalidDict}}
+	_, err = sc.ToServer(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "invalid zstd dictionary")
+}
+
 func TestContextWithClient(t *testing.T) {
+	clientCert := &x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}
 	testCases := []struct {
 		desc       string
 		input      context.Context
@@ -957,6 +1026,42 @@ func TestContextWithClient(t *testing.T) {
 				Metadata: client.NewMetadata(map[string][]string{"test-metadata-key": {"test-value"}, ":authority": {"localhost:55443"}, "Host": {"localhost:55443"}}),
 			},
 		},
+		{
+			desc: "peer with verified client certificate",
+			input: peer.NewContext(context.Background(), &peer.Peer{
+				AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{clientCert}}}},
+			}),
+			expected: client.Info{
+				Auth: client.NewTLSAuthData(clientCert),
+			},
+		},
+		{
+			desc: "peer with verified client certificate and forged metadata",
+			input: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{
+					AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{clientCert}}}},
+				}),
+				metadata.Pairs(client.TLSSubjectAttribute, "CN=forged"),
+			),
+			doMetadata: true,
+			expected: client.Info{
+				Auth:     client.NewTLSAuthData(clientCert),
+				Metadata: client.NewMetadata(map[string][]string{client.TLSSubjectAttribute: {"CN=collector"}}),
+			},
+		},
+		{
+			desc: "peer without verified client certificate and forged metadata",
+			input: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{
+					AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}},
+				}),
+				metadata.Pairs(client.TLSSubjectAttribute, "CN=forged"),
+			),
+			doMetadata: true,
+			expected: client.Info{
+				Metadata: client.NewMetadata(map[string][]string{}),
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -3,8 +3,10 @@ module go.opentelemetry.io/collector/config/configgrpc
 go 1.25.0
 
 require (
+	github.com/klauspost/compress v1.17.9
 	github.com/mostynb/go-grpc-compression v1.2.3
 	github.com/stretchr/testify v1.11.1
+	go.opentelemetry.io/collector v0.150.0
 	go.opentelemetry.io/collector/client v1.56.0
 	go.opentelemetry.io/collector/component v1.56.0
 	go.opentelemetry.io/collector/component/componenttest v0.150.0
@@ -43,7 +45,6 @@ require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/go-version v1.9.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
-	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/knadh/koanf/maps v0.1.2 // indirect
 	github.com/knadh/koanf/providers/confmap v1.0.0 // indirect
 	github.com/knadh/koanf/v2 v2.3.4 // indirect
@@ -120,3 +121,5 @@ replace go.opentelemetry.io/collector/confmap/xconfmap => ../../confmap/xconfmap
 replace go.opentelemetry.io/collector/internal/testutil => ../../internal/testutil
 
 replace go.opentelemetry.io/collector/internal/componentalias => ../../internal/componentalias
+
+replace go.opentelemetry.io/collector => ../..
@@ -11,6 +11,7 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"slices"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -113,6 +114,10 @@ type ClientConfig struct {
 	// Middleware handlers are called in the order they appear in this list,
 	// with the first middleware becoming the outermost handler.
 	Middlewares []configmiddleware.Config `mapstructure:"middlewares,omitempty"`
+
+	// HTTP3 enables sending the requests with HTTP/3 instead of HTTP/1.1 or HTTP/2.
+	// The settings of the HTTP/1.1 and HTTP/2 connections are ignored. This setting is experimental.
+	HTTP3 configoptional.Optional[HTTP3Config] `mapstructure:"http3,omitempty"`
 }
 
 // CookiesConfig defines the configuration of the HTTP client regarding cookies served by the server.
@@ -141,6 +146,14 @@ func (cc *ClientConfig) Validate() error {
 			return err
 		}
 	}
+	if cc.HTTP3.HasValue() {
+		if cc.ProxyURL != "" {
+			return errors.New("proxy_url is not supported with HTTP/3")
+		}
+		if strings.HasPrefix(cc.Endpoint, "http://") {
+			return errors.New("HTTP/3 requires an https endpoint")
+		}
+	}
 	return nil
 }
 
@@ -199,6 +212,9 @@ func (cc *ClientConfig) ToClient(ctx context.Context, extensions map[component.I
 	}
 
 	clientTransport := http.RoundTripper(transport)
+	if cc.HTTP3.HasValue() {
+		clientTransport = cc.HTTP3.Get().newHTTP3Transport(tlsCfg)
+	}
 
 	// Apply middlewares in reverse order so they execute in
 	// forward order. The first middleware runs after authentication.
@@ -304,3 +320,15 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 	// Send the request to next transport.
 	return interceptor.transport.RoundTrip(req)
 }
+
+// CloseIdleConnections closes the idle connections of the next transport.
+func (interceptor *headerRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(interceptor.transport)
+}
+
+// closeIdleConnections closes the idle connections of the transport if it supports it.
+func closeIdleConnections(rt http.RoundTripper) {
+	if ci, ok := rt.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
@@ -5,6 +5,9 @@ package confighttp
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"net"
 	"net/http"
@@ -595,6 +598,7 @@ func TestHTTPTransportOptions(t *testing.T) {
 }
 
 func TestContextWithClient(t *testing.T) {
+	clientCert := &x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}
 	testCases := []struct {
 		name       string
 		input      *http.Request
@@ -646,6 +650,38 @@ func TestContextWithClient(t *testing.T) {
 				Metadata: client.NewMetadata(map[string][]string{"x-tt-header": {"tt-value"}, "Host": {"localhost:55443"}}),
 			},
 		},
+		{
+			name: "request with verified client certificate",
+			input: &http.Request{
+				TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{clientCert}}},
+			},
+			expected: client.Info{
+				Auth: client.NewTLSAuthData(clientCert),
+			},
+		},
+		{
+			name: "request with verified client certificate and forged metadata",
+			input: &http.Request{
+				Header: map[string][]string{"Tls.client.subject": {"CN=forged"}},
+				TLS:    &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{clientCert}}},
+			},
+			doMetadata: true,
+			expected: client.Info{
+				Auth:     client.NewTLSAuthData(clientCert),
+				Metadata: client.NewMetadata(map[string][]string{client.TLSSubjectAttribute: {"CN=collector"}}),
+			},
+		},
+		{
+			name: "request with unverified client certificate",
+			input: &http.Request{
+				Header: map[string][]string{"Tls.client.subject": {"CN=forged"}},
+				TLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}},
+			},
+			doMetadata: true,
+			expected: client.Info{
+				Metadata: client.NewMetadata(map[string][]string{}),
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -26,8 +26,8 @@ func (h *clientInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	h.next.ServeHTTP(w, req)
 }
 
-// contextWithClient attempts to add the client IP address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
+// contextWithClient attempts to add the client IP address and the identity of its verified TLS certificate to
+// the client.Info from the context. When no client.Info exists in the context, one is created.
 func contextWithClient(req *http.Request, includeMetadata bool) context.Context {
 	cl := client.FromContext(req.Context())
 
@@ -36,11 +36,17 @@ func contextWithClient(req *http.Request, includeMetadata bool) context.Context
 		cl.Addr = ip
 	}
 
+	tlsData := tlsAuthData(req)
+	if tlsData != nil {
+		cl.Auth = tlsData
+	}
+
 	if includeMetadata {
 		md := req.Header.Clone()
 		if md.Get(client.MetadataHostName) == "" && req.Host != "" {
 			md.Add(client.MetadataHostName, req.Host)
 		}
+		client.SetTLSMetadata(md, tlsData)
 
 		cl.Metadata = client.NewMetadata(md)
 	}
@@ -49,6 +55,15 @@ func contextWithClient(req *http.Request, includeMetadata bool) context.Context
 	return ctx
 }
 
+// tlsAuthData returns the client.TLSAuthData of the verified client certificate of the request, or nil if the
+// client certificate was not verified.
+func tlsAuthData(req *http.Request) *client.TLSAuthData {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return client.NewTLSAuthData(req.TLS.VerifiedChains[0][0])
+}
+
 // parseIP parses the given string for an IP address. The input string might contain the port,
 // but must not contain a protocol or path. Suitable for getting the IP part of a client connection.
 func parseIP(source string) *net.IPAddr {
@@ -15,6 +15,9 @@ import (
 	"io"
 	"maps"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/golang/snappy"
@@ -22,6 +25,7 @@ import (
 	"github.com/pierrec/lz4/v4"
 
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confighttp/internal"
 )
 
 func defaultCompressionAlgorithms() []string {
@@ -39,6 +43,7 @@ var zstdReaderPool sync.Pool
 
 type pooledZstdReadCloser struct {
 	inner *zstd.Decoder
+	pool  *sync.Pool
 }
 
 func (pzrc *pooledZstdReadCloser) Read(dst []byte) (int, error) {
@@ -54,12 +59,69 @@ func (pzrc *pooledZstdReadCloser) Close() error {
 		if err != nil {
 			return err
 		}
-		zstdReaderPool.Put(pzrc.inner)
+		pzrc.pool.Put(pzrc.inner)
 		pzrc.inner = nil
 	}
 	return nil
 }
 
+// newPooledZstdDecoder returns a zstd decoder reusing the decoders of the pool, created with the given options.
+func newPooledZstdDecoder(pool *sync.Pool, opts ...zstd.DOption) func(body io.ReadCloser) (io.ReadCloser, error) {
+	return func(body io.ReadCloser) (io.ReadCloser, error) {
+		v := pool.Get()
+		var zr *zstd.Decoder
+		var err error
+		if v == nil {
+			zr, err = zstd.NewReader(body, opts...)
+		} else {
+			zr = v.(*zstd.Decoder)
+			err = zr.Reset(body)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pooledZstdReadCloser{inner: zr, pool: pool}, nil
+	}
+}
+
+// zstdDictionaryEncodingPrefix is the prefix of the content-codings of the payloads compressed with a zstd
+// dictionary, named after the ID of the dictionary as the gRPC compressors.
+const zstdDictionaryEncodingPrefix = "zstd-dict-"
+
+var errUnknownZstdDictionary = errors.New("unknown zstd dictionary")
+
+// zstdDictionaryEncoding returns the content-coding of the payloads compressed with the zstd dictionary
+// identified by id, so the servers without the dictionary reject them instead of failing to decompress them.
+func zstdDictionaryEncoding(id uint32) string {
+	return fmt.Sprintf("%s%d", zstdDictionaryEncodingPrefix, id)
+}
+
+// newZstdDictionaryDecoders returns the zstd decoders of the payloads compressed with the dictionaries stored
+// in files, by content-coding.
+func newZstdDictionaryDecoders(files []string) (map[string]func(body io.ReadCloser) (io.ReadCloser, error), error) {
+	decoders := make(map[string]func(body io.ReadCloser) (io.ReadCloser, error), len(files))
+	for _, file := range files {
+		dict, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the zstd dictionary: %w", err)
+		}
+		info, err := zstd.InspectDictionary(dict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the zstd dictionary %q: %w", file, err)
+		}
+		opts := []zstd.DOption{zstd.WithDecoderConcurrency(1), zstd.WithDecoderDicts(dict)}
+		// The dictionary is validated once, the decoders created by the pool can't fail on it.
+		zr, err := zstd.NewReader(nil, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the zstd dictionary %q: %w", file, err)
+		}
+		pool := &sync.Pool{}
+		pool.Put(zr)
+		decoders[zstdDictionaryEncoding(info.ID())] = newPooledZstdDecoder(pool, opts...)
+	}
+	return decoders, nil
+}
+
 var availableDecoders = map[string]func(body io.ReadCloser) (io.ReadCloser, error){
 	"": func(io.ReadCloser) (io.ReadCloser, error) {
 		// Not a compressed payload. Nothing to do.
@@ -72,26 +134,12 @@ var availableDecoders = map[string]func(body io.ReadCloser) (io.ReadCloser, erro
 		}
 		return gr, nil
 	},
-	"zstd": func(body io.ReadCloser) (io.ReadCloser, error) {
-		v := zstdReaderPool.Get()
-		var zr *zstd.Decoder
-		var err error
-		if v == nil {
-			// NOTE(tigrannajaryan):
-			// Concurrency 1 disables async decoding. We don't need async decoding, it is pointless
-			// for our use-case (a server accepting decoding http requests).
-			// Disabling async improves performance (I benchmarked it previously when working
-			// on https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/23257).
-			zr, err = zstd.NewReader(body, zstd.WithDecoderConcurrency(1))
-		} else {
-			zr = v.(*zstd.Decoder)
-			err = zr.Reset(body)
-		}
-		if err != nil {
-			return nil, err
-		}
-		return &pooledZstdReadCloser{inner: zr}, nil
-	},
+	// NOTE(tigrannajaryan):
+	// Concurrency 1 disables async decoding. We don't need async decoding, it is pointless
+	// for our use-case (a server accepting decoding http requests).
+	// Disabling async improves performance (I benchmarked it previously when working
+	// on https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/23257).
+	"zstd": newPooledZstdDecoder(&zstdReaderPool, zstd.WithDecoderConcurrency(1)),
 	"zlib": func(body io.ReadCloser) (io.ReadCloser, error) {
 		zr, err := zlib.NewReader(body)
 		if err != nil {
@@ -201,11 +249,16 @@ func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 
 	// Clone the headers and add the encoding header.
 	cReq.Header = req.Header.Clone()
-	cReq.Header.Add(headerContentEncoding, string(r.compressionType))
+	cReq.Header.Add(headerContentEncoding, r.compressor.contentEncoding)
 
 	return r.rt.RoundTrip(cReq)
 }
 
+// CloseIdleConnections closes the idle connections of the next transport.
+func (r *compressRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(r.rt)
+}
+
 type decompressor struct {
 	errHandler         func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
 	base               http.Handler
@@ -244,13 +297,21 @@ func httpContentDecompressor(h http.Handler, maxRequestBodySize int64, eh func(w
 }
 
 func (d *decompressor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	newBody, err := d.newBodyReader(r)
+	compressedBody := internal.NewCompressedBody(r.Body, r.ContentLength)
+	newBody, err := d.newBodyReader(r, compressedBody)
 	if err != nil {
-		d.errHandler(w, r, err.Error(), http.StatusBadRequest)
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, errUnknownZstdDictionary) {
+			// The client can send its payloads without the dictionary.
+			statusCode = http.StatusUnsupportedMediaType
+		}
+		d.errHandler(w, r, err.Error(), statusCode)
 		return
 	}
 	if newBody != nil {
 		defer newBody.Close()
+		// The compressed body is kept in the context, so the middlewares know the size of the request as received.
+		r = r.WithContext(internal.ContextWithCompressedBody(r.Context(), compressedBody))
 		// "Content-Encoding" header is removed to avoid decompressing twice
 		// in case the next handler(s) have implemented a similar mechanism.
 		r.Header.Del("Content-Encoding")
@@ -262,7 +323,7 @@ func (d *decompressor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	d.base.ServeHTTP(w, r)
 }
 
-func (d *decompressor) newBodyReader(r *http.Request) (io.ReadCloser, error) {
+func (d *decompressor) newBodyReader(r *http.Request, body io.ReadCloser) (io.ReadCloser, error) {
 	if len(d.decoders) == 0 {
 		return nil, nil // Signal: don't replace r.Body
 	}
@@ -270,9 +331,12 @@ func (d *decompressor) newBodyReader(r *http.Request) (io.ReadCloser, error) {
 
 	decoder, ok := d.decoders[encoding]
 	if !ok {
+		if strings.HasPrefix(encoding, zstdDictionaryEncodingPrefix) {
+			return nil, fmt.Errorf("unsupported %s: %s: %w", headerContentEncoding, encoding, errUnknownZstdDictionary)
+		}
 		return nil, fmt.Errorf("unsupported %s: %s", headerContentEncoding, encoding)
 	}
-	return decoder(r.Body)
+	return decoder(body)
 }
 
 // defaultErrorHandler writes the error message in plain text.
@@ -13,11 +13,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"testing/iotest"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/dict"
 	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
 	"github.com/stretchr/testify/assert"
@@ -225,6 +228,89 @@ func TestHTTPCustomDecompression(t *testing.T) {
 	require.NoError(t, res.Body.Close(), "failed to close request body: %v", err)
 }
 
+func writeZstdDictionary(t *testing.T, id uint32) string {
+	var samples [][]byte
+	for i := range 20 {
+		samples = append(samples, fmt.Appendf(nil, `{"resource":{"attributes":{"service.name":"checkout","host.name":"node-%d"}}}`, i))
+	}
+	d, err := dict.BuildZstdDict(samples, dict.Options{MaxDictSize: 4096, HashBytes: 6, ZstdDictID: id})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "otlp.dict")
+	require.NoError(t, os.WriteFile(file, d, 0o600))
+	return file
+}
+
+func TestHTTPZstdDictionaryCompression(t *testing.T) {
+	dictFile := writeZstdDictionary(t, 3001)
+	testBody := []byte(`{"resource":{"attributes":{"service.name":"checkout","host.name":"node-42"}}}`)
+	var contentEncoding string
+	newServer := func(t *testing.T, sc ServerConfig) *httptest.Server {
+		srv, err := sc.ToServer(context.Background(), nil, componenttest.NewNopTelemetrySettings(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				assert.Equal(t, testBody, body)
+				w.WriteHeader(http.StatusOK)
+			}))
+		require.NoError(t, err)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentEncoding = r.Header.Get(headerContentEncoding)
+			srv.Handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(ts.Close)
+		return ts
+	}
+	send := func(t *testing.T, url string) int {
+		cc := ClientConfig{
+			Endpoint:          url,
+			Compression:       configcompression.TypeZstd,
+			CompressionParams: configcompression.CompressionParams{DictionaryFile: dictFile},
+		}
+		require.NoError(t, cc.Validate())
+		client, err := cc.ToClient(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+		require.NoError(t, err)
+		res, err := client.Post(url, "application/json", bytes.NewReader(testBody))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+
+	sc := NewDefaultServerConfig()
+	sc.CompressionDictionaryFiles = []string{dictFile}
+	assert.Equal(t, http.StatusOK, send(t, newServer(t, sc).URL))
+	// The payloads are identified by the ID of the dictionary.
+	assert.Equal(t, "zstd-dict-3001", contentEncoding)
+
+	// The payloads are rejected as unsupported by the servers without the dictionary.
+	assert.Equal(t, http.StatusUnsupportedMediaType, send(t, newServer(t, NewDefaultServerConfig()).URL))
+	sc.CompressionDictionaryFiles = []string{writeZstdDictionary(t, 3002)}
+	assert.Equal(t, http.StatusUnsupportedMediaType, send(t, newServer(t, sc).URL))
+}
+
+func TestHTTPZstdDictionaryErrors(t *testing.T) {
+	cc := ClientConfig{
+		Endpoint:          "localhost:1234",
+		Compression:       configcompression.TypeZstd,
+		CompressionParams: configcompression.CompressionParams{DictionaryFile: "/doesnt/exist"},
+	}
+	_, err := cc.ToClient(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "failed to load the zstd dictionary")
+
+	invalidDict := filepath.Join(t.TempDir(), "invalid.dict")
+	require.NoError(t, os.WriteFile(invalidDict, []byte("not a dictionary"), 0o600))
+	cc.CompressionParams = configcompression.CompressionParams{DictionaryFile: invalidDict}
+	_, err = cc.ToClient(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "failed to load the zstd dictionary")
+
+	sc := NewDefaultServerConfig()
+	sc.CompressionDictionaryFiles = []string{invalidDict}
+	_, err = sc.ToServer(context.Background(), nil, componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.ErrorContains(t, err, "failed to load the zstd dictionary")
+}
+
 func TestHTTPContentDecompressionHandler(t *testing.T) {
 	testBody := []byte("uncompressed_text")
 	noDecoders := map[string]func(io.ReadCloser) (io.ReadCloser, error){}
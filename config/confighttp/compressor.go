@@ -8,7 +8,10 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/golang/snappy"
@@ -25,6 +28,8 @@ type writeCloserReset interface {
 
 type compressor struct {
 	pool sync.Pool
+	// contentEncoding is the content-coding of the compressed payloads.
+	contentEncoding string
 }
 
 type compressorMap map[compressionMapKey]*compressor
@@ -50,51 +55,72 @@ func newCompressor(compressionType configcompression.Type, compressionParams con
 		return c, nil
 	}
 
-	f, err := newWriteCloserResetFunc(compressionType, compressionParams)
+	f, contentEncoding, err := newWriteCloserResetFunc(compressionType, compressionParams)
 	if err != nil {
 		return nil, err
 	}
-	c = &compressor{pool: sync.Pool{New: func() any { return f() }}}
+	c = &compressor{pool: sync.Pool{New: func() any { return f() }}, contentEncoding: contentEncoding}
 	compressorPools[mapKey] = c
 	return c, nil
 }
 
-func newWriteCloserResetFunc(compressionType configcompression.Type, compressionParams configcompression.CompressionParams) (func() writeCloserReset, error) {
+// newWriteCloserResetFunc returns the factory of the writers compressing the payloads, and their content-coding.
+func newWriteCloserResetFunc(compressionType configcompression.Type, compressionParams configcompression.CompressionParams) (func() writeCloserReset, string, error) {
+	contentEncoding := string(compressionType)
 	switch compressionType {
 	case configcompression.TypeGzip:
 		return func() writeCloserReset {
 			w, _ := gzip.NewWriterLevel(nil, int(compressionParams.Level))
 			return w
-		}, nil
+		}, contentEncoding, nil
 	case configcompression.TypeSnappyFramed:
 		return func() writeCloserReset {
 			return snappy.NewBufferedWriter(nil)
-		}, nil
+		}, contentEncoding, nil
 	case configcompression.TypeSnappy:
 		return func() writeCloserReset {
 			// If framed snappy feature gate is enabled, we use the correct behavior
 			// where the 'Content-Encoding: snappy' is compressed as the block snappy format.
 			return &rawSnappyWriter{}
-		}, nil
+		}, contentEncoding, nil
 	case configcompression.TypeZstd:
-		level := zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(int(compressionParams.Level)))
+		opts := []zstd.EOption{
+			zstd.WithEncoderConcurrency(1),
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(int(compressionParams.Level))),
+		}
+		if compressionParams.DictionaryFile != "" {
+			dict, err := os.ReadFile(filepath.Clean(compressionParams.DictionaryFile))
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to load the zstd dictionary: %w", err)
+			}
+			opts = append(opts, zstd.WithEncoderDict(dict))
+			// The dictionary is validated once, the writers created by the pool can't fail on it.
+			if _, err = zstd.NewWriter(nil, opts...); err != nil {
+				return nil, "", fmt.Errorf("failed to load the zstd dictionary %q: %w", compressionParams.DictionaryFile, err)
+			}
+			info, err := zstd.InspectDictionary(dict)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to load the zstd dictionary %q: %w", compressionParams.DictionaryFile, err)
+			}
+			contentEncoding = zstdDictionaryEncoding(info.ID())
+		}
 		return func() writeCloserReset {
-			zw, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), level)
+			zw, _ := zstd.NewWriter(nil, opts...)
 			return zw
-		}, nil
+		}, contentEncoding, nil
 	case configcompression.TypeZlib, configcompression.TypeDeflate:
 		return func() writeCloserReset {
 			w, _ := zlib.NewWriterLevel(nil, int(compressionParams.Level))
 			return w
-		}, nil
+		}, contentEncoding, nil
 	case configcompression.TypeLz4:
 		return func() writeCloserReset {
 			lz := lz4.NewWriter(nil)
 			_ = lz.Apply(lz4.ConcurrencyOption(1))
 			return lz
-		}, nil
+		}, contentEncoding, nil
 	}
-	return nil, errors.New("unsupported compression type")
+	return nil, "", errors.New("unsupported compression type")
 }
 
 func (p *compressor) compress(buf *bytes.Buffer, body io.ReadCloser) error {
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// HTTP3Config defines the HTTP/3 settings of a client or a server. HTTP/3 runs over QUIC, which always
+// uses TLS, configured by the "tls" settings.
+type HTTP3Config struct {
+	// MaxIdleTimeout is the maximum duration a QUIC connection can stay idle before being closed.
+	// Default is 30 seconds.
+	MaxIdleTimeout time.Duration `mapstructure:"max_idle_timeout,omitempty"`
+
+	// KeepAlivePeriod is the period of the keep-alive packets keeping the idle QUIC connections open,
+	// e.g. through NATs. Default is 0, the keep-alive packets are not sent.
+	KeepAlivePeriod time.Duration `mapstructure:"keep_alive_period,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (hc *HTTP3Config) Validate() error {
+	if hc.MaxIdleTimeout < 0 {
+		return fmt.Errorf("invalid max_idle_timeout value: %v", hc.MaxIdleTimeout)
+	}
+	if hc.KeepAlivePeriod < 0 {
+		return fmt.Errorf("invalid keep_alive_period value: %v", hc.KeepAlivePeriod)
+	}
+	return nil
+}
+
+func (hc *HTTP3Config) quicConfig() *quic.Config {
+	return &quic.Config{
+		MaxIdleTimeout:  hc.MaxIdleTimeout,
+		KeepAlivePeriod: hc.KeepAlivePeriod,
+	}
+}
+
+// newHTTP3Transport returns the transport sending the requests with HTTP/3.
+func (hc *HTTP3Config) newHTTP3Transport(tlsCfg *tls.Config) *http3.Transport {
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{}
+	}
+	return &http3.Transport{
+		TLSClientConfig: tlsCfg,
+		QUICConfig:      hc.quicConfig(),
+		// Every connection uses its own UDP socket, closed with the connection, so that closing the idle
+		// connections releases all the resources of the transport.
+		Dial: quic.DialAddrEarly,
+	}
+}
+
+// HTTP3Server serves HTTP/3 on a UDP socket, created by [ServerConfig.ToHTTP3Server].
+type HTTP3Server struct {
+	server *http3.Server
+	conn   net.PacketConn
+}
+
+// Addr returns the UDP address the server listens on.
+func (s *HTTP3Server) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// Serve serves HTTP/3 until the server is shut down, it then returns [http.ErrServerClosed].
+func (s *HTTP3Server) Serve() error {
+	return s.server.Serve(s.conn)
+}
+
+// Shutdown gracefully shuts down the server, waiting for the requests in progress to complete until the
+// context is done.
+func (s *HTTP3Server) Shutdown(ctx context.Context) error {
+	return errors.Join(s.server.Shutdown(ctx), s.conn.Close())
+}
+
+// ToHTTP3Server creates the HTTP/3 server listening on the UDP port of the endpoint, serving the handler of
+// the given server created by [ServerConfig.ToServer]. It returns nil if HTTP/3 is not enabled.
+// The handler of the given server is updated to advertise the HTTP/3 server to the HTTP/1.1 and HTTP/2
+// clients with the Alt-Svc header.
+func (sc *ServerConfig) ToHTTP3Server(ctx context.Context, server *http.Server) (*HTTP3Server, error) {
+	if !sc.HTTP3.HasValue() {
+		return nil, nil
+	}
+	if !sc.TLS.HasValue() {
+		return nil, errors.New("HTTP/3 requires TLS to be configured")
+	}
+	var network string
+	switch sc.NetAddr.Transport {
+	case confignet.TransportTypeTCP, confignet.TransportTypeTCP4, confignet.TransportTypeTCP6:
+		network = "udp" + string(sc.NetAddr.Transport)[len("tcp"):]
+	default:
+		return nil, fmt.Errorf("HTTP/3 is not supported by the %q transport", sc.NetAddr.Transport)
+	}
+	tlsCfg, err := sc.TLS.Get().LoadTLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := (&net.ListenConfig{}).ListenPacket(ctx, network, sc.NetAddr.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	h3 := &http3.Server{
+		Handler:     server.Handler,
+		TLSConfig:   tlsCfg,
+		QUICConfig:  sc.HTTP3.Get().quicConfig(),
+		IdleTimeout: sc.IdleTimeout,
+		Port:        conn.LocalAddr().(*net.UDPAddr).Port,
+	}
+
+	handler := server.Handler
+	server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The header is only set once the HTTP/3 server is listening.
+		_ = h3.SetQUICHeaders(w.Header())
+		handler.ServeHTTP(w, r)
+	})
+	return &HTTP3Server{server: h3, conn: conn}, nil
+}
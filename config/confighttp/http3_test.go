@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var (
+	http3ServerTLS = configtls.ServerConfig{
+		Config: configtls.Config{
+			CertFile: filepath.Join("testdata", "server.crt"),
+			KeyFile:  filepath.Join("testdata", "server.key"),
+		},
+	}
+	http3ClientTLS = configtls.ClientConfig{
+		Config: configtls.Config{
+			CAFile: filepath.Join("testdata", "ca.crt"),
+		},
+		ServerName: "localhost",
+	}
+)
+
+func TestHTTP3Server(t *testing.T) {
+	sc := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		TLS:   configoptional.Some(http3ServerTLS),
+		HTTP3: configoptional.Some(HTTP3Config{MaxIdleTimeout: time.Minute}),
+	}
+	ln, err := sc.ToListener(t.Context())
+	require.NoError(t, err)
+	s, err := sc.ToServer(t.Context(), nil, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, errRead := io.ReadAll(r.Body)
+		assert.NoError(t, errRead)
+		_, errWrite := fmt.Fprintf(w, "%s %s", r.Proto, body)
+		assert.NoError(t, errWrite)
+	}))
+	require.NoError(t, err)
+	h3, err := sc.ToHTTP3Server(t.Context(), s)
+	require.NoError(t, err)
+	require.NotNil(t, h3)
+
+	go func() { _ = s.Serve(ln) }()
+	h3Done := make(chan error)
+	go func() { h3Done <- h3.Serve() }()
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+		require.NoError(t, h3.Shutdown(context.Background()))
+		assert.ErrorIs(t, <-h3Done, http.ErrServerClosed)
+	})
+
+	// The HTTP/3 server is advertised to the HTTP/2 clients.
+	cc := &ClientConfig{
+		Endpoint:          "https://" + ln.Addr().String(),
+		TLS:               http3ClientTLS,
+		ForceAttemptHTTP2: true,
+	}
+	client, err := cc.ToClient(t.Context(), nil, nilProvidersSettings)
+	require.NoError(t, err)
+	defer client.CloseIdleConnections()
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		resp, errResp := client.Post(cc.Endpoint, "text/plain", strings.NewReader("h2"))
+		require.NoError(tt, errResp)
+		defer resp.Body.Close()
+		assert.Equal(tt, fmt.Sprintf(`h3=":%d"; ma=2592000`, h3.Addr().(*net.UDPAddr).Port), resp.Header.Get("Alt-Svc"))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cc = &ClientConfig{
+		Endpoint:    "https://" + h3.Addr().String(),
+		TLS:         http3ClientTLS,
+		Headers:     configopaque.MapList{{Name: "X-Test", Value: "h3"}},
+		Compression: configcompression.TypeGzip,
+		HTTP3:       configoptional.Some(HTTP3Config{}),
+	}
+	require.NoError(t, cc.Validate())
+	client, err = cc.ToClient(t.Context(), nil, nilProvidersSettings)
+	require.NoError(t, err)
+	defer client.CloseIdleConnections()
+	resp, err := client.Post(cc.Endpoint, "text/plain", strings.NewReader("h3"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "HTTP/3.0 h3", string(body))
+}
+
+func TestHTTP3ServerDisabled(t *testing.T) {
+	sc := NewDefaultServerConfig()
+	h3, err := sc.ToHTTP3Server(t.Context(), &http.Server{})
+	require.NoError(t, err)
+	assert.Nil(t, h3)
+}
+
+func TestHTTP3ServerErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		sc          ServerConfig
+		expectedErr string
+	}{
+		{
+			name: "no TLS",
+			sc: ServerConfig{
+				NetAddr: confignet.AddrConfig{Endpoint: "localhost:0", Transport: confignet.TransportTypeTCP},
+				HTTP3:   configoptional.Some(HTTP3Config{}),
+			},
+			expectedErr: "HTTP/3 requires TLS to be configured",
+		},
+		{
+			name: "unix transport",
+			sc: ServerConfig{
+				NetAddr: confignet.AddrConfig{Endpoint: "/tmp/otlp.sock", Transport: confignet.TransportTypeUnix},
+				TLS:     configoptional.Some(http3ServerTLS),
+				HTTP3:   configoptional.Some(HTTP3Config{}),
+			},
+			expectedErr: `HTTP/3 is not supported by the "unix" transport`,
+		},
+		{
+			name: "invalid TLS",
+			sc: ServerConfig{
+				NetAddr: confignet.AddrConfig{Endpoint: "localhost:0", Transport: confignet.TransportTypeTCP},
+				TLS: configoptional.Some(configtls.ServerConfig{
+					Config: configtls.Config{CertFile: "/doesnt/exist", KeyFile: "/doesnt/exist"},
+				}),
+				HTTP3: configoptional.Some(HTTP3Config{}),
+			},
+			expectedErr: "failed to load TLS config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.sc.ToHTTP3Server(t.Context(), &http.Server{Handler: http.NotFoundHandler()})
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestHTTP3ConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ClientConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			cfg:  ClientConfig{Endpoint: "https://localhost:4318", HTTP3: configoptional.Some(HTTP3Config{})},
+		},
+		{
+			name:        "proxy",
+			cfg:         ClientConfig{Endpoint: "https://localhost:4318", ProxyURL: "http://proxy:8080", HTTP3: configoptional.Some(HTTP3Config{})},
+			expectedErr: errors.New("proxy_url is not supported with HTTP/3"),
+		},
+		{
+			name:        "http endpoint",
+			cfg:         ClientConfig{Endpoint: "http://localhost:4318", HTTP3: configoptional.Some(HTTP3Config{})},
+			expectedErr: errors.New("HTTP/3 requires an https endpoint"),
+		},
+		{
+			name: "negative idle timeout",
+			cfg: ClientConfig{
+				Endpoint: "https://localhost:4318",
+				HTTP3:    configoptional.Some(HTTP3Config{MaxIdleTimeout: -time.Second}),
+			},
+			expectedErr: errors.New("invalid max_idle_timeout value: -1s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if err == nil {
+				err = tt.cfg.HTTP3.Get().Validate()
+			}
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
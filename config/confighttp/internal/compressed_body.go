@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/config/confighttp/internal"
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+)
+
+type compressedBodyKey struct{}
+
+// CompressedBody is the body of a request as received, before its decompression by the server. It counts the
+// bytes read from the body.
+type CompressedBody struct {
+	// ContentLength is the Content-Length of the compressed body, -1 if it is unknown.
+	ContentLength int64
+
+	body      io.ReadCloser
+	bytesRead atomic.Int64
+}
+
+// NewCompressedBody wraps the body of a request, whose Content-Length is contentLength.
+func NewCompressedBody(body io.ReadCloser, contentLength int64) *CompressedBody {
+	return &CompressedBody{ContentLength: contentLength, body: body}
+}
+
+func (b *CompressedBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.bytesRead.Add(int64(n))
+	return n, err
+}
+
+func (b *CompressedBody) Close() error {
+	return b.body.Close()
+}
+
+// BytesRead returns the number of compressed bytes read so far.
+func (b *CompressedBody) BytesRead() int64 {
+	return b.bytesRead.Load()
+}
+
+// ContextWithCompressedBody returns a context carrying the compressed body of its request.
+func ContextWithCompressedBody(ctx context.Context, body *CompressedBody) context.Context {
+	return context.WithValue(ctx, compressedBodyKey{}, body)
+}
+
+// CompressedBodyFromContext returns the compressed body of the request of the context, if it was decompressed.
+func CompressedBodyFromContext(ctx context.Context) (*CompressedBody, bool) {
+	body, ok := ctx.Value(compressedBodyKey{}).(*CompressedBody)
+	return body, ok
+}
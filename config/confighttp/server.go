@@ -8,6 +8,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"slices"
@@ -23,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp/internal"
 	"go.opentelemetry.io/collector/config/configmiddleware"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -64,6 +66,10 @@ type ServerConfig struct {
 	// CompressionAlgorithms configures the list of compression algorithms the server can accept. Default: ["", "gzip", "zstd", "zlib", "snappy", "deflate"]
 	CompressionAlgorithms []string `mapstructure:"compression_algorithms,omitempty"`
 
+	// CompressionDictionaryFiles are the paths of the zstd dictionaries used to decompress the payloads of
+	// the clients configured with "compression_params::dictionary_file".
+	CompressionDictionaryFiles []string `mapstructure:"compression_dictionary_files,omitempty"`
+
 	// ReadTimeout is the maximum duration for reading the entire
 	// request, including the body. A zero or negative value means
 	// there will be no timeout.
@@ -103,6 +109,10 @@ type ServerConfig struct {
 	// KeepAlivesEnabled controls whether HTTP keep-alives are enabled.
 	// By default, keep-alives are always enabled. Only very resource-constrained environments should disable them.
 	KeepAlivesEnabled bool `mapstructure:"keep_alives_enabled,omitempty"`
+
+	// HTTP3 enables serving HTTP/3 on the UDP port of the endpoint, alongside HTTP/1.1 and HTTP/2.
+	// It requires TLS to be configured. This setting is experimental.
+	HTTP3 configoptional.Optional[HTTP3Config] `mapstructure:"http3,omitempty"`
 }
 
 // NewDefaultServerConfig returns ServerConfig type object with default values.
@@ -215,12 +225,23 @@ func (sc *ServerConfig) ToServer(ctx context.Context, extensions map[component.I
 		}
 	}
 
+	decoders := serverOpts.Decoders
+	if len(sc.CompressionDictionaryFiles) > 0 && slices.Contains(sc.CompressionAlgorithms, string(configcompression.TypeZstd)) {
+		dictDecoders, err := newZstdDictionaryDecoders(sc.CompressionDictionaryFiles)
+		if err != nil {
+			return nil, err
+		}
+		// The decoders provided by the caller take precedence.
+		decoders = dictDecoders
+		maps.Copy(decoders, serverOpts.Decoders)
+	}
+
 	handler = httpContentDecompressor(
 		handler,
 		sc.MaxRequestBodySize,
 		serverOpts.ErrHandler,
 		sc.CompressionAlgorithms,
-		serverOpts.Decoders,
+		decoders,
 	)
 
 	if sc.MaxRequestBodySize > 0 {
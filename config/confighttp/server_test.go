@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
@@ -279,6 +280,54 @@ func TestHTTPServerTLS(t *testing.T) {
 	}
 }
 
+func TestHTTPServerTLSClientInfo(t *testing.T) {
+	sc := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		TLS: configoptional.Some(configtls.ServerConfig{
+			Config: configtls.Config{
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+			ClientCAFile: filepath.Join("testdata", "ca.crt"),
+		}),
+		IncludeMetadata: true,
+	}
+	ln, err := sc.ToListener(t.Context())
+	require.NoError(t, err)
+	startServer(t, sc, ln, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		cl := client.FromContext(r.Context())
+		require.NotNil(t, cl.Auth)
+		subject := "CN=MyCommonName,O=MyOrgName,L=Sydney,ST=Australia,C=AU"
+		assert.Equal(t, subject, cl.Auth.GetAttribute(client.TLSSubjectAttribute))
+		assert.Equal(t, []string{"localhost"}, cl.Auth.GetAttribute(client.TLSDNSNamesAttribute))
+		assert.Equal(t, []string{subject}, cl.Metadata.Get(client.TLSSubjectAttribute))
+		assert.Equal(t, []string{"localhost"}, cl.Metadata.Get(client.TLSDNSNamesAttribute))
+	}))
+
+	cc := &ClientConfig{
+		Endpoint: "https://" + ln.Addr().String(),
+		Headers:  configopaque.MapList{{Name: client.TLSSubjectAttribute, Value: "CN=forged"}},
+		TLS: configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile:   filepath.Join("testdata", "ca.crt"),
+				CertFile: filepath.Join("testdata", "client.crt"),
+				KeyFile:  filepath.Join("testdata", "client.key"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	c, err := cc.ToClient(t.Context(), nil, nilProvidersSettings)
+	require.NoError(t, err)
+	defer c.CloseIdleConnections()
+	resp, err := c.Get(cc.Endpoint)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestHTTPServerTransport(t *testing.T) {
 	if runtime.GOOS == "linux" {
 		t.Run("unix", func(t *testing.T) {
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfighttp // import "go.opentelemetry.io/collector/config/confighttp/xconfighttp"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config/confighttp/internal"
+)
+
+// CompressedBody describes the body of a request as received, before its decompression by the server.
+type CompressedBody struct {
+	body *internal.CompressedBody
+}
+
+// CompressedBodyFromContext returns the compressed body of the request of the context. It is only available to
+// the handlers and middlewares of a server built by confighttp.ServerConfig.ToServer, when the body of the
+// request is compressed.
+func CompressedBodyFromContext(ctx context.Context) (CompressedBody, bool) {
+	body, ok := internal.CompressedBodyFromContext(ctx)
+	return CompressedBody{body: body}, ok
+}
+
+// ContentLength returns the Content-Length of the compressed body, or -1 if it is unknown, e.g. when the
+// request is chunked. The ContentLength of the request is -1 once its body is decompressed.
+func (b CompressedBody) ContentLength() int64 {
+	return b.body.ContentLength
+}
+
+// BytesRead returns the number of compressed bytes read so far from the body of the request.
+func (b CompressedBody) BytesRead() int64 {
+	return b.body.BytesRead()
+}
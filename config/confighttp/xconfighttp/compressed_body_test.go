@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfighttp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestCompressedBodyFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte(strings.Repeat("payload", 1000)))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	compressed := buf.Bytes()
+
+	tests := []struct {
+		name                  string
+		body                  []byte
+		encoding              string
+		expectedOK            bool
+		expectedContentLength int64
+	}{
+		{
+			name:                  "gzip",
+			body:                  compressed,
+			encoding:              "gzip",
+			expectedOK:            true,
+			expectedContentLength: int64(len(compressed)),
+		},
+		{
+			name: "not compressed",
+			body: []byte("payload"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := confighttp.NewDefaultServerConfig()
+			srv, err := sc.ToServer(context.Background(), nil, componenttest.NewNopTelemetrySettings(),
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					body, ok := CompressedBodyFromContext(r.Context())
+					assert.Equal(t, tt.expectedOK, ok)
+					if !ok {
+						return
+					}
+					assert.Equal(t, tt.expectedContentLength, body.ContentLength())
+					_, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.Equal(t, int64(len(compressed)), body.BytesRead())
+				}))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
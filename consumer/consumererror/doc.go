@@ -20,4 +20,8 @@
 // may be done by the component itself, however typically it is done by the original sender, after
 // the receiver in the pipeline returns a response to the sender indicating that the Collector is
 // currently overloaded and the request must be retried.
+//
+// If only a part of the data is rejected, the Partial error records the number of rejected items. The
+// receivers report it to the sender as a partial success. It does not change whether the error is
+// Permanent: wrap it with NewPermanent if the data must not be sent again.
 package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+// Partial is an error that indicates that only a part of the data was rejected, the rest of the data was
+// successfully consumed.
+//
+// Partial should be obtained from a given `error` object using AsPartial.
+type Partial struct {
+	err      error
+	rejected int64
+}
+
+var _ error = Partial{}
+
+// NewPartial wraps an error to indicate that the data was partially rejected. rejected is the number of
+// rejected items: spans, metric data points, log records or profiles depending on the signal.
+//
+// The receivers report the partial errors to their clients as a success, e.g. the OTLP receiver
+// returns them as the partial success of the export response. A partial error is not permanent: wrap it
+// with NewPermanent if the components sending the data must not retry it.
+func NewPartial(err error, rejected int64) error {
+	return Partial{err: err, rejected: rejected}
+}
+
+// Error implements the error interface.
+func (p Partial) Error() string {
+	return p.err.Error()
+}
+
+// Unwrap returns the wrapped error for use by `errors.Is` and `errors.As`.
+func (p Partial) Unwrap() error {
+	return p.err
+}
+
+// Rejected returns the number of rejected items.
+func (p Partial) Rejected() int64 {
+	return p.rejected
+}
+
+// IsPartial checks if an error was wrapped with the NewPartial function, see AsPartial.
+func IsPartial(err error) bool {
+	_, ok := AsPartial(err)
+	return ok
+}
+
+// AsPartial returns the Partial error wrapped by err. If err joins several errors, e.g. the errors of the
+// pipelines of a fanout, it is partial only if all the joined errors are partial: the data was not accepted by a
+// pipeline if any other error is joined. The Partial with the most rejected items is returned in this case.
+func AsPartial(err error) (Partial, bool) {
+	switch e := err.(type) {
+	case Partial:
+		return e, true
+	case interface{ Unwrap() []error }:
+		var partial Partial
+		errs := e.Unwrap()
+		for _, joined := range errs {
+			p, ok := AsPartial(joined)
+			if !ok {
+				return Partial{}, false
+			}
+			if p.rejected >= partial.rejected {
+				partial = p
+			}
+		}
+		return partial, len(errs) > 0
+	case interface{ Unwrap() error }:
+		return AsPartial(e.Unwrap())
+	default:
+		return Partial{}, false
+	}
+}
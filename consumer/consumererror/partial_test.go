@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartial(t *testing.T) {
+	var err error
+	assert.False(t, IsPartial(err))
+
+	origErr := errors.New("2 spans dropped by the filter")
+	err = fmt.Errorf("wrapped: %w", NewPartial(origErr, 2))
+	assert.True(t, IsPartial(err))
+	assert.False(t, IsPermanent(err))
+	assert.True(t, IsPermanent(NewPermanent(err)))
+	require.ErrorIs(t, err, origErr)
+	assert.Equal(t, "wrapped: 2 spans dropped by the filter", err.Error())
+
+	p, ok := AsPartial(err)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), p.Rejected())
+	assert.Equal(t, "2 spans dropped by the filter", p.Error())
+
+	assert.False(t, IsPartial(NewPermanent(origErr)))
+}
+
+func TestPartialJoined(t *testing.T) {
+	// The data was accepted by all the pipelines, with some items rejected.
+	p, ok := AsPartial(errors.Join(NewPartial(errors.New("2 spans dropped"), 2), NewPartial(errors.New("3 spans dropped"), 3)))
+	require.True(t, ok)
+	assert.Equal(t, int64(3), p.Rejected())
+
+	// The data was not accepted by a pipeline.
+	_, ok = AsPartial(errors.Join(NewPartial(errors.New("2 spans dropped"), 2), errors.New("unavailable")))
+	assert.False(t, ok)
+	assert.False(t, IsPartial(fmt.Errorf("wrapped: %w", errors.Join(errors.New("unavailable"), NewPartial(errors.New("2 spans dropped"), 2)))))
+}
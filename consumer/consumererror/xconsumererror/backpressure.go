@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumererror // import "go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
+
+import "errors"
+
+// Backpressure is an error returned by a component whose queue cannot accept more data, carrying the fill level
+// of the queue, so the receivers can tell their clients how long to wait before retrying.
+type Backpressure struct {
+	err  error
+	fill float64
+}
+
+// NewBackpressure wraps an error returned by a queue whose fill level is fill, the ratio of its size to its
+// capacity, clamped between 0 and 1.
+func NewBackpressure(err error, fill float64) error {
+	return Backpressure{err: err, fill: min(max(fill, 0), 1)}
+}
+
+// Error implements the error interface.
+func (b Backpressure) Error() string {
+	return b.err.Error()
+}
+
+// Unwrap returns the wrapped error for use by `errors.Is` and `errors.As`.
+func (b Backpressure) Unwrap() error {
+	return b.err
+}
+
+// Fill returns the fill level of the queue, between 0 and 1.
+func (b Backpressure) Fill() float64 {
+	return b.fill
+}
+
+// BackpressureFill returns the fill level of the queue if the error contains a Backpressure error.
+func BackpressureFill(err error) (float64, bool) {
+	var b Backpressure
+	if !errors.As(err, &b) {
+		return 0, false
+	}
+	return b.fill, true
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestBackpressure(t *testing.T) {
+	errFull := errors.New("queue is full")
+	err := fmt.Errorf("export failed: %w", NewBackpressure(errFull, 0.75))
+	require.EqualError(t, err, "export failed: queue is full")
+	require.ErrorIs(t, err, errFull)
+
+	fill, ok := BackpressureFill(err)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.75, fill, 1e-9)
+
+	_, ok = BackpressureFill(errFull)
+	assert.False(t, ok)
+	_, ok = BackpressureFill(nil)
+	assert.False(t, ok)
+}
+
+func TestBackpressureClamped(t *testing.T) {
+	fill, ok := BackpressureFill(NewBackpressure(errors.New("full"), 1.5))
+	assert.True(t, ok)
+	assert.InDelta(t, 1, fill, 1e-9)
+
+	fill, ok = BackpressureFill(NewBackpressure(errors.New("empty"), -1))
+	assert.True(t, ok)
+	assert.InDelta(t, 0, fill, 1e-9)
+}
+
+func TestBackpressurePermanent(t *testing.T) {
+	err := NewBackpressure(consumererror.NewPermanent(errors.New("rejected")), 1)
+	assert.True(t, consumererror.IsPermanent(err))
+}
@@ -0,0 +1,13 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer // import "go.opentelemetry.io/collector/consumer/xconsumer"
+
+// Drainable is an optional interface of the components holding the data they consumed before passing it on,
+// e.g. in a queue or a batch. When a drain timeout is configured, the service waits for them to drain before
+// shutting them down, after the components sending data to them are shut down.
+type Drainable interface {
+	// Pending returns the amount of data held by the component, in the unit of its choice, e.g. the size of its
+	// queue. The component is drained when it returns 0.
+	Pending() int64
+}
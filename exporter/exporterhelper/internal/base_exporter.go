@@ -15,13 +15,17 @@ import (
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
+var _ xconsumer.Drainable = (*BaseExporter)(nil)
+
 // Option apply changes to BaseExporter.
 type Option func(*BaseExporter) error
 
@@ -38,8 +42,12 @@ type BaseExporter struct {
 	// Chain of senders that the exporter helper applies before passing the data to the actual exporter.
 	// The data is handled by each sender in the respective order starting from the QueueBatch.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
-	QueueSender sender.Sender[request.Request]
-	RetrySender sender.Sender[request.Request]
+	QueueSender          sender.Sender[request.Request]
+	DeadLetterSender     sender.Sender[request.Request]
+	CircuitBreakerSender sender.Sender[request.Request]
+	RetrySender          sender.Sender[request.Request]
+	RateLimitSender      sender.Sender[request.Request]
+	ConcurrencySender    sender.Sender[request.Request]
 
 	firstSender sender.Sender[request.Request]
 
@@ -75,12 +83,48 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		be.firstSender = newTimeoutSender(be.timeoutCfg, be.firstSender)
 	}
 
+	var err error
+	// The concurrency sender is placed before the retry sender, so every attempt is limited and observed.
+	if be.queueCfg.HasValue() && be.queueCfg.Get().AdaptiveConcurrency.HasValue() {
+		be.ConcurrencySender, err = newConcurrencySender(set, *be.queueCfg.Get().AdaptiveConcurrency.Get(), be.queueCfg.Get().NumConsumers, be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.ConcurrencySender
+	}
+
+	// The rate limit sender is placed before the concurrency sender, so the rate limiting is not considered
+	// a sign of overload of the backend.
+	if be.queueCfg.HasValue() && be.queueCfg.Get().RateLimit.HasValue() {
+		// Without retries, the throttling error would drop the data.
+		if !be.queueCfg.Get().RateLimit.Get().BlockOnLimit && !be.retryCfg.Enabled {
+			return nil, errors.New("`sending_queue::rate_limit` requires `block_on_limit` when `retry_on_failure` is disabled")
+		}
+		be.RateLimitSender, err = newRateLimitSender(set, *be.queueCfg.Get().RateLimit.Get(), be.queueCfg.Get().Sizer, be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.RateLimitSender
+	}
+
+	// The circuit breaker observes every attempt and stops the retries when the circuit opens, then waits for the
+	// circuit to allow the exports again before the retries, so the requests stay in the queue meanwhile.
+	var cb *circuitBreaker
+	if be.queueCfg.HasValue() && be.queueCfg.Get().CircuitBreaker.HasValue() {
+		cb = newCircuitBreaker(*be.queueCfg.Get().CircuitBreaker.Get(), set.Logger)
+		be.firstSender = newCircuitAttemptSender(cb, be.firstSender)
+	}
+
 	if be.retryCfg.Enabled {
 		be.RetrySender = newRetrySender(be.retryCfg, set, be.firstSender)
 		be.firstSender = be.RetrySender
 	}
 
-	var err error
+	if cb != nil {
+		be.CircuitBreakerSender = newCircuitBreakerSender(cb, be.firstSender)
+		be.firstSender = be.CircuitBreakerSender
+	}
+
 	be.firstSender, err = newObsReportSender(set, signal, be.ExtraAttrs, be.firstSender)
 	if err != nil {
 		return nil, err
@@ -98,6 +142,22 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 			ID:        set.ID,
 			Telemetry: set.TelemetrySettings,
 		}
+		// The dead letter sender is placed right after the queue, so only the data that otherwise would be dropped
+		// by the queue consumers is stored.
+		if be.queueCfg.Get().DeadLetter.HasValue() {
+			var encryption *persistentqueue.EncryptionSettings
+			if be.queueCfg.Get().Encryption.HasValue() {
+				if encryption, err = queuebatch.NewEncryptionSettings(*be.queueCfg.Get().Encryption.Get()); err != nil {
+					return nil, err
+				}
+			}
+			be.DeadLetterSender, err = newDeadLetterSender(qSet, *be.queueCfg.Get().DeadLetter.Get(), encryption,
+				queuebatch.NewCompressionSettings(*be.queueCfg.Get()), be.firstSender)
+			if err != nil {
+				return nil, err
+			}
+			be.firstSender = be.DeadLetterSender
+		}
 		be.QueueSender, err = NewQueueSender(qSet, *be.queueCfg.Get(), be.ExportFailureMessage, be.firstSender)
 		if err != nil {
 			return nil, err
@@ -127,6 +187,20 @@ func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	// Then start the circuit breaker, so it can report the status of the exporter.
+	if be.CircuitBreakerSender != nil {
+		if err := be.CircuitBreakerSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	// Then start the dead letter queue, before any request can be consumed from the QueueBatch.
+	if be.DeadLetterSender != nil {
+		if err := be.DeadLetterSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// Last start the QueueBatch.
 	if be.QueueSender != nil {
 		return be.QueueSender.Start(ctx, host)
@@ -143,15 +217,45 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 		err = multierr.Append(err, be.RetrySender.Shutdown(ctx))
 	}
 
+	// Also shutdown the circuit breaker sender, so the queue sender does not wait for the circuit to close.
+	if be.CircuitBreakerSender != nil {
+		err = multierr.Append(err, be.CircuitBreakerSender.Shutdown(ctx))
+	}
+
 	// Then shutdown the queue sender.
 	if be.QueueSender != nil {
 		err = multierr.Append(err, be.QueueSender.Shutdown(ctx))
 	}
 
+	// Then shutdown the dead letter sender, after the queue is drained.
+	if be.DeadLetterSender != nil {
+		err = multierr.Append(err, be.DeadLetterSender.Shutdown(ctx))
+	}
+
+	// Then shutdown the rate limit and concurrency senders, once no more requests are exported.
+	if be.RateLimitSender != nil {
+		err = multierr.Append(err, be.RateLimitSender.Shutdown(ctx))
+	}
+	if be.ConcurrencySender != nil {
+		err = multierr.Append(err, be.ConcurrencySender.Shutdown(ctx))
+	}
+
 	// Last shutdown the wrapped exporter itself.
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
 
+// Pending returns the size of the queue, including the requests being batched or exported, or 0 without queue.
+// The persistent queues are not drained, their data is kept in the storage and sent once restarted.
+func (be *BaseExporter) Pending() int64 {
+	if be.queueCfg.HasValue() && be.queueCfg.Get().StorageID != nil {
+		return 0
+	}
+	if qb, ok := be.QueueSender.(*queuebatch.QueueBatch); ok {
+		return qb.Size()
+	}
+	return 0
+}
+
 // WithStart overrides the default Start function for an exporter.
 // The default start function does nothing and always returns nil.
 func WithStart(start component.StartFunc) Option {
@@ -219,6 +323,9 @@ func WithQueueBatch(cfg configoptional.Optional[queuebatch.Config], set queuebat
 		if cfg.Get().StorageID != nil && set.Encoding == nil {
 			return errors.New("`Settings.Encoding` must not be nil when persistent queue is enabled")
 		}
+		if cfg.Get().DeadLetter.HasValue() && cfg.Get().DeadLetter.Get().StorageID != (component.ID{}) && set.Encoding == nil {
+			return errors.New("`Settings.Encoding` must not be nil when dead letter queue is enabled")
+		}
 		// Automatically configure partitioner if MetadataKeys is set
 		if cfg.Get().Batch.HasValue() && len(cfg.Get().Batch.Get().Partition.MetadataKeys) > 0 {
 			if set.Partitioner != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,10 +21,12 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/pipeline"
 )
@@ -267,6 +270,58 @@ func TestQueueRetryWithDisabledQueue(t *testing.T) {
 	}
 }
 
+func TestBaseExporterPending(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, noopExport)
+	require.NoError(t, err)
+	assert.Zero(t, be.Pending())
+
+	release := make(chan struct{})
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs,
+		func(context.Context, request.Request) error {
+			<-release
+			return nil
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(NewDefaultQueueConfig())))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	// The requests are pending until they are exported.
+	assert.Equal(t, int64(2), be.Pending())
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return be.Pending() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterPendingPersistentQueue(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	qCfg := NewDefaultQueueConfig()
+	qCfg.StorageID = &storageID
+	release := make(chan struct{})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs,
+		func(context.Context, request.Request) error {
+			<-release
+			return nil
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	// The persistent queue keeps its data in the storage, it is not drained.
+	assert.Zero(t, be.Pending())
+
+	close(release)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
 func errExport(context.Context, request.Request) error {
 	return errors.New("my error")
 }
@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+const (
+	defaultCircuitFailureRatio     = 0.5
+	defaultCircuitMinRequests      = 10
+	defaultCircuitWindow           = time.Minute
+	defaultCircuitOpenDuration     = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker keeps the state of the circuit shared by the circuitBreakerSender and the circuitAttemptSender.
+// The circuit opens when the ratio of failed export attempts during the window reaches the failure ratio. After the
+// open duration, the circuit is half-open and a limited number of probe attempts are allowed: if they all succeed
+// the circuit is closed, otherwise it opens again.
+type circuitBreaker struct {
+	failureRatio     float64
+	minRequests      int
+	window           time.Duration
+	openDuration     time.Duration
+	halfOpenRequests int
+	logger           *zap.Logger
+	// stopped is closed on shutdown, so the requests waiting for the circuit are released.
+	stopped  chan struct{}
+	stopOnce sync.Once
+
+	// mu guards everything declared below.
+	mu          sync.Mutex
+	host        component.Host
+	state       circuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openUntil   time.Time
+	probes      int
+	successes   int
+	// changed is closed and replaced every time the state changes.
+	changed chan struct{}
+}
+
+func newCircuitBreaker(cfg queuebatch.CircuitBreakerConfig, logger *zap.Logger) *circuitBreaker {
+	cb := &circuitBreaker{
+		failureRatio:     cfg.FailureRatio,
+		minRequests:      cfg.MinRequests,
+		window:           cfg.Window,
+		openDuration:     cfg.OpenDuration,
+		halfOpenRequests: cfg.HalfOpenRequests,
+		logger:           logger,
+		stopped:          make(chan struct{}),
+		windowStart:      time.Now(),
+		changed:          make(chan struct{}),
+	}
+	if cb.failureRatio == 0 {
+		cb.failureRatio = defaultCircuitFailureRatio
+	}
+	if cb.minRequests == 0 {
+		cb.minRequests = defaultCircuitMinRequests
+	}
+	if cb.window == 0 {
+		cb.window = defaultCircuitWindow
+	}
+	if cb.openDuration == 0 {
+		cb.openDuration = defaultCircuitOpenDuration
+	}
+	if cb.halfOpenRequests == 0 {
+		cb.halfOpenRequests = defaultCircuitHalfOpenRequests
+	}
+	return cb
+}
+
+// wait blocks until the circuit allows new exports.
+func (cb *circuitBreaker) wait(ctx context.Context) error {
+	cb.mu.Lock()
+	for {
+		cb.refreshLocked(time.Now())
+		if cb.state == circuitClosed || (cb.state == circuitHalfOpen && cb.probes < cb.halfOpenRequests) {
+			cb.mu.Unlock()
+			return nil
+		}
+		changed := cb.changed
+		// When half-open, wait for the probes to complete.
+		var timerC <-chan time.Time
+		if cb.state == circuitOpen {
+			timerC = time.After(time.Until(cb.openUntil))
+		}
+		cb.mu.Unlock()
+		select {
+		case <-changed:
+		case <-timerC:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-cb.stopped:
+			// Keep the request in the persistent queue, so it is sent after restart.
+			return experr.NewShutdownErr(errCircuitOpen)
+		}
+		cb.mu.Lock()
+	}
+}
+
+// acquire returns false if the circuit does not allow an export attempt, and whether the attempt is a probe.
+func (cb *circuitBreaker) acquire() (ok, probe bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refreshLocked(time.Now())
+	switch cb.state {
+	case circuitOpen:
+		return false, false
+	case circuitHalfOpen:
+		if cb.probes >= cb.halfOpenRequests {
+			return false, false
+		}
+		cb.probes++
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// record updates the state of the circuit with the result of an export attempt.
+func (cb *circuitBreaker) record(probe bool, err error) {
+	failed := isCircuitFailure(err)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := time.Now()
+	cb.refreshLocked(now)
+	if probe {
+		cb.probes--
+	}
+
+	switch cb.state {
+	case circuitClosed:
+		cb.requests++
+		if failed {
+			cb.failures++
+		}
+		if cb.requests >= cb.minRequests && float64(cb.failures) >= cb.failureRatio*float64(cb.requests) {
+			cb.openLocked(now, err)
+		}
+	case circuitHalfOpen:
+		// Only the probes are considered, the other attempts started before the circuit opened.
+		if !probe {
+			return
+		}
+		if failed {
+			cb.openLocked(now, err)
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.halfOpenRequests {
+			cb.closeLocked(now)
+		}
+	case circuitOpen:
+		// Attempts that started before the circuit opened.
+	}
+}
+
+func (cb *circuitBreaker) refreshLocked(now time.Time) {
+	switch cb.state {
+	case circuitOpen:
+		if !now.Before(cb.openUntil) {
+			cb.logger.Info("Circuit breaker is half-open, probing the backend.")
+			cb.state = circuitHalfOpen
+			cb.probes = 0
+			cb.successes = 0
+			cb.notifyLocked()
+		}
+	case circuitClosed:
+		if now.Sub(cb.windowStart) >= cb.window {
+			cb.windowStart = now
+			cb.requests = 0
+			cb.failures = 0
+		}
+	case circuitHalfOpen:
+	}
+}
+
+func (cb *circuitBreaker) openLocked(now time.Time, err error) {
+	cb.logger.Warn("Circuit breaker opened, exports are stopped.",
+		zap.Error(err), zap.Duration("open_duration", cb.openDuration))
+	cb.state = circuitOpen
+	cb.openUntil = now.Add(cb.openDuration)
+	cb.notifyLocked()
+	if cb.host != nil {
+		componentstatus.ReportStatus(cb.host, componentstatus.NewRecoverableErrorEvent(errors.Join(errCircuitOpen, err)))
+	}
+}
+
+func (cb *circuitBreaker) closeLocked(now time.Time) {
+	cb.logger.Info("Circuit breaker closed, exports are resumed.")
+	cb.state = circuitClosed
+	cb.windowStart = now
+	cb.requests = 0
+	cb.failures = 0
+	cb.notifyLocked()
+	if cb.host != nil {
+		componentstatus.ReportStatus(cb.host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+}
+
+func (cb *circuitBreaker) notifyLocked() {
+	close(cb.changed)
+	cb.changed = make(chan struct{})
+}
+
+// isCircuitFailure returns true if the error may indicate that the backend is down. Invalid data and the local
+// rate limiting are not failures of the backend.
+func isCircuitFailure(err error) bool {
+	return err != nil && !consumererror.IsPermanent(err) && !errors.Is(err, errRateLimited)
+}
+
+// circuitBreakerSender is a requestSender that waits for the circuit to allow exports before sending the request,
+// and sends the request again if the circuit opened while the request was retried.
+type circuitBreakerSender struct {
+	cb   *circuitBreaker
+	next sender.Sender[request.Request]
+}
+
+func newCircuitBreakerSender(cb *circuitBreaker, next sender.Sender[request.Request]) *circuitBreakerSender {
+	return &circuitBreakerSender{cb: cb, next: next}
+}
+
+func (cs *circuitBreakerSender) Start(_ context.Context, host component.Host) error {
+	cs.cb.mu.Lock()
+	defer cs.cb.mu.Unlock()
+	cs.cb.host = host
+	return nil
+}
+
+func (cs *circuitBreakerSender) Shutdown(context.Context) error {
+	cs.cb.stopOnce.Do(func() { close(cs.cb.stopped) })
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cs *circuitBreakerSender) Send(ctx context.Context, req request.Request) error {
+	for {
+		if err := cs.cb.wait(ctx); err != nil {
+			return err
+		}
+		err := cs.next.Send(ctx, req)
+		if !errors.Is(err, errCircuitOpen) {
+			return err
+		}
+	}
+}
+
+// circuitAttemptSender is a requestSender that records the result of every export attempt, and fails fast when the
+// circuit is open. The error is permanent, so the retries are stopped and the circuitBreakerSender waits instead.
+type circuitAttemptSender struct {
+	component.StartFunc
+	component.ShutdownFunc
+	cb   *circuitBreaker
+	next sender.Sender[request.Request]
+}
+
+func newCircuitAttemptSender(cb *circuitBreaker, next sender.Sender[request.Request]) sender.Sender[request.Request] {
+	return &circuitAttemptSender{cb: cb, next: next}
+}
+
+// Send implements the requestSender interface
+func (as *circuitAttemptSender) Send(ctx context.Context, req request.Request) error {
+	ok, probe := as.cb.acquire()
+	if !ok {
+		return consumererror.NewPermanent(errCircuitOpen)
+	}
+	err := as.next.Send(ctx, req)
+	as.cb.record(probe, err)
+	return err
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// statusHost records the status events reported by the component.
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []componentstatus.Status
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev.Status())
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]componentstatus.Status(nil), h.events...)
+}
+
+// newCircuitBreakerChain returns the circuit breaker senders as they are chained by the BaseExporter, without retries.
+func newCircuitBreakerChain(t *testing.T, cfg queuebatch.CircuitBreakerConfig, exportErr *atomic.Pointer[error], attempts *atomic.Int64) (*circuitBreakerSender, *statusHost) {
+	cb := newCircuitBreaker(cfg, zap.NewNop())
+	cs := newCircuitBreakerSender(cb, newCircuitAttemptSender(cb, sender.NewSender(func(context.Context, request.Request) error {
+		attempts.Add(1)
+		if err := exportErr.Load(); err != nil {
+			return *err
+		}
+		return nil
+	})))
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, cs.Start(context.Background(), host))
+	return cs, host
+}
+
+func TestCircuitBreaker_OpenHalfOpenClose(t *testing.T) {
+	var exportErr atomic.Pointer[error]
+	var attempts atomic.Int64
+	cs, host := newCircuitBreakerChain(t, queuebatch.CircuitBreakerConfig{
+		FailureRatio: 0.5, MinRequests: 4, OpenDuration: 50 * time.Millisecond,
+	}, &exportErr, &attempts)
+
+	// Below the failure ratio, the circuit stays closed.
+	unavailable := errors.New("unavailable")
+	require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	exportErr.Store(&unavailable)
+	require.ErrorIs(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), unavailable)
+	assert.Equal(t, circuitClosed, cs.cb.state)
+	require.ErrorIs(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), unavailable)
+	assert.Equal(t, circuitOpen, cs.cb.state)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// While open, the requests wait for the circuit to be half-open without any export attempt.
+	attempts.Store(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cs.Send(ctx, &requesttest.FakeRequest{Items: 1}), context.DeadlineExceeded)
+	assert.Zero(t, attempts.Load())
+
+	// The probe succeeds and closes the circuit.
+	exportErr.Store(nil)
+	start := time.Now()
+	require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Positive(t, time.Since(start))
+	assert.EqualValues(t, 1, attempts.Load())
+	assert.Equal(t, circuitClosed, cs.cb.state)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestCircuitBreaker_PermanentErrorsIgnored(t *testing.T) {
+	var exportErr atomic.Pointer[error]
+	var attempts atomic.Int64
+	cs, _ := newCircuitBreakerChain(t, queuebatch.CircuitBreakerConfig{MinRequests: 2}, &exportErr, &attempts)
+
+	permanent := consumererror.NewPermanent(errors.New("bad data"))
+	exportErr.Store(&permanent)
+	for range 5 {
+		require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, circuitClosed, cs.cb.state)
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestCircuitBreaker_ShutdownKeepsRequest(t *testing.T) {
+	var exportErr atomic.Pointer[error]
+	var attempts atomic.Int64
+	cs, _ := newCircuitBreakerChain(t, queuebatch.CircuitBreakerConfig{MinRequests: 1, OpenDuration: time.Hour}, &exportErr, &attempts)
+
+	unavailable := errors.New("unavailable")
+	exportErr.Store(&unavailable)
+	require.ErrorIs(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), unavailable)
+	assert.Equal(t, circuitOpen, cs.cb.state)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	}()
+	require.NoError(t, cs.Shutdown(context.Background()))
+	// A shutdown error keeps the request in the persistent queue.
+	err := <-errCh
+	require.ErrorIs(t, err, errCircuitOpen)
+	assert.True(t, experr.IsShutdownErr(err))
+	assert.EqualValues(t, 1, attempts.Load())
+	// Shutting down again is a no-op.
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	cb := newCircuitBreaker(queuebatch.CircuitBreakerConfig{MinRequests: 1, OpenDuration: time.Millisecond, HalfOpenRequests: 2}, zap.NewNop())
+	cb.record(false, errors.New("unavailable"))
+	assert.Equal(t, circuitOpen, cb.state)
+	ok, _ := cb.acquire()
+	assert.False(t, ok)
+
+	time.Sleep(2 * time.Millisecond)
+	ok1, probe1 := cb.acquire()
+	ok2, probe2 := cb.acquire()
+	ok3, _ := cb.acquire()
+	assert.True(t, ok1 && probe1 && ok2 && probe2)
+	assert.False(t, ok3)
+
+	// A late result of an attempt that started before the circuit opened is ignored.
+	cb.record(false, errors.New("unavailable"))
+	cb.record(true, nil)
+	assert.Equal(t, circuitHalfOpen, cb.state)
+	cb.record(true, nil)
+	assert.Equal(t, circuitClosed, cb.state)
+}
+
+func TestBaseExporterCircuitBreaker(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.CircuitBreaker = configoptional.Some(queuebatch.CircuitBreakerConfig{MinRequests: 1, OpenDuration: 20 * time.Millisecond})
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	var attempts atomic.Int64
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics,
+		func(context.Context, request.Request) error {
+			// The first attempt opens the circuit, then the retries are stopped until the circuit is half-open.
+			if attempts.Add(1) == 1 {
+				return errors.New("unavailable")
+			}
+			return nil
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithRetry(rCfg),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NotNil(t, be.CircuitBreakerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Eventually(t, func() bool { return attempts.Load() == 2 }, time.Second, time.Millisecond)
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	assert.Nil(t, be.CircuitBreakerSender)
+}
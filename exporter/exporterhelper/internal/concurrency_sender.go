@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+const defaultConcurrencyDecreaseRatio = 0.5
+
+// concurrencySender is a requestSender that limits the number of concurrent exports. The limit is adjusted using
+// an additive increase, multiplicative decrease (AIMD) algorithm: every successful export below the latency threshold
+// increases the limit by 1/limit (so by 1 for every "limit" successful exports), and every sign of overload
+// (throttling error, timeout or slow export) multiplies the limit by the decrease ratio.
+type concurrencySender struct {
+	component.StartFunc
+	minLimit         float64
+	maxLimit         float64
+	latencyThreshold time.Duration
+	decreaseRatio    float64
+	tb               *metadata.TelemetryBuilder
+	next             sender.Sender[request.Request]
+
+	// mu guards everything declared below.
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// lastDecrease is used to decrease the limit only once for the exports that were in-flight at the same time.
+	lastDecrease time.Time
+	// changed is closed and replaced every time a slot may be available.
+	changed chan struct{}
+}
+
+func newConcurrencySender(
+	set exporter.Settings,
+	cfg queuebatch.AdaptiveConcurrencyConfig,
+	maxConcurrency int,
+	next sender.Sender[request.Request],
+) (*concurrencySender, error) {
+	minLimit := max(cfg.MinConcurrency, 1)
+	decreaseRatio := cfg.DecreaseRatio
+	if decreaseRatio == 0 {
+		decreaseRatio = defaultConcurrencyDecreaseRatio
+	}
+	cs := &concurrencySender{
+		minLimit:         float64(minLimit),
+		maxLimit:         float64(max(maxConcurrency, minLimit)),
+		latencyThreshold: cfg.LatencyThreshold,
+		decreaseRatio:    decreaseRatio,
+		next:             next,
+		changed:          make(chan struct{}),
+	}
+	// Start with the maximum concurrency, so the exporter behaves as without adaptive concurrency until an overload.
+	cs.limit = cs.maxLimit
+
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	attrs := metric.WithAttributeSet(attribute.NewSet(attribute.String(ExporterKey, set.ID.String())))
+	err = tb.RegisterExporterConcurrencyLimitCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(int64(cs.currentLimit()), attrs)
+		return nil
+	})
+	if err != nil {
+		tb.Shutdown()
+		return nil, err
+	}
+	cs.tb = tb
+	return cs, nil
+}
+
+func (cs *concurrencySender) Shutdown(context.Context) error {
+	cs.tb.Shutdown()
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cs *concurrencySender) Send(ctx context.Context, req request.Request) error {
+	if err := cs.acquire(ctx); err != nil {
+		return err
+	}
+	start := time.Now()
+	err := cs.next.Send(ctx, req)
+	cs.release(start, err)
+	return err
+}
+
+// acquire waits until the number of in-flight exports is below the current limit.
+func (cs *concurrencySender) acquire(ctx context.Context) error {
+	cs.mu.Lock()
+	for cs.inFlight >= int(cs.limit) {
+		changed := cs.changed
+		cs.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		cs.mu.Lock()
+	}
+	cs.inFlight++
+	cs.mu.Unlock()
+	return nil
+}
+
+// release frees the slot and adjusts the limit based on the export result.
+func (cs *concurrencySender) release(start time.Time, err error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.inFlight--
+
+	switch {
+	case isOverloadErr(err) || (cs.latencyThreshold > 0 && time.Since(start) > cs.latencyThreshold):
+		// Exports started before the last decrease observed the same overload, do not decrease again.
+		if start.After(cs.lastDecrease) {
+			cs.limit = max(cs.limit*cs.decreaseRatio, cs.minLimit)
+			cs.lastDecrease = time.Now()
+		}
+	case err == nil:
+		cs.limit = min(cs.limit+1/cs.limit, cs.maxLimit)
+	}
+
+	close(cs.changed)
+	cs.changed = make(chan struct{})
+}
+
+func (cs *concurrencySender) currentLimit() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return int(cs.limit)
+}
+
+// isOverloadErr returns true if the error indicates that the destination is overloaded. The OTLP/HTTP errors are
+// converted to gRPC statuses, so HTTP 429 and 503 are reported as ResourceExhausted and Unavailable.
+func isOverloadErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.As(err, &throttleRetry{}) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded:
+			return true
+		}
+	}
+	return false
+}
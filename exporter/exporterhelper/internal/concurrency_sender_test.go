@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestConcurrencySender_AIMD(t *testing.T) {
+	var exportErr error
+	cs, err := newConcurrencySender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 2}, 10,
+		sender.NewSender(func(context.Context, request.Request) error { return exportErr }))
+	require.NoError(t, err)
+	require.NoError(t, cs.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, 10, cs.currentLimit())
+
+	// Throttling halves the limit.
+	exportErr = NewThrottleRetry(errors.New("throttled"), time.Second)
+	require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 5, cs.currentLimit())
+
+	// Not below the minimum.
+	exportErr = status.Error(codes.ResourceExhausted, "slow down")
+	require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cs.currentLimit())
+
+	// Other errors do not change the limit.
+	exportErr = consumererror.NewPermanent(errors.New("bad data"))
+	require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cs.currentLimit())
+
+	// Increase by 1/limit for every successful export: 2 -> 2.5 -> 2.9 -> 3.24.
+	exportErr = nil
+	for range 3 {
+		require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 3, cs.currentLimit())
+
+	// Not above the maximum.
+	for range 100 {
+		require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 10, cs.currentLimit())
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestConcurrencySender_Latency(t *testing.T) {
+	var delay atomic.Int64
+	cs, err := newConcurrencySender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.AdaptiveConcurrencyConfig{LatencyThreshold: 10 * time.Millisecond, DecreaseRatio: 0.8}, 10,
+		sender.NewSender(func(context.Context, request.Request) error {
+			time.Sleep(time.Duration(delay.Load()))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 10, cs.currentLimit())
+
+	delay.Store(int64(20 * time.Millisecond))
+	require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 8, cs.currentLimit())
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestConcurrencySender_DecreaseOncePerOverload(t *testing.T) {
+	block := make(chan struct{})
+	cs, err := newConcurrencySender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.AdaptiveConcurrencyConfig{}, 8,
+		sender.NewSender(func(context.Context, request.Request) error {
+			<-block
+			return status.Error(codes.Unavailable, "overloaded")
+		}))
+	require.NoError(t, err)
+
+	// All the in-flight exports observe the same overload.
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Go(func() {
+			assert.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		})
+	}
+	assert.Eventually(t, func() bool {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		return cs.inFlight == 4
+	}, time.Second, time.Millisecond)
+	close(block)
+	wg.Wait()
+	assert.Equal(t, 4, cs.currentLimit())
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestConcurrencySender_Limit(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+	cs, err := newConcurrencySender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 3}, 3,
+		sender.NewSender(func(context.Context, request.Request) error {
+			cur := inFlight.Add(1)
+			for {
+				prev := maxInFlight.Load()
+				if cur <= prev || maxInFlight.CompareAndSwap(prev, cur) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			return nil
+		}))
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for range 20 {
+		wg.Go(func() {
+			assert.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		})
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, maxInFlight.Load(), int64(3))
+
+	// Waiting for a slot is interrupted by the context.
+	cs.limit = 1
+	cs.inFlight = 1
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cs.Send(ctx, &requesttest.FakeRequest{Items: 1}), context.DeadlineExceeded)
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestConcurrencySender_Metric(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := exportertest.NewNopSettings(exportertest.NopType)
+	set.TelemetrySettings = tt.NewTelemetrySettings()
+	cs, err := newConcurrencySender(set, queuebatch.AdaptiveConcurrencyConfig{}, 6,
+		sender.NewSender(func(context.Context, request.Request) error {
+			return NewThrottleRetry(errors.New("throttled"), 0)
+		}))
+	require.NoError(t, err)
+	require.Error(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+
+	metadatatest.AssertEqualExporterConcurrencyLimit(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String(ExporterKey, set.ID.String())),
+				Value:      3,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, cs.Shutdown(context.Background()))
+}
+
+func TestIsOverloadErr(t *testing.T) {
+	assert.False(t, isOverloadErr(nil))
+	assert.False(t, isOverloadErr(errors.New("other")))
+	assert.False(t, isOverloadErr(consumererror.NewPermanent(status.Error(codes.InvalidArgument, "bad data"))))
+	assert.True(t, isOverloadErr(NewThrottleRetry(errors.New("throttled"), time.Second)))
+	assert.True(t, isOverloadErr(context.DeadlineExceeded))
+	// OTLP/HTTP exporters report HTTP 429 and 503 with these codes.
+	assert.True(t, isOverloadErr(fmt.Errorf("export failed: %w", status.Error(codes.ResourceExhausted, "too many requests"))))
+	assert.True(t, isOverloadErr(status.Error(codes.Unavailable, "unavailable")))
+}
+
+func TestBaseExporterAdaptiveConcurrency(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.AdaptiveConcurrency = configoptional.Some(queuebatch.AdaptiveConcurrencyConfig{MinConcurrency: 1})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NotNil(t, be.ConcurrencySender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	assert.Nil(t, be.ConcurrencySender)
+}
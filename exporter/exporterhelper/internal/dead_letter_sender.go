@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// defaultDeadLetterQueueSize is the number of requests kept in the dead letter queue when not configured.
+const defaultDeadLetterQueueSize = 1_000
+
+// exposeExporters is implemented by the host to provide access to the exporters of the pipelines.
+type exposeExporters interface {
+	GetExporters() map[pipeline.Signal]map[component.ID]component.Component
+}
+
+// deadLetterSender is a requestSender that stores the requests that failed to be exported
+// in a dead letter queue, or sends them to another exporter, instead of dropping them.
+type deadLetterSender struct {
+	// queue is nil when the dead letters are sent to another exporter.
+	queue      queue.Queue[request.Request]
+	signal     pipeline.Signal
+	exporterID component.ID
+	newExport  func(component.Component) (sender.SendFunc[request.Request], error)
+	// export is set on start when the dead letters are sent to another exporter.
+	export sender.SendFunc[request.Request]
+	logger *zap.Logger
+	next   sender.Sender[request.Request]
+}
+
+func newDeadLetterSender(
+	qSet queuebatch.AllSettings[request.Request],
+	cfg queuebatch.DeadLetterConfig,
+	encryption *persistentqueue.EncryptionSettings,
+	compression *queue.CompressionSettings,
+	next sender.Sender[request.Request],
+) (*deadLetterSender, error) {
+	if cfg.ExporterID != (component.ID{}) {
+		if qSet.DeadLetterExporter == nil {
+			return nil, errors.New("`dead_letter::exporter` is not supported by this exporter")
+		}
+		if cfg.ExporterID == qSet.ID {
+			return nil, errors.New("`dead_letter::exporter` must be another exporter")
+		}
+		return &deadLetterSender{
+			signal:     qSet.Signal,
+			exporterID: cfg.ExporterID,
+			newExport:  qSet.DeadLetterExporter,
+			logger:     qSet.Telemetry.Logger,
+			next:       next,
+		}, nil
+	}
+
+	capacity := cfg.QueueSize
+	if capacity == 0 {
+		capacity = defaultDeadLetterQueueSize
+	}
+	q, err := queue.NewDeadLetterQueue(queue.Settings[request.Request]{
+		SizerType:   request.SizerTypeRequests,
+		Capacity:    capacity,
+		Signal:      qSet.Signal,
+		StorageID:   &cfg.StorageID,
+		Encoding:    qSet.Encoding,
+		ID:          qSet.ID,
+		Telemetry:   qSet.Telemetry,
+		Encryption:  encryption,
+		Compression: compression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterSender{
+		queue:  q,
+		logger: qSet.Telemetry.Logger,
+		next:   next,
+	}, nil
+}
+
+func (dls *deadLetterSender) Start(ctx context.Context, host component.Host) error {
+	if dls.queue != nil {
+		return dls.queue.Start(ctx, host)
+	}
+	eh, ok := host.(exposeExporters)
+	if !ok {
+		return errors.New("the host does not expose the exporters, `dead_letter::exporter` is not supported")
+	}
+	exp, ok := eh.GetExporters()[dls.signal][dls.exporterID]
+	if !ok {
+		return fmt.Errorf("dead letter exporter %q is not used by a %s pipeline", dls.exporterID, dls.signal)
+	}
+	export, err := dls.newExport(exp)
+	if err != nil {
+		return fmt.Errorf("dead letter exporter %q: %w", dls.exporterID, err)
+	}
+	dls.export = export
+	return nil
+}
+
+func (dls *deadLetterSender) Shutdown(ctx context.Context) error {
+	// The other exporter is shut down by the service.
+	if dls.queue == nil {
+		return nil
+	}
+	return dls.queue.Shutdown(ctx)
+}
+
+// Send implements the requestSender interface
+func (dls *deadLetterSender) Send(ctx context.Context, req request.Request) error {
+	// Have to read the number of items before sending the request since the request can
+	// be modified by the downstream components.
+	itemsCount := req.ItemsCount()
+	err := dls.next.Send(ctx, req)
+	// Requests interrupted by shutdown are kept by the persistent queue, if any, so they are not dead letters.
+	if err == nil || experr.IsShutdownErr(err) {
+		return err
+	}
+
+	if dls.queue == nil {
+		if dlErr := dls.export(ctx, req); dlErr != nil {
+			dls.logger.Error("Failed to send data to the dead letter exporter.",
+				zap.Error(dlErr), zap.Stringer("dead_letter_exporter", dls.exporterID), zap.Int("dropped_items", itemsCount))
+			return err
+		}
+		dls.logger.Warn("Exporting failed. Sent data to the dead letter exporter.",
+			zap.Error(err), zap.Stringer("dead_letter_exporter", dls.exporterID), zap.Int("dead_letter_items", itemsCount))
+	} else {
+		if dlErr := dls.queue.Offer(ctx, req); dlErr != nil {
+			dls.logger.Error("Failed to store data in the dead letter queue.",
+				zap.Error(dlErr), zap.Int("dropped_items", itemsCount))
+			return err
+		}
+		dls.logger.Warn("Exporting failed. Stored data in the dead letter queue.",
+			zap.Error(err), zap.Int("dead_letter_items", itemsCount))
+	}
+	// The data was not exported, the error is still reported, but the request must not be retried.
+	if consumererror.IsPermanent(err) {
+		return err
+	}
+	return consumererror.NewPermanent(err)
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newDeadLetterTestSettings() queuebatch.AllSettings[request.Request] {
+	return queuebatch.AllSettings[request.Request]{
+		Settings:  newFakeQueueBatch(),
+		Signal:    pipeline.SignalLogs,
+		ID:        component.NewID(exportertest.NopType),
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+	}
+}
+
+func TestDeadLetterSender(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "dlq")
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	qSet := newDeadLetterTestSettings()
+	logger, observed := observer.New(zap.WarnLevel)
+	qSet.Telemetry.Logger = zap.New(logger)
+
+	sink := requesttest.NewSink()
+	dls, err := newDeadLetterSender(qSet, queuebatch.DeadLetterConfig{StorageID: storageID, QueueSize: 2}, nil, nil, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+	require.NoError(t, dls.Start(context.Background(), host))
+
+	require.NoError(t, dls.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.Equal(t, int64(0), dls.queue.Size())
+
+	// The stored requests still fail with the original error, as a permanent error so they are not retried.
+	permanentErr := consumererror.NewPermanent(errors.New("bad data"))
+	sink.SetExportErr(permanentErr)
+	require.Equal(t, permanentErr, dls.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	assert.Equal(t, int64(1), dls.queue.Size())
+
+	// Requests interrupted by shutdown are not dead letters.
+	shutdownErr := experr.NewShutdownErr(errors.New("shutting down"))
+	sink.SetExportErr(shutdownErr)
+	require.ErrorIs(t, dls.Send(context.Background(), &requesttest.FakeRequest{Items: 4}), shutdownErr)
+	assert.Equal(t, int64(1), dls.queue.Size())
+
+	retriesErr := errors.New("no more retries left")
+	sink.SetExportErr(retriesErr)
+	err = dls.Send(context.Background(), &requesttest.FakeRequest{Items: 5})
+	require.ErrorIs(t, err, retriesErr)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, int64(2), dls.queue.Size())
+
+	// The dead letter queue is full, the original error is returned.
+	expErr := errors.New("no more retries left")
+	sink.SetExportErr(expErr)
+	require.ErrorIs(t, dls.Send(context.Background(), &requesttest.FakeRequest{Items: 6}), expErr)
+	assert.Equal(t, int64(2), dls.queue.Size())
+
+	require.NoError(t, dls.Shutdown(context.Background()))
+
+	require.Len(t, observed.All(), 3)
+	assert.Equal(t, "Exporting failed. Stored data in the dead letter queue.", observed.All()[0].Message)
+	assert.Equal(t, "Exporting failed. Stored data in the dead letter queue.", observed.All()[1].Message)
+	assert.Equal(t, "Failed to store data in the dead letter queue.", observed.All()[2].Message)
+}
+
+func TestDeadLetterSenderMissingStorage(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "dlq")
+	dls, err := newDeadLetterSender(newDeadLetterTestSettings(), queuebatch.DeadLetterConfig{StorageID: storageID, QueueSize: 10}, nil, nil, sender.NewSender(noopExport))
+	require.NoError(t, err)
+	require.Error(t, dls.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+// exportersHost is a host exposing the exporters of the pipelines.
+type exportersHost struct {
+	component.Host
+	exporters map[pipeline.Signal]map[component.ID]component.Component
+}
+
+func (h exportersHost) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
+	return h.exporters
+}
+
+// sinkExporter is an exporter consuming the requests with a sink.
+type sinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	sink *requesttest.Sink
+}
+
+func TestDeadLetterSenderExporter(t *testing.T) {
+	dlqID := component.MustNewIDWithName("otlp_grpc", "dlq")
+	dlq := &sinkExporter{sink: requesttest.NewSink()}
+	host := exportersHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[pipeline.Signal]map[component.ID]component.Component{pipeline.SignalLogs: {dlqID: dlq}},
+	}
+	qSet := newDeadLetterTestSettings()
+	qSet.DeadLetterExporter = func(exp component.Component) (sender.SendFunc[request.Request], error) {
+		return exp.(*sinkExporter).sink.Export, nil
+	}
+
+	sink := requesttest.NewSink()
+	dls, err := newDeadLetterSender(qSet, queuebatch.DeadLetterConfig{ExporterID: dlqID}, nil, nil, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+	require.Nil(t, dls.queue)
+	require.NoError(t, dls.Start(context.Background(), host))
+
+	require.NoError(t, dls.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.Equal(t, 0, dlq.sink.ItemsCount())
+
+	// The failed requests are sent to the other exporter, and reported as permanent errors.
+	sink.SetExportErr(errors.New("no more retries left"))
+	err = dls.Send(context.Background(), &requesttest.FakeRequest{Items: 3})
+	require.EqualError(t, err, "Permanent error: no more retries left")
+	assert.Equal(t, 3, dlq.sink.ItemsCount())
+
+	// The other exporter fails, the original error is returned.
+	sink.SetExportErr(errors.New("no more retries left"))
+	dlq.sink.SetExportErr(errors.New("dead letter exporter failed"))
+	err = dls.Send(context.Background(), &requesttest.FakeRequest{Items: 4})
+	require.EqualError(t, err, "no more retries left")
+	assert.Equal(t, 3, dlq.sink.ItemsCount())
+	require.NoError(t, dls.Shutdown(context.Background()))
+
+	// The exporter must be used by a pipeline of the same signal.
+	dls, err = newDeadLetterSender(qSet, queuebatch.DeadLetterConfig{ExporterID: component.MustNewID("debug")}, nil, nil, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+	require.EqualError(t, dls.Start(context.Background(), host), `dead letter exporter "debug" is not used by a logs pipeline`)
+	require.EqualError(t, dls.Start(context.Background(), componenttest.NewNopHost()),
+		"the host does not expose the exporters, `dead_letter::exporter` is not supported")
+
+	_, err = newDeadLetterSender(qSet, queuebatch.DeadLetterConfig{ExporterID: qSet.ID}, nil, nil, sender.NewSender(sink.Export))
+	require.EqualError(t, err, "`dead_letter::exporter` must be another exporter")
+
+	_, err = newDeadLetterSender(newDeadLetterTestSettings(), queuebatch.DeadLetterConfig{ExporterID: dlqID}, nil, nil, sender.NewSender(sink.Export))
+	require.EqualError(t, err, "`dead_letter::exporter` is not supported by this exporter")
+}
+
+func TestBaseExporterDeadLetter(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "dlq")
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	qCfg := NewDefaultQueueConfig()
+	qCfg.WaitForResult = true
+	qCfg.Batch = configoptional.None[queuebatch.BatchConfig]()
+	qCfg.DeadLetter = configoptional.Some(queuebatch.DeadLetterConfig{StorageID: storageID})
+
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, errExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NotNil(t, be.DeadLetterSender)
+	assert.Equal(t, int64(defaultDeadLetterQueueSize), be.DeadLetterSender.(*deadLetterSender).queue.Capacity())
+	require.NoError(t, be.Start(context.Background(), host))
+	err = be.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	require.EqualError(t, err, "Permanent error: my error")
+	assert.Equal(t, int64(1), be.DeadLetterSender.(*deadLetterSender).queue.Size())
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	_, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatch(configoptional.Some(qCfg), queuebatch.Settings[request.Request]{}))
+	require.EqualError(t, err, "`Settings.Encoding` must not be nil when dead letter queue is enabled")
+}
@@ -28,6 +28,7 @@ type TelemetryBuilder struct {
 	meter                               metric.Meter
 	mu                                  sync.Mutex
 	registrations                       []metric.Registration
+	ExporterConcurrencyLimit            metric.Int64ObservableGauge
 	ExporterEnqueueFailedLogRecords     metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints   metric.Int64Counter
 	ExporterEnqueueFailedProfileSamples metric.Int64Counter
@@ -35,7 +36,12 @@ type TelemetryBuilder struct {
 	ExporterQueueBatchSendSize          metric.Int64Histogram
 	ExporterQueueBatchSendSizeBytes     metric.Int64Histogram
 	ExporterQueueCapacity               metric.Int64ObservableGauge
+	ExporterQueueLaneCapacity           metric.Int64ObservableGauge
+	ExporterQueueLaneSize               metric.Int64ObservableGauge
+	ExporterQueuePartitionSize          metric.Int64ObservableGauge
 	ExporterQueueSize                   metric.Int64ObservableGauge
+	ExporterRateLimitThrottledRequests  metric.Int64Counter
+	ExporterRateLimitThrottledTime      metric.Float64Counter
 	ExporterSendFailedLogRecords        metric.Int64Counter
 	ExporterSendFailedMetricPoints      metric.Int64Counter
 	ExporterSendFailedProfileSamples    metric.Int64Counter
@@ -57,6 +63,21 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterExporterConcurrencyLimitCallback sets callback for observable ExporterConcurrencyLimit metric.
+func (builder *TelemetryBuilder) RegisterExporterConcurrencyLimitCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterConcurrencyLimit, obs: o})
+		return nil
+	}, builder.ExporterConcurrencyLimit)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueCapacityCallback sets callback for observable ExporterQueueCapacity metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -72,6 +93,51 @@ func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric
 	return nil
 }
 
+// RegisterExporterQueueLaneCapacityCallback sets callback for observable ExporterQueueLaneCapacity metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueLaneCapacityCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueLaneCapacity, obs: o})
+		return nil
+	}, builder.ExporterQueueLaneCapacity)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+// RegisterExporterQueueLaneSizeCallback sets callback for observable ExporterQueueLaneSize metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueLaneSizeCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueLaneSize, obs: o})
+		return nil
+	}, builder.ExporterQueueLaneSize)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+// RegisterExporterQueuePartitionSizeCallback sets callback for observable ExporterQueuePartitionSize metric.
+func (builder *TelemetryBuilder) RegisterExporterQueuePartitionSizeCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueuePartitionSize, obs: o})
+		return nil
+	}, builder.ExporterQueuePartitionSize)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueSizeCallback sets callback for observable ExporterQueueSize metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueSizeCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -115,6 +181,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ExporterConcurrencyLimit, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_concurrency_limit",
+		metric.WithDescription("Current limit of concurrent exports when the adaptive concurrency is enabled. [Development]"),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterEnqueueFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_enqueue_failed_log_records",
 		metric.WithDescription("Number of log records failed to be added to the sending queue. [Alpha]"),
@@ -159,12 +231,42 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{batch}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueLaneCapacity, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_lane_capacity",
+		metric.WithDescription("Fixed capacity of a priority lane of the sending queue. [Development]"),
+		metric.WithUnit("{batch}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterQueueLaneSize, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_lane_size",
+		metric.WithDescription("Current size of a priority lane of the sending queue. [Development]"),
+		metric.WithUnit("{batch}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterQueuePartitionSize, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_partition_size",
+		metric.WithDescription("Current size of a partition of the sending queue, using the queue sizer. [Development]"),
+		metric.WithUnit("{batch}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterQueueSize, err = builder.meter.Int64ObservableGauge(
 		"otelcol_exporter_queue_size",
 		metric.WithDescription("Current size of the retry queue (in batches). [Alpha]"),
 		metric.WithUnit("{batch}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterRateLimitThrottledRequests, err = builder.meter.Int64Counter(
+		"otelcol_exporter_rate_limit_throttled_requests",
+		metric.WithDescription("Number of export attempts failed with a throttling error by the rate limiter, without waiting. [Development]"),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterRateLimitThrottledTime, err = builder.meter.Float64Counter(
+		"otelcol_exporter_rate_limit_throttled_time",
+		metric.WithDescription("Total time the exports waited for the rate limiter. [Development]"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination. At detailed telemetry level, includes attributes: error.type (semantic convention), error.permanent. [Alpha]"),
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// CompressionSettings define how the items persisted by the queue are compressed.
+type CompressionSettings struct {
+	// Type is the compression algorithm, as defined by configcompression.
+	Type configcompression.Type
+	// Params are the parameters of the compression algorithm.
+	Params configcompression.CompressionParams
+}
+
+type writeCloserReset interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// itemCompressor compresses the encoded items before they are persisted.
+type itemCompressor struct {
+	typ  configcompression.Type
+	pool sync.Pool
+}
+
+func newItemCompressor(set CompressionSettings) (*itemCompressor, error) {
+	if err := set.Type.ValidateParams(set.Params); err != nil {
+		return nil, err
+	}
+	level := int(set.Params.Level)
+	if level == 0 {
+		level = configcompression.DefaultCompressionLevel
+	}
+	var newWriter func() writeCloserReset
+	switch set.Type {
+	case configcompression.TypeGzip:
+		newWriter = func() writeCloserReset {
+			w, _ := gzip.NewWriterLevel(nil, level)
+			return w
+		}
+	case configcompression.TypeZlib, configcompression.TypeDeflate:
+		newWriter = func() writeCloserReset {
+			w, _ := zlib.NewWriterLevel(nil, level)
+			return w
+		}
+	case configcompression.TypeSnappy:
+		newWriter = func() writeCloserReset {
+			return &rawSnappyWriter{}
+		}
+	case configcompression.TypeSnappyFramed:
+		newWriter = func() writeCloserReset {
+			return snappy.NewBufferedWriter(nil)
+		}
+	case configcompression.TypeZstd:
+		encoderLevel := zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level))
+		newWriter = func() writeCloserReset {
+			w, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), encoderLevel)
+			return w
+		}
+	case configcompression.TypeLz4:
+		newWriter = func() writeCloserReset {
+			w := lz4.NewWriter(nil)
+			_ = w.Apply(lz4.ConcurrencyOption(1))
+			return w
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression type %q", set.Type)
+	}
+	return &itemCompressor{
+		typ:  set.Type,
+		pool: sync.Pool{New: func() any { return newWriter() }},
+	}, nil
+}
+
+func (ic *itemCompressor) compress(buf []byte) ([]byte, error) {
+	writer := ic.pool.Get().(writeCloserReset)
+	defer ic.pool.Put(writer)
+	out := bytes.NewBuffer(make([]byte, 0, len(buf)/2))
+	writer.Reset(out)
+	if _, err := writer.Write(buf); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// rawSnappyWriter buffers all writes and, on Close, compresses the data as a raw snappy block.
+type rawSnappyWriter struct {
+	buffer bytes.Buffer
+	w      io.Writer
+}
+
+func (w *rawSnappyWriter) Write(p []byte) (int, error) {
+	return w.buffer.Write(p)
+}
+
+func (w *rawSnappyWriter) Close() error {
+	_, err := w.w.Write(snappy.Encode(nil, w.buffer.Bytes()))
+	return err
+}
+
+func (w *rawSnappyWriter) Reset(newWriter io.Writer) {
+	w.buffer.Reset()
+	w.w = newWriter
+}
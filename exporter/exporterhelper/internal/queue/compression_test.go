@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+func TestItemCompressor(t *testing.T) {
+	payload := bytes.Repeat([]byte("a log line that is repeated "), 100)
+	for _, typ := range []configcompression.Type{
+		configcompression.TypeGzip,
+		configcompression.TypeZlib,
+		configcompression.TypeDeflate,
+		configcompression.TypeSnappy,
+		configcompression.TypeSnappyFramed,
+		configcompression.TypeZstd,
+		configcompression.TypeLz4,
+	} {
+		t.Run(string(typ), func(t *testing.T) {
+			ic, err := newItemCompressor(CompressionSettings{Type: typ})
+			require.NoError(t, err)
+			// The writers are reused, compress twice to check they are correctly reset.
+			for range 2 {
+				compressed, err := ic.compress(payload)
+				require.NoError(t, err)
+				assert.Less(t, len(compressed), len(payload))
+				decompressed, err := persistentqueue.Decompress(typ, compressed)
+				require.NoError(t, err)
+				assert.Equal(t, payload, decompressed)
+			}
+		})
+	}
+}
+
+func TestItemCompressor_Invalid(t *testing.T) {
+	_, err := newItemCompressor(CompressionSettings{Type: configcompression.TypeSnappy, Params: configcompression.CompressionParams{Level: 3}})
+	require.ErrorContains(t, err, "unsupported parameters")
+
+	_, err = newItemCompressor(CompressionSettings{Type: "brotli"})
+	require.ErrorContains(t, err, `unsupported compression type "brotli"`)
+	_, err = persistentqueue.Decompress("brotli", []byte("value"))
+	require.ErrorContains(t, err, `unsupported compression type "brotli"`)
+
+	_, err = persistentqueue.Decompress(configcompression.TypeGzip, []byte("not gzip"))
+	require.Error(t, err)
+}
+
+func TestPersistentQueue_Compression(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeBytes, 1000)
+
+	// Items stored before the compression was enabled.
+	pq := newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	require.NoError(t, pq.Start(context.Background(), host))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(2)))
+	assert.EqualValues(t, 30, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	set.Compression = &CompressionSettings{Type: configcompression.TypeZstd}
+	pq = newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	require.NoError(t, pq.Start(context.Background(), host))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(3)))
+
+	raw := newRawTestClient(t, ext)
+	stored, err := raw.Get(context.Background(), persistentqueue.ItemKey(2))
+	require.NoError(t, err)
+	itemMetadata, err := raw.Get(context.Background(), persistentqueue.ItemMetadataKey(2))
+	require.NoError(t, err)
+	require.NotNil(t, itemMetadata)
+	itemMetadata, err = raw.Get(context.Background(), persistentqueue.ItemMetadataKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, itemMetadata)
+	// The compressed item is accounted by its stored size.
+	assert.Equal(t, 30+int64(len(stored)), pq.Size())
+
+	// Leave the compressed item dispatched, so it is restored after restart.
+	for i := range 3 {
+		_, req, done, found := pq.Read(context.Background())
+		require.True(t, found)
+		assert.Equal(t, intRequest(i+1), req)
+		if i < 2 {
+			done.OnDone(nil)
+		}
+	}
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The compression can be disabled, the stored items are still decompressed.
+	set.Compression = nil
+	pq = newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	require.NoError(t, pq.Start(context.Background(), host))
+	_, req, done, found := pq.Read(context.Background())
+	require.True(t, found)
+	assert.Equal(t, intRequest(3), req)
+	done.OnDone(nil)
+	assert.Zero(t, pq.Size())
+	require.NoError(t, pq.Offer(context.Background(), intRequest(4)))
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	var values []string
+	require.NoError(t, persistentqueue.Read(context.Background(), raw, func(item persistentqueue.Item) error {
+		values = append(values, string(item.Value))
+		return nil
+	}))
+	assert.Equal(t, []string{"4"}, values)
+	itemMetadata, err = raw.Get(context.Background(), persistentqueue.ItemMetadataKey(3))
+	require.NoError(t, err)
+	assert.Nil(t, itemMetadata)
+}
+
+func TestReadPersistentQueue_Compression(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.Compression = &CompressionSettings{Type: configcompression.TypeGzip}
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(42)))
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	raw := newRawTestClient(t, ext)
+	var values []string
+	require.NoError(t, persistentqueue.Drain(context.Background(), raw, func(item persistentqueue.Item) error {
+		values = append(values, string(item.Value))
+		return nil
+	}))
+	assert.Equal(t, []string{"42"}, values)
+	itemMetadata, err := raw.Get(context.Background(), persistentqueue.ItemMetadataKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, itemMetadata)
+
+	// An invalid compression fails the start.
+	set.Compression = &CompressionSettings{Type: configcompression.TypeLz4, Params: configcompression.CompressionParams{Level: 1}}
+	require.Error(t, newPersistentQueue[intRequest](set).Start(context.Background(), host))
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+var errNoDeadLetterStorage = errors.New("dead letter queue requires a storage extension")
+
+// NewDeadLetterQueue returns a Queue that persists requests that permanently failed to be exported.
+// The items are stored in the same format as the persistent queue, but are never consumed by the exporter,
+// they are kept in the storage until inspected or replayed by an external tool.
+func NewDeadLetterQueue[T request.Request](set Settings[T]) (Queue[T], error) {
+	if set.StorageID == nil {
+		return nil, errNoDeadLetterStorage
+	}
+	// Dead letters are never blocking the exporter, if the queue is full the request is dropped.
+	set.BlockOnOverflow = false
+	set.WaitForResult = false
+	pq := newPersistentQueue[T](set).(*persistentQueue[T])
+	pq.storageName = persistentqueue.DeadLetterStorageName(set.Signal)
+	return pq, nil
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	_, err := NewDeadLetterQueue(newSettings(request.SizerTypeRequests, 10))
+	require.ErrorIs(t, err, errNoDeadLetterStorage)
+
+	set := newSettingsWithStorage(request.SizerTypeRequests, 2)
+	set.BlockOnOverflow = true
+	dlq, err := NewDeadLetterQueue(set)
+	require.NoError(t, err)
+	pq := dlq.(*persistentQueue[intRequest])
+	assert.Equal(t, "traces_dead_letter", pq.storageName)
+	assert.Equal(t, persistentqueue.DeadLetterStorageName(pipeline.SignalTraces), pq.storageName)
+
+	ext := storagetest.NewMockStorageExtension(nil)
+	require.NoError(t, dlq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: ext})))
+	require.NoError(t, dlq.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, dlq.Offer(context.Background(), intRequest(2)))
+	// Never blocks, even if the settings asked for it.
+	require.ErrorIs(t, dlq.Offer(context.Background(), intRequest(3)), ErrQueueIsFull)
+	assert.Equal(t, int64(2), dlq.Size())
+
+	_, req, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, intRequest(1), req)
+	done.OnDone(nil)
+	require.NoError(t, dlq.Shutdown(context.Background()))
+}
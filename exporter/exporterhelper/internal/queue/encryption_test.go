@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+var (
+	testKeyA = persistentqueue.EncryptionKey{ID: "a", Key: bytes.Repeat([]byte{1}, 32)}
+	testKeyB = persistentqueue.EncryptionKey{ID: "b", Key: bytes.Repeat([]byte{2}, 16)}
+)
+
+func newRawTestClient(t *testing.T, ext storage.Extension) storage.Client {
+	client, err := ext.GetClient(context.Background(), component.KindExporter, component.ID{}, "")
+	require.NoError(t, err)
+	return client
+}
+
+func newEncryptedTestClient(t *testing.T, raw storage.Client, set persistentqueue.EncryptionSettings) storage.Client {
+	client, err := persistentqueue.NewEncryptedClient(raw, set)
+	require.NoError(t, err)
+	return client
+}
+
+func TestPersistentQueue_Encryption(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyA}
+
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	for i := range 3 {
+		require.NoError(t, pq.Offer(context.Background(), intRequest(i+1)))
+	}
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The items can only be read with the key.
+	raw := newRawTestClient(t, ext)
+	require.NoError(t, persistentqueue.Read(context.Background(), newEncryptedTestClient(t, raw, *set.Encryption), func(item persistentqueue.Item) error {
+		stored, err := raw.Get(context.Background(), persistentqueue.ItemKey(item.Index))
+		require.NoError(t, err)
+		assert.NotEqual(t, item.Value, stored)
+		return nil
+	}))
+
+	pq = newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	assert.EqualValues(t, 3, pq.Size())
+	for i := range 3 {
+		_, req, done, found := pq.Read(context.Background())
+		require.True(t, found)
+		assert.Equal(t, intRequest(i+1), req)
+		done.OnDone(nil)
+	}
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// An invalid key fails the start.
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: persistentqueue.EncryptionKey{Key: []byte("short")}}
+	require.Error(t, newPersistentQueue[intRequest](set).Start(context.Background(), host))
+}
+
+func TestPersistentQueue_EncryptionEnabled(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	for i := range 2 {
+		require.NoError(t, pq.Offer(context.Background(), intRequest(i+1)))
+	}
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The items stored before the encryption was enabled are encrypted on start.
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyA}
+	pq = newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	raw := newRawTestClient(t, ext)
+	for _, key := range []string{persistentqueue.MetadataKey, persistentqueue.ItemKey(0), persistentqueue.ItemKey(1)} {
+		stored, err := raw.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(stored, []byte{0x00, 'o', 't', 'e'}), key)
+	}
+	assert.EqualValues(t, 2, pq.Size())
+	for i := range 2 {
+		_, req, done, found := pq.Read(context.Background())
+		require.True(t, found)
+		assert.Equal(t, intRequest(i+1), req)
+		done.OnDone(nil)
+	}
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_DecryptionFailed(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyA}
+
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// A wrong key fails the start instead of starting with an empty queue.
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyB}
+	err := newPersistentQueue[intRequest](set).Start(context.Background(), host)
+	require.ErrorIs(t, err, persistentqueue.ErrDecryptionFailed)
+	require.ErrorContains(t, err, "failed to decrypt the queue metadata")
+
+	// The metadata is encrypted with the new key after the rotation, the items still need the previous key.
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyB, PreviousKeys: []persistentqueue.EncryptionKey{testKeyA}}
+	pq = newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	require.NoError(t, pq.Offer(context.Background(), intRequest(2)))
+	require.NoError(t, pq.Shutdown(context.Background()))
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyB}
+	err = newPersistentQueue[intRequest](set).Start(context.Background(), host)
+	require.ErrorIs(t, err, persistentqueue.ErrDecryptionFailed)
+	require.ErrorContains(t, err, "failed to decrypt the oldest item of the queue")
+
+	// Nothing is lost.
+	set.Encryption = &persistentqueue.EncryptionSettings{Key: testKeyB, PreviousKeys: []persistentqueue.EncryptionKey{testKeyA}}
+	pq = newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+	assert.EqualValues(t, 2, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
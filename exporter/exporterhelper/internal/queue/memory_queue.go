@@ -40,6 +40,8 @@ type memoryQueue[T request.Request] struct {
 	stopped         bool
 	waitForResult   bool
 	blockOnOverflow bool
+	// notify if set, is called every time an element is added to the queue.
+	notify func()
 }
 
 // newMemoryQueue creates a sized elements channel. Each element is assigned a size by the provided sizer.
@@ -131,6 +133,9 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 	mq.items.push(ctx, el, done)
 	// Signal one consumer if any.
 	mq.hasMoreElements.Signal()
+	if mq.notify != nil {
+		mq.notify()
+	}
 	return done, nil
 }
 
@@ -158,6 +163,23 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 	}
 }
 
+// tryRead removes the element from the queue and returns it if available, it never blocks.
+func (mq *memoryQueue[T]) tryRead(context.Context) (context.Context, T, Done, bool) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	if mq.items.hasElements() {
+		elCtx, el, done := mq.items.pop()
+		return elCtx, el, done, true
+	}
+	var el T
+	return context.Background(), el, nil, false
+}
+
+func (mq *memoryQueue[T]) setNotify(notify func()) {
+	mq.notify = notify
+}
+
 func (mq *memoryQueue[T]) onDone(bd *blockingDone, err error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
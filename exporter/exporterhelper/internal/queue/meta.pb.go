@@ -1,203 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.26.0
-// 	protoc        v3.21.6
-// source: exporter/exporterhelper/internal/queue/meta.proto
-
-package queue
-
-import (
-	reflect "reflect"
-	sync "sync"
-
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-// PersistentMetadata holds all persistent metadata for the queue.
-// The items and bytes sizes are recorded explicitly,
-// the requests size can be calculated as (write_index - read_index + len(currently_dispatched_items)).
-type PersistentMetadata struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Current total items size of the queue.
-	ItemsSize int64 `protobuf:"fixed64,1,opt,name=items_size,json=itemsSize,proto3" json:"items_size,omitempty"`
-	// Current total bytes size of the queue.
-	BytesSize int64 `protobuf:"fixed64,2,opt,name=bytes_size,json=bytesSize,proto3" json:"bytes_size,omitempty"`
-	// Index of the next item to be read from the queue.
-	ReadIndex uint64 `protobuf:"fixed64,3,opt,name=read_index,json=readIndex,proto3" json:"read_index,omitempty"`
-	// Index where the next item will be written to the queue.
-	WriteIndex uint64 `protobuf:"fixed64,4,opt,name=write_index,json=writeIndex,proto3" json:"write_index,omitempty"`
-	// List of item indices currently being processed by consumers.
-	CurrentlyDispatchedItems []uint64 `protobuf:"fixed64,5,rep,packed,name=currently_dispatched_items,json=currentlyDispatchedItems,proto3" json:"currently_dispatched_items,omitempty"`
-}
-
-func (x *PersistentMetadata) Reset() {
-	*x = PersistentMetadata{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_exporter_exporterhelper_internal_queue_meta_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *PersistentMetadata) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PersistentMetadata) ProtoMessage() {}
-
-func (x *PersistentMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_exporter_exporterhelper_internal_queue_meta_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PersistentMetadata.ProtoReflect.Descriptor instead.
-func (*PersistentMetadata) Descriptor() ([]byte, []int) {
-	return file_exporter_exporterhelper_internal_queue_meta_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *PersistentMetadata) GetItemsSize() int64 {
-	if x != nil {
-		return x.ItemsSize
-	}
-	return 0
-}
-
-func (x *PersistentMetadata) GetBytesSize() int64 {
-	if x != nil {
-		return x.BytesSize
-	}
-	return 0
-}
-
-func (x *PersistentMetadata) GetReadIndex() uint64 {
-	if x != nil {
-		return x.ReadIndex
-	}
-	return 0
-}
-
-func (x *PersistentMetadata) GetWriteIndex() uint64 {
-	if x != nil {
-		return x.WriteIndex
-	}
-	return 0
-}
-
-func (x *PersistentMetadata) GetCurrentlyDispatchedItems() []uint64 {
-	if x != nil {
-		return x.CurrentlyDispatchedItems
-	}
-	return nil
-}
-
-var File_exporter_exporterhelper_internal_queue_meta_proto protoreflect.FileDescriptor
-
-var file_exporter_exporterhelper_internal_queue_meta_proto_rawDesc = []byte{
-	0x0a, 0x31, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72,
-	0x74, 0x65, 0x72, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x3e, 0x6f, 0x70, 0x65, 0x6e, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
-	0x72, 0x79, 0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x65, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x68, 0x65,
-	0x6c, 0x70, 0x65, 0x72, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x71, 0x75,
-	0x65, 0x75, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x12, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x10, 0x52, 0x09,
-	0x69, 0x74, 0x65, 0x6d, 0x73, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x10, 0x52, 0x09, 0x62,
-	0x79, 0x74, 0x65, 0x73, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64,
-	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x06, 0x52, 0x09, 0x72, 0x65,
-	0x61, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x06, 0x52, 0x0a, 0x77, 0x72,
-	0x69, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3c, 0x0a, 0x1a, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x6c, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
-	0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x06, 0x52, 0x18, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65,
-	0x64, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x6f, 0x2e, 0x6f, 0x70, 0x65,
-	0x6e, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
-	0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_exporter_exporterhelper_internal_queue_meta_proto_rawDescOnce sync.Once
-	file_exporter_exporterhelper_internal_queue_meta_proto_rawDescData = file_exporter_exporterhelper_internal_queue_meta_proto_rawDesc
-)
-
-func file_exporter_exporterhelper_internal_queue_meta_proto_rawDescGZIP() []byte {
-	file_exporter_exporterhelper_internal_queue_meta_proto_rawDescOnce.Do(func() {
-		file_exporter_exporterhelper_internal_queue_meta_proto_rawDescData = protoimpl.X.CompressGZIP(file_exporter_exporterhelper_internal_queue_meta_proto_rawDescData)
-	})
-	return file_exporter_exporterhelper_internal_queue_meta_proto_rawDescData
-}
-
-var file_exporter_exporterhelper_internal_queue_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_exporter_exporterhelper_internal_queue_meta_proto_goTypes = []interface{}{
-	(*PersistentMetadata)(nil), // 0: opentelemetry.collector.exporter.exporterhelper.internal.queue.PersistentMetadata
-}
-var file_exporter_exporterhelper_internal_queue_meta_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
-}
-
-func init() { file_exporter_exporterhelper_internal_queue_meta_proto_init() }
-func file_exporter_exporterhelper_internal_queue_meta_proto_init() {
-	if File_exporter_exporterhelper_internal_queue_meta_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_exporter_exporterhelper_internal_queue_meta_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PersistentMetadata); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_exporter_exporterhelper_internal_queue_meta_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
-			NumExtensions: 0,
-			NumServices:   0,
-		},
-		GoTypes:           file_exporter_exporterhelper_internal_queue_meta_proto_goTypes,
-		DependencyIndexes: file_exporter_exporterhelper_internal_queue_meta_proto_depIdxs,
-		MessageInfos:      file_exporter_exporterhelper_internal_queue_meta_proto_msgTypes,
-	}.Build()
-	File_exporter_exporterhelper_internal_queue_meta_proto = out.File
-	file_exporter_exporterhelper_internal_queue_meta_proto_rawDesc = nil
-	file_exporter_exporterhelper_internal_queue_meta_proto_goTypes = nil
-	file_exporter_exporterhelper_internal_queue_meta_proto_depIdxs = nil
-}
@@ -8,8 +8,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"strconv"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -18,7 +18,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
-	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
 )
 
 const (
@@ -29,9 +29,6 @@ const (
 	legacyReadIndexKey                = "ri"
 	legacyWriteIndexKey               = "wi"
 	legacyCurrentlyDispatchedItemsKey = "di"
-
-	// metadataKey is the new single key for all queue metadata.
-	metadataKey = "qmv0"
 )
 
 var (
@@ -80,17 +77,37 @@ type persistentQueue[T request.Request] struct {
 	bytesSizer  request.Sizer
 	storageID   component.ID
 	id          component.ID
-	signal      pipeline.Signal
+	storageName string
+	encryption  *persistentqueue.EncryptionSettings
+	compression *CompressionSettings
+	compressor  *itemCompressor
 
 	// mu guards everything declared below.
 	mu              sync.Mutex
 	hasMoreElements *sync.Cond
 	hasMoreSpace    *cond
-	metadata        PersistentMetadata
+	metadata        persistentqueue.PersistentMetadata
 	refClient       int64
 	stopped         bool
+	// waiters contains the channels receiving the export result of the items, by index, if waitForResult is set.
+	waiters map[uint64]chan error
+	// restoredIndex is the write index when the queue was started, the items of lower indexes were persisted
+	// before the start.
+	restoredIndex uint64
 
+	waitForResult   bool
 	blockOnOverflow bool
+	// notify if set, is called every time an element is added to the queue.
+	notify func()
+}
+
+type restoredCtxKey struct{}
+
+// IsRestored returns whether the request read from the queue was persisted before the queue was started, for
+// example by a previous run of the collector.
+func IsRestored(ctx context.Context) bool {
+	restored, _ := ctx.Value(restoredCtxKey{}).(bool)
+	return restored
 }
 
 // newPersistentQueue creates a new queue backed by file storage; name and signal must be a unique combination that identifies the queue storage
@@ -105,8 +122,12 @@ func newPersistentQueue[T request.Request](set Settings[T]) readableQueue[T] {
 		bytesSizer:      request.NewBytesSizer(),
 		storageID:       *set.StorageID,
 		id:              set.ID,
-		signal:          set.Signal,
+		storageName:     set.Signal.String(),
+		encryption:      set.Encryption,
+		compression:     set.Compression,
+		waitForResult:   set.WaitForResult,
 		blockOnOverflow: set.BlockOnOverflow,
+		waiters:         make(map[uint64]chan error),
 	}
 	pq.hasMoreElements = sync.NewCond(&pq.mu)
 	pq.hasMoreSpace = newCond(&pq.mu)
@@ -115,11 +136,29 @@ func newPersistentQueue[T request.Request](set Settings[T]) readableQueue[T] {
 
 // Start starts the persistentQueue with the given number of consumers.
 func (pq *persistentQueue[T]) Start(ctx context.Context, host component.Host) error {
-	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, pq.signal)
+	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, pq.storageName)
 	if err != nil {
 		return err
 	}
-	pq.initClient(ctx, storageClient)
+	if pq.compression != nil {
+		if pq.compressor, err = newItemCompressor(*pq.compression); err != nil {
+			return errors.Join(err, storageClient.Close(ctx))
+		}
+	}
+	if pq.encryption != nil {
+		if encErr := persistentqueue.EncryptStoredValues(ctx, storageClient, *pq.encryption, pq.logger); encErr != nil {
+			return errors.Join(fmt.Errorf("failed to encrypt the queue: %w", encErr), storageClient.Close(ctx))
+		}
+		encryptedClient, encErr := persistentqueue.NewEncryptedClient(storageClient, *pq.encryption)
+		if encErr != nil {
+			return errors.Join(encErr, storageClient.Close(ctx))
+		}
+		storageClient = encryptedClient
+	}
+	if err = pq.initClient(ctx, storageClient); err != nil {
+		pq.client = nil
+		return errors.Join(err, storageClient.Close(ctx))
+	}
 	return nil
 }
 
@@ -148,7 +187,7 @@ func (pq *persistentQueue[T]) Capacity() int64 {
 	return pq.capacity
 }
 
-func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Client) {
+func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Client) error {
 	pq.client = client
 	// Start with a reference 1 which is the reference we use for the producer goroutines and initialization.
 	pq.refClient = 1
@@ -157,20 +196,44 @@ func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Cli
 	err := pq.loadQueueMetadata(ctx)
 	switch {
 	case err == nil:
-		pq.enqueueNotDispatchedReqs(ctx, pq.metadata.CurrentlyDispatchedItems)
+		if err = pq.enqueueNotDispatchedReqs(ctx, pq.metadata.CurrentlyDispatchedItems); err != nil {
+			return err
+		}
 		pq.metadata.CurrentlyDispatchedItems = nil
+		if err = pq.checkOldestItem(ctx); err != nil {
+			return err
+		}
+	case errors.Is(err, persistentqueue.ErrDecryptionFailed):
+		// Starting with new metadata would drop all the items of the queue.
+		return fmt.Errorf("failed to decrypt the queue metadata, check the encryption keys: %w", err)
 	case !errors.Is(err, errValueNotSet):
 		pq.logger.Error("Failed getting metadata, starting with new ones", zap.Error(err))
-		pq.metadata = PersistentMetadata{}
+		pq.metadata = persistentqueue.PersistentMetadata{}
 	default:
 		pq.logger.Info("New queue metadata key not found, attempting to load legacy format.")
-		pq.loadLegacyMetadata(ctx)
+		if err = pq.loadLegacyMetadata(ctx); err != nil {
+			return err
+		}
 	}
+	pq.restoredIndex = pq.metadata.WriteIndex
+	return nil
+}
+
+// checkOldestItem checks that the oldest item of the queue can be decrypted. The oldest items are encrypted with
+// the oldest keys, so a previous key removed while still in use is reported instead of dropping the items.
+func (pq *persistentQueue[T]) checkOldestItem(ctx context.Context) error {
+	if pq.encryption == nil || pq.metadata.ReadIndex == pq.metadata.WriteIndex {
+		return nil
+	}
+	if _, err := pq.client.Get(ctx, persistentqueue.ItemKey(pq.metadata.ReadIndex)); errors.Is(err, persistentqueue.ErrDecryptionFailed) {
+		return fmt.Errorf("failed to decrypt the oldest item of the queue, check the encryption keys: %w", err)
+	}
+	return nil
 }
 
 // loadQueueMetadata loads queue metadata from the consolidated key
 func (pq *persistentQueue[T]) loadQueueMetadata(ctx context.Context) error {
-	buf, err := pq.client.Get(ctx, metadataKey)
+	buf, err := pq.client.Get(ctx, persistentqueue.MetadataKey)
 	if err != nil {
 		return err
 	}
@@ -194,7 +257,7 @@ func (pq *persistentQueue[T]) loadQueueMetadata(ctx context.Context) error {
 }
 
 // TODO: Remove legacy format support after 6 months (target: December 2025)
-func (pq *persistentQueue[T]) loadLegacyMetadata(ctx context.Context) {
+func (pq *persistentQueue[T]) loadLegacyMetadata(ctx context.Context) error {
 	// Fallback to legacy individual keys for backward compatibility
 	riOp := storage.GetOperation(legacyReadIndexKey)
 	wiOp := storage.GetOperation(legacyWriteIndexKey)
@@ -209,27 +272,32 @@ func (pq *persistentQueue[T]) loadLegacyMetadata(ctx context.Context) {
 	}
 
 	if err != nil {
-		if errors.Is(err, errValueNotSet) {
+		switch {
+		case errors.Is(err, persistentqueue.ErrDecryptionFailed):
+			return fmt.Errorf("failed to decrypt the queue metadata, check the encryption keys: %w", err)
+		case errors.Is(err, errValueNotSet):
 			pq.logger.Info("Initializing new persistent queue")
-		} else {
+		default:
 			pq.logger.Error("Failed getting read/write index, starting with new ones", zap.Error(err))
 		}
 		pq.metadata.ReadIndex = 0
 		pq.metadata.WriteIndex = 0
 	}
 
-	pq.retrieveAndEnqueueNotDispatchedReqs(ctx)
+	if err = pq.retrieveAndEnqueueNotDispatchedReqs(ctx); err != nil {
+		return err
+	}
 
 	// Save to a new format and clean up legacy keys
 	metadataBytes, err := proto.Marshal(&pq.metadata)
 	if err != nil {
 		pq.logger.Error("Failed to marshal metadata", zap.Error(err))
-		return
+		return nil
 	}
 
-	if err = pq.client.Set(ctx, metadataKey, metadataBytes); err != nil {
+	if err = pq.client.Set(ctx, persistentqueue.MetadataKey, metadataBytes); err != nil {
 		pq.logger.Error("Failed to persist current metadata to storage", zap.Error(err))
-		return
+		return nil
 	}
 
 	if err = pq.client.Batch(ctx,
@@ -240,6 +308,7 @@ func (pq *persistentQueue[T]) loadLegacyMetadata(ctx context.Context) {
 	} else {
 		pq.logger.Info("Successfully migrated to consolidated metadata format")
 	}
+	return nil
 }
 
 func (pq *persistentQueue[T]) Shutdown(ctx context.Context) error {
@@ -253,6 +322,10 @@ func (pq *persistentQueue[T]) Shutdown(ctx context.Context) error {
 	// Mark this queue as stopped, so consumer don't start any more work.
 	pq.stopped = true
 	pq.hasMoreElements.Broadcast()
+	// The items are kept in the storage and exported after restart, so they are acknowledged.
+	for index := range pq.waiters {
+		pq.notifyResult(index, nil)
+	}
 	return pq.unrefClient(ctx)
 }
 
@@ -269,43 +342,133 @@ func (pq *persistentQueue[T]) unrefClient(ctx context.Context) error {
 // Offer inserts the specified element into this queue if it is possible to do so immediately
 // without violating capacity restrictions. If success returns no error.
 // It returns ErrQueueIsFull if no space is currently available.
+// If waitForResult is set, it blocks until the element is exported and returns the export result.
 func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
+	// Encode the request before taking the lock, the compression may be expensive.
+	item, err := pq.encodeItem(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	index, resultCh, err := pq.add(ctx, req, item)
+	if err != nil || resultCh == nil {
+		return err
+	}
+	select {
+	case err = <-resultCh:
+		return err
+	case <-ctx.Done():
+		// The item is still exported, but nobody waits for the result anymore.
+		pq.mu.Lock()
+		delete(pq.waiters, index)
+		pq.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// add stores the item and returns its index, with the channel receiving the export result if waitForResult is set.
+func (pq *persistentQueue[T]) add(ctx context.Context, req T, item storedItem) (uint64, chan error, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	size := pq.activeSizer.Sizeof(req)
+	bytesSize := pq.storedBytesSize(req, item)
+	size := bytesSize
+	if pq.sizerType != request.SizerTypeBytes {
+		size = pq.activeSizer.Sizeof(req)
+	}
 	for pq.internalSize()+size > pq.capacity {
 		if !pq.blockOnOverflow {
-			return ErrQueueIsFull
+			return 0, nil, ErrQueueIsFull
 		}
 		if err := pq.hasMoreSpace.Wait(ctx); err != nil {
-			return err
+			return 0, nil, err
 		}
 	}
 
 	pq.metadata.ItemsSize += pq.itemsSizer.Sizeof(req)
-	pq.metadata.BytesSize += pq.bytesSizer.Sizeof(req)
+	pq.metadata.BytesSize += bytesSize
 
-	return pq.putInternal(ctx, req)
+	if err := pq.putInternal(ctx, item, time.Now()); err != nil {
+		return 0, nil, err
+	}
+	index := pq.metadata.WriteIndex - 1
+	if !pq.waitForResult {
+		return index, nil, nil
+	}
+	// Buffered, so the result is never blocked by a waiter that is gone.
+	resultCh := make(chan error, 1)
+	pq.waiters[index] = resultCh
+	return index, resultCh, nil
 }
 
-// putInternal adds the request to the storage without updating items/bytes sizes.
-func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
-	pq.metadata.WriteIndex++
+// notifyResult sends the export result of the item to its waiter, if any. Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) notifyResult(index uint64, err error) {
+	if resultCh, ok := pq.waiters[index]; ok {
+		resultCh <- err
+		delete(pq.waiters, index)
+	}
+}
 
-	metadataBuf, err := proto.Marshal(&pq.metadata)
+// storedItem is a request as persisted in the storage.
+type storedItem struct {
+	value []byte
+	// metadata is the marshaled persistentqueue.PersistentItemMetadata, nil if the value is the request as encoded.
+	metadata []byte
+}
+
+// encodeItem encodes and, if configured, compresses the request.
+func (pq *persistentQueue[T]) encodeItem(ctx context.Context, req T) (storedItem, error) {
+	reqBuf, err := pq.encoding.Marshal(ctx, req)
+	if err != nil || pq.compressor == nil {
+		return storedItem{value: reqBuf}, err
+	}
+	compressed, err := pq.compressor.compress(reqBuf)
 	if err != nil {
-		return err
+		return storedItem{}, err
+	}
+	itemMetadata, err := proto.Marshal(&persistentqueue.PersistentItemMetadata{Compression: string(pq.compressor.typ)})
+	if err != nil {
+		return storedItem{}, err
 	}
+	return storedItem{value: compressed, metadata: itemMetadata}, nil
+}
 
-	reqBuf, err := pq.encoding.Marshal(ctx, req)
+// decodeItem decodes a stored item, whatever the compression used when it was stored.
+func (pq *persistentQueue[T]) decodeItem(item storedItem) (context.Context, T, error) {
+	reqBuf, err := persistentqueue.DecodeValue(item.value, item.metadata)
+	if err != nil {
+		var req T
+		return context.Background(), req, err
+	}
+	return pq.encoding.Unmarshal(reqBuf)
+}
+
+// storedBytesSize returns the bytes size of the item. The compressed items are accounted by their stored size,
+// so the capacity limits the disk usage.
+func (pq *persistentQueue[T]) storedBytesSize(req T, item storedItem) int64 {
+	if item.metadata == nil {
+		return pq.bytesSizer.Sizeof(req)
+	}
+	return int64(len(item.value))
+}
+
+// putInternal adds the item to the storage without updating items/bytes sizes.
+func (pq *persistentQueue[T]) putInternal(ctx context.Context, item storedItem, enqueueTime time.Time) error {
+	pq.metadata.WriteIndex++
+
+	metadataBuf, err := proto.Marshal(&pq.metadata)
 	if err != nil {
 		return err
 	}
+
 	// Carry out a transaction where we both add the item and update the write index
 	ops := []*storage.Operation{
-		storage.SetOperation(metadataKey, metadataBuf),
-		storage.SetOperation(getItemKey(pq.metadata.WriteIndex-1), reqBuf),
+		storage.SetOperation(persistentqueue.MetadataKey, metadataBuf),
+		storage.SetOperation(persistentqueue.ItemKey(pq.metadata.WriteIndex-1), item.value),
+		storage.SetOperation(persistentqueue.EnqueueTimeKey(pq.metadata.WriteIndex-1), persistentqueue.TimeToBytes(enqueueTime)),
+	}
+	if item.metadata != nil {
+		ops = append(ops, storage.SetOperation(persistentqueue.ItemMetadataKey(pq.metadata.WriteIndex-1), item.metadata))
 	}
 	if err := pq.client.Batch(ctx, ops...); err != nil {
 		// At this moment, metadata may be updated in the storage, so we cannot just revert changes to the
@@ -314,6 +477,9 @@ func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
 	}
 
 	pq.hasMoreElements.Signal()
+	if pq.notify != nil {
+		pq.notify()
+	}
 
 	return nil
 }
@@ -328,21 +494,8 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 			return context.Background(), req, nil, false
 		}
 
-		// Read until either a successful retrieved element or no more elements in the storage.
-		for pq.metadata.ReadIndex != pq.metadata.WriteIndex {
-			index, req, reqCtx, consumed := pq.getNextItem(ctx)
-			// Ensure the used size are in sync when queue is drained.
-			if pq.requestSize() == 0 {
-				pq.metadata.BytesSize = 0
-				pq.metadata.ItemsSize = 0
-			}
-			if consumed {
-				id := indexDonePool.Get().(*indexDone)
-				id.reset(index, pq.itemsSizer.Sizeof(req), pq.bytesSizer.Sizeof(req), pq)
-				return reqCtx, req, id, true
-			}
-			// More space available, data was dropped.
-			pq.hasMoreSpace.Signal()
+		if reqCtx, req, done, ok := pq.readNext(ctx); ok {
+			return reqCtx, req, done, true
 		}
 
 		// TODO: Need to change the Queue interface to return an error to allow distinguish between shutdown and context canceled.
@@ -351,10 +504,48 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 	}
 }
 
-// getNextItem pulls the next available item from the persistent storage along with its index. Once processing is
-// finished, the index should be called with onDone to clean up the storage. If no new item is available,
-// returns false.
-func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, context.Context, bool) {
+// tryRead pulls the next available item from the queue if any, it never blocks.
+func (pq *persistentQueue[T]) tryRead(ctx context.Context) (context.Context, T, Done, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if pq.stopped {
+		var req T
+		return context.Background(), req, nil, false
+	}
+	return pq.readNext(ctx)
+}
+
+func (pq *persistentQueue[T]) setNotify(notify func()) {
+	pq.notify = notify
+}
+
+// readNext reads until either a successful retrieved element or no more elements in the storage.
+// Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) readNext(ctx context.Context) (context.Context, T, Done, bool) {
+	for pq.metadata.ReadIndex != pq.metadata.WriteIndex {
+		index, req, bytesSize, reqCtx, consumed := pq.getNextItem(ctx)
+		// Ensure the used size are in sync when queue is drained.
+		if pq.requestSize() == 0 {
+			pq.metadata.BytesSize = 0
+			pq.metadata.ItemsSize = 0
+		}
+		if consumed {
+			id := indexDonePool.Get().(*indexDone)
+			id.reset(index, pq.itemsSizer.Sizeof(req), bytesSize, pq)
+			return reqCtx, req, id, true
+		}
+		// More space available, data was dropped.
+		pq.hasMoreSpace.Signal()
+	}
+	var req T
+	return context.Background(), req, nil, false
+}
+
+// getNextItem pulls the next available item from the persistent storage along with its index and bytes size.
+// Once processing is finished, the index should be called with onDone to clean up the storage. If no new item
+// is available, returns false.
+func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, int64, context.Context, bool) {
 	index := pq.metadata.ReadIndex
 	// Increase here, so even if errors happen below, it always iterates
 	pq.metadata.ReadIndex++
@@ -364,30 +555,40 @@ func (pq *persistentQueue[T]) getNextItem(ctx context.Context) (uint64, T, conte
 	restoredCtx := context.Background()
 	metadataBytes, err := proto.Marshal(&pq.metadata)
 	if err != nil {
-		return 0, req, restoredCtx, false
+		return 0, req, 0, restoredCtx, false
 	}
 
-	getOp := storage.GetOperation(getItemKey(index))
-	err = pq.client.Batch(ctx, storage.SetOperation(metadataKey, metadataBytes), getOp)
+	getOp := storage.GetOperation(persistentqueue.ItemKey(index))
+	getMetadataOp := storage.GetOperation(persistentqueue.ItemMetadataKey(index))
+	err = pq.client.Batch(ctx, storage.SetOperation(persistentqueue.MetadataKey, metadataBytes), getOp, getMetadataOp)
+	item := storedItem{value: getOp.Value, metadata: getMetadataOp.Value}
 	if err == nil {
-		restoredCtx, req, err = pq.encoding.Unmarshal(getOp.Value)
+		restoredCtx, req, err = pq.decodeItem(item)
+		if index < pq.restoredIndex {
+			restoredCtx = context.WithValue(restoredCtx, restoredCtxKey{}, true)
+		}
 	}
 
 	if err != nil {
-		pq.logger.Debug("Failed to dispatch item", zap.Error(err))
+		if errors.Is(err, persistentqueue.ErrDecryptionFailed) {
+			pq.logger.Error("Failed to decrypt item, dropping it", zap.Uint64("index", index), zap.Error(err))
+		} else {
+			pq.logger.Debug("Failed to dispatch item", zap.Error(err))
+		}
+		pq.notifyResult(index, err)
 		// We need to make sure that currently dispatched items list is cleaned
 		if err = pq.itemDispatchingFinish(ctx, index); err != nil {
 			pq.logger.Error("Error deleting item from queue", zap.Error(err))
 		}
 
-		return 0, req, restoredCtx, false
+		return 0, req, 0, restoredCtx, false
 	}
 
 	// Increase the reference count, so the client is not closed while the request is being processed.
 	// The client cannot be closed because we hold the lock since last we checked `stopped`.
 	pq.refClient++
 
-	return index, req, restoredCtx, true
+	return index, req, pq.storedBytesSize(req, item), restoredCtx, true
 }
 
 // onDone should be called to remove the item of the given index from the queue once processing is finished.
@@ -405,8 +606,10 @@ func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize int64, c
 	if experr.IsShutdownErr(consumeErr) {
 		// The queue is shutting down, don't mark the item as dispatched, so it's picked up again after restart.
 		// TODO: Handle partially delivered requests by updating their values in the storage.
+		pq.notifyResult(index, nil)
 		return
 	}
+	pq.notifyResult(index, consumeErr)
 
 	pq.metadata.BytesSize -= bytesSize
 	if pq.metadata.BytesSize < 0 {
@@ -427,7 +630,7 @@ func (pq *persistentQueue[T]) onDone(index uint64, itemsSize, bytesSize int64, c
 
 // retrieveAndEnqueueNotDispatchedReqs gets the items for which sending was not finished, cleans the storage
 // and moves the items at the back of the queue.
-func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Context) {
+func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Context) error {
 	var dispatchedItems []uint64
 
 	pq.mu.Lock()
@@ -437,30 +640,43 @@ func (pq *persistentQueue[T]) retrieveAndEnqueueNotDispatchedReqs(ctx context.Co
 	if err == nil {
 		dispatchedItems, err = bytesToItemIndexArray(itemKeysBuf)
 	}
+	if errors.Is(err, persistentqueue.ErrDecryptionFailed) {
+		return fmt.Errorf("failed to decrypt the items left for dispatch by consumers, check the encryption keys: %w", err)
+	}
 	if err != nil {
 		pq.logger.Error("Could not fetch items left for dispatch by consumers", zap.Error(err))
-		return
+		return nil
 	}
 
-	pq.enqueueNotDispatchedReqs(ctx, dispatchedItems)
+	return pq.enqueueNotDispatchedReqs(ctx, dispatchedItems)
 }
 
-func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, dispatchedItems []uint64) {
+// enqueueNotDispatchedReqs moves the items left for dispatch back to the queue. It fails only if the items
+// cannot be decrypted, they are kept in the storage in this case.
+func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, dispatchedItems []uint64) error {
 	if len(dispatchedItems) == 0 {
 		pq.logger.Debug("No items left for dispatch by consumers")
-		return
+		return nil
 	}
 
 	pq.logger.Info("Fetching items left for dispatch by consumers", zap.Int(zapNumberOfItems,
 		len(dispatchedItems)))
 	retrieveBatch := make([]*storage.Operation, len(dispatchedItems))
-	cleanupBatch := make([]*storage.Operation, len(dispatchedItems))
+	retrieveTimeBatch := make([]*storage.Operation, len(dispatchedItems))
+	retrieveMetadataBatch := make([]*storage.Operation, len(dispatchedItems))
+	cleanupBatch := make([]*storage.Operation, 0, 3*len(dispatchedItems))
 	for i, it := range dispatchedItems {
-		key := getItemKey(it)
+		key := persistentqueue.ItemKey(it)
 		retrieveBatch[i] = storage.GetOperation(key)
-		cleanupBatch[i] = storage.DeleteOperation(key)
+		retrieveTimeBatch[i] = storage.GetOperation(persistentqueue.EnqueueTimeKey(it))
+		retrieveMetadataBatch[i] = storage.GetOperation(persistentqueue.ItemMetadataKey(it))
+		cleanupBatch = append(cleanupBatch, storage.DeleteOperation(key), storage.DeleteOperation(persistentqueue.EnqueueTimeKey(it)),
+			storage.DeleteOperation(persistentqueue.ItemMetadataKey(it)))
+	}
+	retrieveErr := pq.client.Batch(ctx, append(append(retrieveBatch, retrieveTimeBatch...), retrieveMetadataBatch...)...)
+	if errors.Is(retrieveErr, persistentqueue.ErrDecryptionFailed) {
+		return fmt.Errorf("failed to decrypt the items left for dispatch by consumers, check the encryption keys: %w", retrieveErr)
 	}
-	retrieveErr := pq.client.Batch(ctx, retrieveBatch...)
 	cleanupErr := pq.client.Batch(ctx, cleanupBatch...)
 
 	if cleanupErr != nil {
@@ -469,22 +685,28 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 
 	if retrieveErr != nil {
 		pq.logger.Warn("Failed retrieving items left by consumers", zap.Error(retrieveErr))
-		return
+		return nil
 	}
 
 	errCount := 0
-	for _, op := range retrieveBatch {
+	for i, op := range retrieveBatch {
 		if op.Value == nil {
 			pq.logger.Warn("Failed retrieving item", zap.String(zapKey, op.Key), zap.Error(errValueNotSet))
 			continue
 		}
-		reqCtx, req, err := pq.encoding.Unmarshal(op.Value)
+		// The item is stored back as is, decode it only to drop the invalid items.
+		item := storedItem{value: op.Value, metadata: retrieveMetadataBatch[i].Value}
 		// If error happened or item is nil, it will be efficiently ignored
-		if err != nil {
+		if _, _, err := pq.decodeItem(item); err != nil {
 			pq.logger.Warn("Failed unmarshalling item", zap.String(zapKey, op.Key), zap.Error(err))
 			continue
 		}
-		if pq.putInternal(reqCtx, req) != nil { //nolint:contextcheck
+		// Keep the original enqueue time, if known, so the age of the item is preserved across restarts.
+		enqueueTime, timeErr := persistentqueue.BytesToTime(retrieveTimeBatch[i].Value)
+		if timeErr != nil {
+			enqueueTime = time.Now()
+		}
+		if pq.putInternal(ctx, item, enqueueTime) != nil {
 			errCount++
 		}
 	}
@@ -496,6 +718,7 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 		pq.logger.Info("Moved items for dispatching back to queue",
 			zap.Int(zapNumberOfItems, len(retrieveBatch)))
 	}
+	return nil
 }
 
 // itemDispatchingFinish removes the item from the list of currently dispatched items and deletes it from the persistent queue
@@ -520,9 +743,11 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 		return err
 	}
 
-	setOp := storage.SetOperation(metadataKey, metadataBytes)
-	deleteOp := storage.DeleteOperation(getItemKey(index))
-	err = pq.client.Batch(ctx, setOp, deleteOp)
+	setOp := storage.SetOperation(persistentqueue.MetadataKey, metadataBytes)
+	deleteOp := storage.DeleteOperation(persistentqueue.ItemKey(index))
+	deleteTimeOp := storage.DeleteOperation(persistentqueue.EnqueueTimeKey(index))
+	deleteMetadataOp := storage.DeleteOperation(persistentqueue.ItemMetadataKey(index))
+	err = pq.client.Batch(ctx, setOp, deleteOp, deleteTimeOp, deleteMetadataOp)
 	if err == nil {
 		// Everything ok, exit
 		return nil
@@ -532,7 +757,7 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 	pq.logger.Warn("Failed updating currently dispatched items, trying to delete the item first",
 		zap.Error(err))
 
-	if err = pq.client.Batch(ctx, deleteOp); err != nil {
+	if err = pq.client.Batch(ctx, deleteOp, deleteTimeOp, deleteMetadataOp); err != nil {
 		// Return an error here, as this indicates an issue with the underlying storage medium
 		return fmt.Errorf("failed deleting item from queue, got error from storage: %w", err)
 	}
@@ -546,7 +771,7 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 	return nil
 }
 
-func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal pipeline.Signal) (storage.Client, error) {
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, storageName string) (storage.Client, error) {
 	ext, found := host.GetExtensions()[storageID]
 	if !found {
 		return nil, errNoStorageClient
@@ -557,11 +782,7 @@ func toStorageClient(ctx context.Context, storageID component.ID, host component
 		return nil, errWrongExtensionType
 	}
 
-	return storageExt.GetClient(ctx, component.KindExporter, ownerID, signal.String())
-}
-
-func getItemKey(index uint64) string {
-	return strconv.FormatUint(index, 10)
+	return storageExt.GetClient(ctx, component.KindExporter, ownerID, storageName)
 }
 
 func bytesToItemIndex(buf []byte) (uint64, error) {
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func readAllPersistentItems(t *testing.T, ps *persistentQueue[intRequest]) []persistentqueue.Item {
+	var items []persistentqueue.Item
+	require.NoError(t, persistentqueue.Read(context.Background(), ps.client, func(item persistentqueue.Item) error {
+		items = append(items, item)
+		return nil
+	}))
+	return items
+}
+
+func TestReadPersistentQueue(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+
+	assert.Empty(t, readAllPersistentItems(t, ps))
+
+	start := time.Now()
+	for i := range 3 {
+		require.NoError(t, ps.Offer(context.Background(), intRequest(i+1)))
+	}
+	// Takes index 0 in process.
+	_, _, _, found := ps.Read(context.Background())
+	require.True(t, found)
+
+	items := readAllPersistentItems(t, ps)
+	require.Len(t, items, 3)
+	for i, item := range items {
+		assert.Equal(t, uint64(i), item.Index)
+		assert.Equal(t, i == 0, item.Dispatched)
+		assert.False(t, item.EnqueueTime.Before(start))
+		_, req, err := ps.encoding.Unmarshal(item.Value)
+		require.NoError(t, err)
+		assert.Equal(t, intRequest(i+1), req)
+	}
+
+	errStop := errors.New("stop")
+	count := 0
+	require.ErrorIs(t, persistentqueue.Read(context.Background(), ps.client, func(persistentqueue.Item) error {
+		count++
+		return errStop
+	}), errStop)
+	assert.Equal(t, 1, count)
+}
+
+func TestDrainPersistentQueue(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+
+	for i := range 4 {
+		require.NoError(t, ps.Offer(context.Background(), intRequest(i+1)))
+	}
+	// Takes index 0 in process.
+	_, _, _, found := ps.Read(context.Background())
+	require.True(t, found)
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, ps.id, pipeline.SignalTraces.String())
+	require.NoError(t, err)
+
+	// Drain the dispatched item and the next one, then fail.
+	errStop := errors.New("stop")
+	var drained []uint64
+	require.ErrorIs(t, persistentqueue.Drain(context.Background(), client, func(item persistentqueue.Item) error {
+		if len(drained) == 2 {
+			return errStop
+		}
+		drained = append(drained, item.Index)
+		return nil
+	}), errStop)
+	assert.Equal(t, []uint64{0, 1}, drained)
+
+	var remaining []uint64
+	require.NoError(t, persistentqueue.Read(context.Background(), client, func(item persistentqueue.Item) error {
+		remaining = append(remaining, item.Index)
+		return nil
+	}))
+	assert.Equal(t, []uint64{2, 3}, remaining)
+
+	// A queue started on the drained storage sees only the remaining items.
+	newPs := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	assert.Equal(t, int64(2), newPs.Size())
+
+	require.NoError(t, persistentqueue.Drain(context.Background(), newPs.client, func(persistentqueue.Item) error { return nil }))
+	buf, err := newPs.client.Get(context.Background(), persistentqueue.MetadataKey)
+	require.NoError(t, err)
+	metadata := &persistentqueue.PersistentMetadata{}
+	require.NoError(t, proto.Unmarshal(buf, metadata))
+	assert.Equal(t, metadata.ReadIndex, metadata.WriteIndex)
+	assert.Zero(t, metadata.ItemsSize)
+	assert.Zero(t, metadata.BytesSize)
+	for i := range metadata.WriteIndex {
+		bb, err := newPs.client.Get(context.Background(), persistentqueue.ItemKey(i))
+		require.NoError(t, err)
+		require.Nil(t, bb)
+		bb, err = newPs.client.Get(context.Background(), persistentqueue.EnqueueTimeKey(i))
+		require.NoError(t, err)
+		require.Nil(t, bb)
+	}
+}
+
+func TestPersistentQueue_PreserveEnqueueTimeOnRestart(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	require.NoError(t, ps.Offer(context.Background(), intRequest(1)))
+	items := readAllPersistentItems(t, ps)
+	require.Len(t, items, 1)
+
+	// Takes index 0 in process, it is re-enqueued at a new index on restart.
+	_, _, _, found := ps.Read(context.Background())
+	require.True(t, found)
+	newPs := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	newItems := readAllPersistentItems(t, newPs)
+	require.Len(t, newItems, 1)
+	assert.Equal(t, uint64(1), newItems[0].Index)
+	assert.True(t, items[0].EnqueueTime.Equal(newItems[0].EnqueueTime))
+}
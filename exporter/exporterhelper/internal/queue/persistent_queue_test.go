@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -497,7 +498,7 @@ func TestToStorageClient(t *testing.T) {
 			ownerID := component.MustNewID("foo_exporter")
 
 			// execute
-			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 			// verify
 			if tt.expectedError != nil {
@@ -527,7 +528,7 @@ func TestInvalidStorageExtensionType(t *testing.T) {
 	ownerID := component.MustNewID("foo_exporter")
 
 	// execute
-	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 	// we should get an error about the extension type
 	require.ErrorIs(t, err, errWrongExtensionType)
@@ -603,7 +604,7 @@ func TestPersistentQueue_CorruptedData(t *testing.T) {
 			}
 
 			if c.corruptMetadataKey {
-				require.NoError(t, ps.client.Set(context.Background(), metadataKey, badBytes))
+				require.NoError(t, ps.client.Set(context.Background(), persistentqueue.MetadataKey, badBytes))
 			}
 
 			// Cannot close until we corrupt the data because the
@@ -667,7 +668,7 @@ func TestPersistentQueue_CurrentlyProcessedItems(t *testing.T) {
 
 	// There should be no items left in the storage
 	for i := uint64(0); i < newPs.metadata.WriteIndex; i++ {
-		bb, err := newPs.client.Get(context.Background(), getItemKey(i))
+		bb, err := newPs.client.Get(context.Background(), persistentqueue.ItemKey(i))
 		require.NoError(t, err)
 		require.Nil(t, bb)
 	}
@@ -791,6 +792,33 @@ func TestPersistentQueue_PutCloseReadClose(t *testing.T) {
 	require.NoError(t, newPs.Shutdown(context.Background()))
 }
 
+func TestPersistentQueue_IsRestored(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	ps := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	require.NoError(t, ps.Offer(context.Background(), intRequest(1)))
+	// The item being exported when the queue is shut down is restored too.
+	require.True(t, consume(ps, func(ctx context.Context, _ intRequest) error {
+		assert.False(t, IsRestored(ctx))
+		return experr.NewShutdownErr(nil)
+	}))
+	require.NoError(t, ps.Offer(context.Background(), intRequest(2)))
+	require.NoError(t, ps.Shutdown(context.Background()))
+
+	newPs := createTestPersistentQueueWithRequestsSizer(t, ext, 1000)
+	require.NoError(t, newPs.Offer(context.Background(), intRequest(3)))
+	var restored []intRequest
+	for range 3 {
+		require.True(t, consume(newPs, func(ctx context.Context, val intRequest) error {
+			if IsRestored(ctx) {
+				restored = append(restored, val)
+			}
+			return nil
+		}))
+	}
+	assert.ElementsMatch(t, []intRequest{1, 2}, restored)
+	require.NoError(t, newPs.Shutdown(context.Background()))
+}
+
 func BenchmarkPersistentQueue(b *testing.B) {
 	ext := storagetest.NewMockStorageExtension(nil)
 	ps := createTestPersistentQueueWithRequestsSizer(b, ext, 10000000)
@@ -1185,3 +1213,43 @@ func itemIndexArrayToBytes(arr []uint64) []byte {
 	}
 	return buf
 }
+
+func TestPersistentQueue_WaitForResult(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeRequests, 100)
+	set.WaitForResult = true
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), host))
+
+	// The export result is returned to the caller.
+	exportErr := errors.New("export failed")
+	errCh := make(chan error, 1)
+	go func() { errCh <- pq.Offer(context.Background(), intRequest(1)) }()
+	_, req, done, found := pq.Read(context.Background())
+	require.True(t, found)
+	assert.Equal(t, intRequest(1), req)
+	select {
+	case <-errCh:
+		t.Fatal("offer must wait for the export result")
+	case <-time.After(10 * time.Millisecond):
+	}
+	done.OnDone(exportErr)
+	require.ErrorIs(t, <-errCh, exportErr)
+
+	// The caller stops waiting when its context is done, the item is still exported.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, pq.Offer(ctx, intRequest(2)), context.DeadlineExceeded)
+	_, req, done, found = pq.Read(context.Background())
+	require.True(t, found)
+	assert.Equal(t, intRequest(2), req)
+	done.OnDone(nil)
+
+	// The items left in the storage at shutdown are acknowledged.
+	go func() { errCh <- pq.Offer(context.Background(), intRequest(3)) }()
+	assert.Eventually(t, func() bool { return pq.Size() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, pq.Shutdown(context.Background()))
+	require.NoError(t, <-errCh)
+	assert.Empty(t, pq.(*persistentQueue[intRequest]).waiters)
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+const (
+	// laneKey used to identify the priority lane in the lane metrics.
+	laneKey = "lane"
+)
+
+// PrioritySettings defines the lanes of a priority queue.
+type PrioritySettings[T any] struct {
+	// Lanes are the lanes of the queue, each one has its own capacity.
+	Lanes []LaneSettings
+	// DefaultLane is the index of the lane used when LaneFunc returns an invalid index. When the queue is persistent,
+	// this lane uses the same storage as a queue without lanes, so the data persisted before enabling lanes is kept.
+	DefaultLane int
+	// LaneFunc returns the index of the lane the request is assigned to.
+	LaneFunc func(context.Context, T) int
+}
+
+// LaneSettings defines a lane of a priority queue.
+type LaneSettings struct {
+	Name     string
+	Weight   int
+	Capacity int64
+}
+
+// laneQueue is a queue that can be used as a lane of the priorityQueue.
+type laneQueue[T any] interface {
+	readableQueue[T]
+	// tryRead pulls the next available item from the queue if any, it never blocks.
+	tryRead(context.Context) (context.Context, T, Done, bool)
+	// setNotify sets a function called every time an element is added to the queue, must be called before Start.
+	setNotify(func())
+}
+
+type priorityLane[T any] struct {
+	index  int
+	name   string
+	weight int
+	// current is the smooth weighted round-robin state of the lane.
+	current int
+	queue   laneQueue[T]
+}
+
+// priorityQueue is a queue made of multiple lanes, each lane being a memory or a persistent queue.
+// The lanes are dispatched using a smooth weighted round-robin, so a lane with weight 3 gets three times
+// more elements dispatched than a lane with weight 1 when both have elements available.
+type priorityQueue[T request.Request] struct {
+	lanes       []*priorityLane[T]
+	defaultLane int
+	laneFunc    func(context.Context, T) int
+	tb          *metadata.TelemetryBuilder
+
+	// mu guards the lanes round-robin state.
+	mu    sync.Mutex
+	order []*priorityLane[T]
+	empty []bool
+
+	// hasMoreElements receives a value every time an element is added to a lane.
+	hasMoreElements chan struct{}
+	stopped         chan struct{}
+	stopOnce        sync.Once
+}
+
+func newPriorityQueue[T request.Request](set Settings[T]) (readableQueue[T], error) {
+	pq := &priorityQueue[T]{
+		lanes:           make([]*priorityLane[T], len(set.Priority.Lanes)),
+		defaultLane:     set.Priority.DefaultLane,
+		laneFunc:        set.Priority.LaneFunc,
+		order:           make([]*priorityLane[T], len(set.Priority.Lanes)),
+		empty:           make([]bool, len(set.Priority.Lanes)),
+		hasMoreElements: make(chan struct{}, 1),
+		stopped:         make(chan struct{}),
+	}
+	if len(pq.lanes) == 0 || pq.defaultLane < 0 || pq.defaultLane >= len(pq.lanes) {
+		return nil, errors.New("invalid priority queue lanes")
+	}
+
+	laneSet := set
+	laneSet.Priority = nil
+	for i, lane := range set.Priority.Lanes {
+		laneSet.Capacity = lane.Capacity
+		var q laneQueue[T]
+		if set.StorageID == nil {
+			q = newMemoryQueue[T](laneSet).(*memoryQueue[T])
+		} else {
+			persistent := newPersistentQueue[T](laneSet).(*persistentQueue[T])
+			if i != pq.defaultLane {
+				persistent.storageName = persistentqueue.LaneStorageName(set.Signal, lane.Name)
+			}
+			q = persistent
+		}
+		q.setNotify(pq.notify)
+		pq.lanes[i] = &priorityLane[T]{index: i, name: lane.Name, weight: lane.Weight, queue: q}
+	}
+
+	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+	laneAttrs := make([]metric.MeasurementOption, len(pq.lanes))
+	for i, lane := range pq.lanes {
+		laneAttrs[i] = metric.WithAttributeSet(attribute.NewSet(
+			attribute.String(exporterKey, set.ID.String()),
+			attribute.String(dataTypeKey, set.Signal.String()),
+			attribute.String(laneKey, lane.name)))
+	}
+	err = errors.Join(
+		tb.RegisterExporterQueueLaneSizeCallback(func(_ context.Context, o metric.Int64Observer) error {
+			for i, lane := range pq.lanes {
+				o.Observe(lane.queue.Size(), laneAttrs[i])
+			}
+			return nil
+		}),
+		tb.RegisterExporterQueueLaneCapacityCallback(func(_ context.Context, o metric.Int64Observer) error {
+			for i, lane := range pq.lanes {
+				o.Observe(lane.queue.Capacity(), laneAttrs[i])
+			}
+			return nil
+		}))
+	if err != nil {
+		tb.Shutdown()
+		return nil, err
+	}
+	pq.tb = tb
+	return pq, nil
+}
+
+// Start starts all the lanes.
+func (pq *priorityQueue[T]) Start(ctx context.Context, host component.Host) error {
+	for i, lane := range pq.lanes {
+		if err := lane.queue.Start(ctx, host); err != nil {
+			for _, started := range pq.lanes[:i] {
+				err = errors.Join(err, started.queue.Shutdown(ctx))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Offer adds the element to the lane it is assigned to.
+func (pq *priorityQueue[T]) Offer(ctx context.Context, el T) error {
+	idx := pq.laneFunc(ctx, el)
+	if idx < 0 || idx >= len(pq.lanes) {
+		idx = pq.defaultLane
+	}
+	return pq.lanes[idx].queue.Offer(ctx, el)
+}
+
+// Read removes the next element to dispatch from the lanes and returns it.
+// The call blocks until there is an item available or the queue is stopped.
+// The function returns true when an item is consumed or false if the queue is stopped and all the lanes are emptied.
+func (pq *priorityQueue[T]) Read(ctx context.Context) (context.Context, T, Done, bool) {
+	for {
+		// Check before reading the lanes, so the elements added before the shutdown are still dispatched.
+		stopped := pq.isStopped()
+		if elCtx, el, done, ok := pq.tryRead(ctx); ok {
+			// Other consumers may be waiting while more elements are available.
+			pq.notify()
+			return elCtx, el, done, true
+		}
+		if stopped {
+			var el T
+			return context.Background(), el, nil, false
+		}
+
+		select {
+		case <-pq.hasMoreElements:
+		case <-pq.stopped:
+		}
+	}
+}
+
+// tryRead reads from the lanes in the smooth weighted round-robin order, skipping the lanes without elements.
+func (pq *priorityQueue[T]) tryRead(ctx context.Context) (context.Context, T, Done, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	copy(pq.order, pq.lanes)
+	slices.SortStableFunc(pq.order, func(a, b *priorityLane[T]) int {
+		return cmp.Compare(b.current+b.weight, a.current+a.weight)
+	})
+	clear(pq.empty)
+	for _, lane := range pq.order {
+		elCtx, el, done, ok := lane.queue.tryRead(ctx)
+		if !ok {
+			pq.empty[lane.index] = true
+			continue
+		}
+		// Lanes without elements do not accumulate credit while idle, including the lanes not visited by this read.
+		total := 0
+		for _, l := range pq.lanes {
+			if pq.empty[l.index] || (l != lane && l.queue.Size() == 0) {
+				l.current = 0
+				continue
+			}
+			l.current += l.weight
+			total += l.weight
+		}
+		lane.current -= total
+		return elCtx, el, done, true
+	}
+	var el T
+	return context.Background(), el, nil, false
+}
+
+// notify wakes up one consumer waiting for elements, if any.
+func (pq *priorityQueue[T]) notify() {
+	select {
+	case pq.hasMoreElements <- struct{}{}:
+	default:
+	}
+}
+
+func (pq *priorityQueue[T]) isStopped() bool {
+	select {
+	case <-pq.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown stops all the lanes and wakes up the consumers.
+func (pq *priorityQueue[T]) Shutdown(ctx context.Context) error {
+	defer pq.tb.Shutdown()
+	var err error
+	for _, lane := range pq.lanes {
+		err = errors.Join(err, lane.queue.Shutdown(ctx))
+	}
+	pq.stopOnce.Do(func() { close(pq.stopped) })
+	return err
+}
+
+// Size returns the sum of the sizes of all the lanes.
+func (pq *priorityQueue[T]) Size() int64 {
+	var size int64
+	for _, lane := range pq.lanes {
+		size += lane.queue.Size()
+	}
+	return size
+}
+
+// Capacity returns the sum of the capacities of all the lanes.
+func (pq *priorityQueue[T]) Capacity() int64 {
+	var capacity int64
+	for _, lane := range pq.lanes {
+		capacity += lane.queue.Capacity()
+	}
+	return capacity
+}
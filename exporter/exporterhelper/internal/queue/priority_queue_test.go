@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// namedStorageExtension keeps the data of every storage client name separately.
+type namedStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu      sync.Mutex
+	clients map[string]storage.Extension
+}
+
+func (n *namedStorageExtension) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.clients == nil {
+		n.clients = map[string]storage.Extension{}
+	}
+	ext, ok := n.clients[name]
+	if !ok {
+		ext = storagetest.NewMockStorageExtension(nil)
+		n.clients[name] = ext
+	}
+	return ext.GetClient(ctx, kind, id, name)
+}
+
+// newPrioritySettings returns settings with a "high" lane of weight 3 for the requests greater or equal
+// than 100 and a default "low" lane of weight 1 for the other ones.
+func newPrioritySettings(set Settings[intRequest]) Settings[intRequest] {
+	set.Priority = &PrioritySettings[intRequest]{
+		Lanes: []LaneSettings{
+			{Name: "high", Weight: 3, Capacity: set.Capacity},
+			{Name: "low", Weight: 1, Capacity: set.Capacity},
+		},
+		DefaultLane: 1,
+		LaneFunc: func(_ context.Context, req intRequest) int {
+			if req >= 100 {
+				return 0
+			}
+			return -1
+		},
+	}
+	return set
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q, err := newBaseQueue(newPrioritySettings(newSettings(request.SizerTypeRequests, 10)))
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	assert.EqualValues(t, 20, q.Capacity())
+
+	for i := range 8 {
+		require.NoError(t, q.Offer(context.Background(), intRequest(i)))
+		require.NoError(t, q.Offer(context.Background(), intRequest(100+i)))
+	}
+	assert.EqualValues(t, 16, q.Size())
+
+	var got []intRequest
+	for range 8 {
+		assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+			got = append(got, el)
+			return nil
+		}))
+	}
+	// Requests are dispatched 3 to 1 and in order within a lane.
+	assert.Equal(t, []intRequest{100, 101, 0, 102, 103, 104, 1, 105}, got)
+	assert.EqualValues(t, 8, q.Size())
+
+	// The low lane gets everything when the high lane is empty.
+	got = got[:0]
+	for range 8 {
+		assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+			got = append(got, el)
+			return nil
+		}))
+	}
+	assert.Equal(t, []intRequest{106, 107, 2, 3, 4, 5, 6, 7}, got)
+	assert.EqualValues(t, 0, q.Size())
+
+	require.NoError(t, q.Shutdown(context.Background()))
+	assert.False(t, consume(q, func(context.Context, intRequest) error { t.FailNow(); return nil }))
+	// The queue can be shut down again, e.g. by a rolled back reload.
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueueIdleLaneCredit(t *testing.T) {
+	q, err := newBaseQueue(newPrioritySettings(newSettings(request.SizerTypeRequests, 10)))
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The low lane is never visited while the high lane has elements, it must not accumulate credit.
+	for i := range 8 {
+		require.NoError(t, q.Offer(context.Background(), intRequest(100+i)))
+		assert.True(t, consume(q, func(context.Context, intRequest) error { return nil }))
+	}
+
+	for i := range 4 {
+		require.NoError(t, q.Offer(context.Background(), intRequest(i)))
+		require.NoError(t, q.Offer(context.Background(), intRequest(100+i)))
+	}
+	var got []intRequest
+	for range 8 {
+		assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+			got = append(got, el)
+			return nil
+		}))
+	}
+	assert.Equal(t, []intRequest{100, 101, 0, 102, 103, 1, 2, 3}, got)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueueLaneFull(t *testing.T) {
+	q, err := newBaseQueue(newPrioritySettings(newSettings(request.SizerTypeRequests, 1)))
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, q.Offer(context.Background(), intRequest(1)))
+	require.ErrorIs(t, q.Offer(context.Background(), intRequest(2)), ErrQueueIsFull)
+	// Other lanes are not affected.
+	require.NoError(t, q.Offer(context.Background(), intRequest(100)))
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueueDrainWhenShutdown(t *testing.T) {
+	q, err := newBaseQueue(newPrioritySettings(newSettings(request.SizerTypeRequests, 10)))
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, q.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, q.Offer(context.Background(), intRequest(100)))
+	require.NoError(t, q.Shutdown(context.Background()))
+
+	for _, expected := range []intRequest{100, 1} {
+		assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+			assert.Equal(t, expected, el)
+			return nil
+		}))
+	}
+	assert.False(t, consume(q, func(context.Context, intRequest) error { t.FailNow(); return nil }))
+}
+
+func TestPriorityQueueBlockingRead(t *testing.T) {
+	q, err := newBaseQueue(newPrioritySettings(newSettings(request.SizerTypeRequests, 10)))
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	const numConsumers = 5
+	var mu sync.Mutex
+	var got []intRequest
+	wg := sync.WaitGroup{}
+	for range numConsumers {
+		wg.Go(func() {
+			for consume(q, func(_ context.Context, el intRequest) error {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, el)
+				return nil
+			}) {
+			}
+		})
+	}
+
+	for i := range 10 {
+		require.NoError(t, q.Offer(context.Background(), intRequest(i)))
+		require.NoError(t, q.Offer(context.Background(), intRequest(100+i)))
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 20
+	}, time.Second, time.Millisecond)
+	require.NoError(t, q.Shutdown(context.Background()))
+	wg.Wait()
+}
+
+func TestPriorityQueuePersistent(t *testing.T) {
+	ext := &namedStorageExtension{}
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newPrioritySettings(newSettingsWithStorage(request.SizerTypeRequests, 10))
+
+	q, err := newBaseQueue(set)
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), host))
+	require.NoError(t, q.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, q.Offer(context.Background(), intRequest(2)))
+	require.NoError(t, q.Offer(context.Background(), intRequest(100)))
+	require.NoError(t, q.Shutdown(context.Background()))
+
+	// The default lane uses the same storage as a queue without lanes.
+	assert.Len(t, ext.clients, 2)
+	assert.Contains(t, ext.clients, pipeline.SignalTraces.String())
+	assert.Contains(t, ext.clients, persistentqueue.LaneStorageName(pipeline.SignalTraces, "high"))
+
+	// The data is restored in the right lanes after a restart.
+	q, err = newBaseQueue(set)
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), host))
+	assert.EqualValues(t, 3, q.Size())
+	for _, expected := range []intRequest{100, 1, 2} {
+		assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+			assert.Equal(t, expected, el)
+			return nil
+		}))
+	}
+	assert.EqualValues(t, 0, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueueMetrics(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := newPrioritySettings(newSettings(request.SizerTypeRequests, 10))
+	set.Telemetry = tt.NewTelemetrySettings()
+	q, err := newBaseQueue(set)
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, q.Offer(context.Background(), intRequest(1)))
+	require.NoError(t, q.Offer(context.Background(), intRequest(2)))
+	require.NoError(t, q.Offer(context.Background(), intRequest(100)))
+
+	laneAttrs := func(lane string) attribute.Set {
+		return attribute.NewSet(
+			attribute.String(exporterKey, exporterID.String()),
+			attribute.String(dataTypeKey, pipeline.SignalTraces.String()),
+			attribute.String(laneKey, lane))
+	}
+	metadatatest.AssertEqualExporterQueueLaneSize(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{Attributes: laneAttrs("high"), Value: 1},
+			{Attributes: laneAttrs("low"), Value: 2},
+		}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterQueueLaneCapacity(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{Attributes: laneAttrs("high"), Value: 10},
+			{Attributes: laneAttrs("low"), Value: 10},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestPriorityQueueInvalidSettings(t *testing.T) {
+	set := newPrioritySettings(newSettings(request.SizerTypeRequests, 10))
+	set.Priority.DefaultLane = 2
+	_, err := newBaseQueue(set)
+	require.Error(t, err)
+}
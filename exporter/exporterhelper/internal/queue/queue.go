@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -76,10 +77,19 @@ type Settings[T request.Request] struct {
 	Encoding         Encoding[T]
 	ID               component.ID
 	Telemetry        component.TelemetrySettings
+	// Priority if set, splits the queue in lanes dispatched using weighted fair queuing.
+	Priority *PrioritySettings[T]
+	// Encryption if set, encrypts the requests persisted in the storage.
+	Encryption *persistentqueue.EncryptionSettings
+	// Compression if set, compresses the requests persisted in the storage.
+	Compression *CompressionSettings
 }
 
 func NewQueue[T request.Request](set Settings[T], next ConsumeFunc[T]) (Queue[T], error) {
-	q := newBaseQueue(set)
+	q, err := newBaseQueue(set)
+	if err != nil {
+		return nil, err
+	}
 	oq, err := newObsQueue(set, newAsyncQueue(q, set.NumConsumers, next, set.ReferenceCounter))
 	if err != nil {
 		return nil, err
@@ -88,13 +98,17 @@ func NewQueue[T request.Request](set Settings[T], next ConsumeFunc[T]) (Queue[T]
 	return oq, nil
 }
 
-func newBaseQueue[T request.Request](set Settings[T]) readableQueue[T] {
+func newBaseQueue[T request.Request](set Settings[T]) (readableQueue[T], error) {
+	if set.Priority != nil {
+		return newPriorityQueue(set)
+	}
+
 	// Configure memory queue or persistent based on the config.
 	if set.StorageID == nil {
-		return newMemoryQueue[T](set)
+		return newMemoryQueue[T](set), nil
 	}
 
-	return newPersistentQueue[T](set)
+	return newPersistentQueue[T](set), nil
 }
 
 // TODO: Investigate why linter "unused" fails if add a private "read" func on the Queue.
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+)
+
+// NewCompressionSettings returns the compression of the persisted requests, nil if they are not compressed.
+func NewCompressionSettings(cfg Config) *queue.CompressionSettings {
+	if !cfg.Compression.IsCompressed() {
+		return nil
+	}
+	return &queue.CompressionSettings{Type: cfg.Compression, Params: cfg.CompressionParams}
+}
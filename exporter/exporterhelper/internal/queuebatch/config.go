@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
@@ -18,9 +20,13 @@ import (
 // Config defines configuration for queueing and batching incoming requests.
 type Config struct {
 	// WaitForResult determines if incoming requests are blocked until the request is processed or not.
-	// Currently, this option is not available when persistent queue is configured using the storage configuration.
 	WaitForResult bool `mapstructure:"wait_for_result"`
 
+	// WaitForResultMode determines when the requests are acknowledged with a persistent queue and `wait_for_result`.
+	// It accepts "exported" (default), to wait for the export result, or "persisted", to return once the request
+	// is written to the storage.
+	WaitForResultMode WaitForResultMode `mapstructure:"wait_for_result_mode"`
+
 	// Sizer determines the type of size measurement used by this component.
 	// It accepts "requests", "items", or "bytes".
 	Sizer request.SizerType `mapstructure:"sizer"`
@@ -44,6 +50,34 @@ type Config struct {
 
 	// BatchConfig it configures how the requests are consumed from the queue and batch together during consumption.
 	Batch configoptional.Optional[BatchConfig] `mapstructure:"batch"`
+
+	// DeadLetter if configured, persists the requests that could not be exported (permanent errors or retries
+	// exhausted) using a storage extension, or sends them to another exporter, instead of dropping them.
+	DeadLetter configoptional.Optional[DeadLetterConfig] `mapstructure:"dead_letter"`
+
+	// Priority if configured, assigns the requests to lanes that are dispatched using weighted fair queuing,
+	// so a flood of low priority data does not delay the high priority data.
+	Priority configoptional.Optional[PriorityConfig] `mapstructure:"priority"`
+
+	// AdaptiveConcurrency if configured, adjusts the number of concurrent exports between `min_concurrency`
+	// and `num_consumers` based on the observed latency and throttling errors.
+	AdaptiveConcurrency configoptional.Optional[AdaptiveConcurrencyConfig] `mapstructure:"adaptive_concurrency"`
+
+	// RateLimit if configured, limits the size of the data sent to the backend per time window.
+	RateLimit configoptional.Optional[RateLimitConfig] `mapstructure:"rate_limit"`
+
+	// CircuitBreaker if configured, stops the exports for a while when most of them fail, leaving the data
+	// in the queue instead of retrying against a backend that is down.
+	CircuitBreaker configoptional.Optional[CircuitBreakerConfig] `mapstructure:"circuit_breaker"`
+
+	// Encryption if configured, encrypts the requests stored by the persistent queue and the dead letter queue.
+	Encryption configoptional.Optional[EncryptionConfig] `mapstructure:"encryption"`
+
+	// Compression if set, compresses the requests stored by the persistent queue and the dead letter queue.
+	Compression configcompression.Type `mapstructure:"compression"`
+
+	// CompressionParams configures the compression algorithm set in `compression`.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params"`
 }
 
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
@@ -72,9 +106,22 @@ func (cfg *Config) Validate() error {
 		return errors.New("`queue_size` must be positive")
 	}
 
-	// Only support request sizer for persistent queue at this moment.
-	if cfg.StorageID != nil && cfg.WaitForResult {
-		return errors.New("`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	switch cfg.WaitForResultMode {
+	case "", WaitForResultModeExported:
+	case WaitForResultModePersisted:
+		if cfg.StorageID == nil {
+			return errors.New("`wait_for_result_mode` `persisted` requires a persistent queue configured with `storage`")
+		}
+	default:
+		return fmt.Errorf("`wait_for_result_mode` must be `exported` or `persisted`, found %q", cfg.WaitForResultMode)
+	}
+
+	if cfg.WaitForResultMode != "" && !cfg.WaitForResult {
+		return errors.New("`wait_for_result_mode` requires `wait_for_result`")
+	}
+
+	if cfg.AdaptiveConcurrency.HasValue() && cfg.AdaptiveConcurrency.Get().MinConcurrency > cfg.NumConsumers {
+		return errors.New("`adaptive_concurrency::min_concurrency` must be less than or equal to `num_consumers`")
 	}
 
 	if cfg.Batch.HasValue() && cfg.Batch.Get().Sizer == cfg.Sizer {
@@ -84,9 +131,36 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Batch.HasValue() && cfg.Batch.Get().Partition.MaxPartitionSize > cfg.QueueSize {
+		return errors.New("`partition::max_partition_size` must be less than or equal to `queue_size`")
+	}
+
+	if cfg.Encryption.HasValue() && cfg.StorageID == nil && !cfg.hasDeadLetterStorage() {
+		return errors.New("`encryption` requires a persistent queue configured with `storage` or a `dead_letter` queue")
+	}
+
+	if cfg.Compression.IsCompressed() {
+		if cfg.StorageID == nil && !cfg.hasDeadLetterStorage() {
+			return errors.New("`compression` requires a persistent queue configured with `storage` or a `dead_letter` queue")
+		}
+		if err := cfg.Compression.ValidateParams(cfg.CompressionParams); err != nil {
+			return fmt.Errorf("`compression_params`: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// WaitForResultMode determines when the requests are acknowledged with a persistent queue and `wait_for_result`.
+type WaitForResultMode string
+
+const (
+	// WaitForResultModeExported acknowledges the requests once they are exported.
+	WaitForResultModeExported WaitForResultMode = "exported"
+	// WaitForResultModePersisted acknowledges the requests once they are written to the storage.
+	WaitForResultModePersisted WaitForResultMode = "persisted"
+)
+
 // BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.
 type BatchConfig struct {
 	// FlushTimeout sets the time after which a batch will be sent regardless of its size.
@@ -107,6 +181,304 @@ type BatchConfig struct {
 	Partition PartitionConfig `mapstructure:"partition"`
 }
 
+// hasDeadLetterStorage returns whether the dead letters are persisted using a storage extension.
+func (cfg *Config) hasDeadLetterStorage() bool {
+	return cfg.DeadLetter.HasValue() && cfg.DeadLetter.Get().StorageID != (component.ID{})
+}
+
+// DeadLetterConfig defines a configuration for storing the requests that permanently failed to be exported.
+type DeadLetterConfig struct {
+	// StorageID is the storage extension used to persist the dead letters.
+	// The same storage extension as the persistent queue can be used, the dead letters are kept separately.
+	StorageID component.ID `mapstructure:"storage"`
+
+	// ExporterID is the exporter the dead letters are sent to. The exporter must be configured in a pipeline
+	// of the same signal.
+	ExporterID component.ID `mapstructure:"exporter"`
+
+	// QueueSize is the maximum number of requests kept in the dead letter storage.
+	// When the limit is reached, the failed requests are dropped. If zero, 1000 requests are kept.
+	QueueSize int64 `mapstructure:"queue_size"`
+}
+
+func (cfg *DeadLetterConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if (cfg.StorageID == (component.ID{})) == (cfg.ExporterID == (component.ID{})) {
+		return errors.New("`dead_letter` requires either a `storage` extension or an `exporter`")
+	}
+
+	if cfg.QueueSize < 0 {
+		return errors.New("`dead_letter::queue_size` must be non-negative")
+	}
+
+	if cfg.QueueSize != 0 && cfg.ExporterID != (component.ID{}) {
+		return errors.New("`dead_letter::queue_size` is only supported with a `storage` extension")
+	}
+
+	return nil
+}
+
+// AdaptiveConcurrencyConfig defines a configuration for adjusting the number of concurrent exports using an
+// additive increase, multiplicative decrease (AIMD) algorithm.
+type AdaptiveConcurrencyConfig struct {
+	// MinConcurrency is the minimum number of concurrent exports. If zero, 1 is used.
+	MinConcurrency int `mapstructure:"min_concurrency"`
+
+	// LatencyThreshold if positive, an export that takes longer is considered a sign of overload
+	// and decreases the limit.
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// DecreaseRatio is the ratio the limit is multiplied by when an overload is detected. If zero, 0.5 is used.
+	DecreaseRatio float64 `mapstructure:"decrease_ratio"`
+}
+
+func (cfg *AdaptiveConcurrencyConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.MinConcurrency < 0 {
+		return errors.New("`min_concurrency` must be non-negative")
+	}
+
+	if cfg.LatencyThreshold < 0 {
+		return errors.New("`latency_threshold` must be non-negative")
+	}
+
+	if cfg.DecreaseRatio < 0 || cfg.DecreaseRatio >= 1 {
+		return errors.New("`decrease_ratio` must be greater or equal to 0 and less than 1")
+	}
+
+	return nil
+}
+
+// RateLimitConfig defines a configuration for limiting the size of the data sent to the backend.
+type RateLimitConfig struct {
+	// Sizer determines the type of size measurement used by the rate limit.
+	// If not configured, use the same configuration as the queue.
+	// It accepts "requests", "items", or "bytes".
+	Sizer request.SizerType `mapstructure:"sizer"`
+
+	// Limit is the maximum size of the data sent during every interval.
+	Limit int64 `mapstructure:"limit"`
+
+	// Interval is the time window of the limit. If zero, 1 second is used.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BlockOnLimit determines the behavior when the limit is reached. If true, the export waits until it is allowed;
+	// otherwise, it immediately fails with a throttling error, so the request is retried after the needed delay.
+	// It must be true when the retries are disabled, otherwise the throttled data is dropped.
+	BlockOnLimit bool `mapstructure:"block_on_limit"`
+}
+
+func (cfg *RateLimitConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Sizer != (request.SizerType{}) && cfg.Sizer != request.SizerTypeRequests &&
+		cfg.Sizer != request.SizerTypeItems && cfg.Sizer != request.SizerTypeBytes {
+		return fmt.Errorf("`rate_limit` supports only `requests`, `items` or `bytes` sizer, found %q", cfg.Sizer.String())
+	}
+
+	if cfg.Limit <= 0 {
+		return fmt.Errorf("`limit` must be positive, found %d", cfg.Limit)
+	}
+
+	if cfg.Interval < 0 {
+		return fmt.Errorf("`interval` must be non-negative, found %d", cfg.Interval)
+	}
+
+	return nil
+}
+
+// CircuitBreakerConfig defines a configuration for stopping the exports when the backend is failing.
+type CircuitBreakerConfig struct {
+	// FailureRatio is the ratio of failed export attempts during the window that opens the circuit.
+	// If zero, 0.5 is used.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+
+	// MinRequests is the minimum number of export attempts during the window before the circuit can open.
+	// If zero, 10 is used.
+	MinRequests int `mapstructure:"min_requests"`
+
+	// Window is the duration after which the counts of export attempts are reset while the circuit is closed.
+	// If zero, 1 minute is used.
+	Window time.Duration `mapstructure:"window"`
+
+	// OpenDuration is the time the circuit stays open before probing the backend. If zero, 30 seconds is used.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// HalfOpenRequests is the number of successful probe attempts required to close the circuit.
+	// If zero, 1 is used.
+	HalfOpenRequests int `mapstructure:"half_open_requests"`
+}
+
+func (cfg *CircuitBreakerConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.FailureRatio < 0 || cfg.FailureRatio > 1 {
+		return fmt.Errorf("`failure_ratio` must be greater or equal to 0 and less or equal to 1, found %v", cfg.FailureRatio)
+	}
+
+	if cfg.MinRequests < 0 {
+		return fmt.Errorf("`min_requests` must be non-negative, found %d", cfg.MinRequests)
+	}
+
+	if cfg.Window < 0 {
+		return fmt.Errorf("`window` must be non-negative, found %d", cfg.Window)
+	}
+
+	if cfg.OpenDuration < 0 {
+		return fmt.Errorf("`open_duration` must be non-negative, found %d", cfg.OpenDuration)
+	}
+
+	if cfg.HalfOpenRequests < 0 {
+		return fmt.Errorf("`half_open_requests` must be non-negative, found %d", cfg.HalfOpenRequests)
+	}
+
+	return nil
+}
+
+// EncryptionConfig defines a configuration for encrypting the persisted requests using AES-GCM.
+type EncryptionConfig struct {
+	// squash ensures fields are correctly decoded in embedded struct.
+	EncryptionKeyConfig `mapstructure:",squash"`
+
+	// PreviousKeys are the keys that are no longer used to encrypt the requests, but are still needed to decrypt
+	// the requests persisted before the key rotation.
+	PreviousKeys []EncryptionKeyConfig `mapstructure:"previous_keys"`
+}
+
+func (cfg *EncryptionConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if err := cfg.EncryptionKeyConfig.validate(); err != nil {
+		return err
+	}
+
+	ids := map[string]struct{}{cfg.KeyID: {}}
+	for i, key := range cfg.PreviousKeys {
+		if err := key.validate(); err != nil {
+			return fmt.Errorf("`previous_keys::%d`: %w", i, err)
+		}
+		if _, ok := ids[key.KeyID]; ok {
+			return fmt.Errorf("duplicate `key_id` %q", key.KeyID)
+		}
+		ids[key.KeyID] = struct{}{}
+	}
+
+	return nil
+}
+
+// EncryptionKeyConfig defines an encryption key. The key must be the base64 encoding of 16, 24 or 32 bytes,
+// to select AES-128, AES-192 or AES-256.
+type EncryptionKeyConfig struct {
+	// KeyID identifies the key, it is stored with every encrypted request to find the key needed to decrypt it.
+	KeyID string `mapstructure:"key_id"`
+
+	// Key is the base64 encoded key.
+	Key configopaque.String `mapstructure:"key"`
+
+	// KeyFile is the path to a file containing the base64 encoded key.
+	KeyFile string `mapstructure:"key_file"`
+}
+
+func (cfg *EncryptionKeyConfig) validate() error {
+	if (cfg.Key == "") == (cfg.KeyFile == "") {
+		return errors.New("exactly one of `key` or `key_file` must be configured")
+	}
+
+	if len(cfg.KeyID) > maxEncryptionKeyIDLength {
+		return fmt.Errorf("`key_id` must be at most %d bytes long, found %d", maxEncryptionKeyIDLength, len(cfg.KeyID))
+	}
+
+	return nil
+}
+
+// PriorityConfig defines a configuration for assigning requests to priority lanes.
+type PriorityConfig struct {
+	// MetadataKey is the client.Metadata key used to assign the requests to lanes.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// ResourceAttribute is the resource attribute used to assign the requests to lanes when the metadata key
+	// is not configured or not present. The first resource that has the attribute is used.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// Lanes are the priority lanes, each one has its own queue.
+	Lanes []PriorityLaneConfig `mapstructure:"lanes"`
+
+	// DefaultLane is the name of the lane used for the requests that do not match any lane.
+	// If empty, the last lane is used.
+	DefaultLane string `mapstructure:"default_lane"`
+}
+
+// PriorityLaneConfig defines a priority lane of the sending queue.
+type PriorityLaneConfig struct {
+	// Name is the name of the lane.
+	Name string `mapstructure:"name"`
+
+	// Values are the metadata or resource attribute values of the requests assigned to this lane.
+	Values []string `mapstructure:"values"`
+
+	// Weight is the relative share of the consumers given to this lane when multiple lanes have data.
+	Weight int `mapstructure:"weight"`
+
+	// QueueSize is the capacity of the lane. If zero, the `queue_size` of the sending queue is used.
+	QueueSize int64 `mapstructure:"queue_size"`
+}
+
+func (cfg *PriorityConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.MetadataKey == "" && cfg.ResourceAttribute == "" {
+		return errors.New("`priority` requires a `metadata_key` or a `resource_attribute`")
+	}
+
+	if len(cfg.Lanes) == 0 {
+		return errors.New("`priority::lanes` must not be empty")
+	}
+
+	names := map[string]bool{}
+	values := map[string]string{}
+	for _, lane := range cfg.Lanes {
+		if lane.Name == "" {
+			return errors.New("`priority::lanes::name` must not be empty")
+		}
+		if names[lane.Name] {
+			return fmt.Errorf("duplicate priority lane %q", lane.Name)
+		}
+		names[lane.Name] = true
+		if lane.Weight <= 0 {
+			return fmt.Errorf("priority lane %q: `weight` must be positive", lane.Name)
+		}
+		if lane.QueueSize < 0 {
+			return fmt.Errorf("priority lane %q: `queue_size` must be non-negative", lane.Name)
+		}
+		for _, v := range lane.Values {
+			if other, ok := values[v]; ok {
+				return fmt.Errorf("value %q is assigned to both priority lanes %q and %q", v, other, lane.Name)
+			}
+			values[v] = lane.Name
+		}
+	}
+
+	if cfg.DefaultLane != "" && !names[cfg.DefaultLane] {
+		return fmt.Errorf("`priority::default_lane` %q is not a configured lane", cfg.DefaultLane)
+	}
+
+	return nil
+}
+
 // PartitionConfig defines a configuration for partitioning requests based on metadata keys.
 type PartitionConfig struct {
 	// MetadataKeys is a list of client.Metadata keys that will be used to partition
@@ -118,6 +490,21 @@ type PartitionConfig struct {
 	//
 	// Entries are case-insensitive. Duplicated entries will trigger a validation error.
 	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// MaxPartitions is the maximum number of active partitions, a partition is active while some of its data is in
+	// the sending queue. When the limit is reached, the requests that would create a new partition are rejected with
+	// a retryable error.
+	// If zero, the least recently used partition is flushed and removed when 10000 partitions are active.
+	MaxPartitions int `mapstructure:"max_partitions"`
+
+	// MaxPartitionSize is the maximum size of the sending queue that a single partition can occupy, using the
+	// queue sizer. When the limit is reached, the new requests of the partition are rejected. If zero, a partition
+	// can use the whole queue.
+	MaxPartitionSize int64 `mapstructure:"max_partition_size"`
+
+	// IdleTimeout is the duration after which a partition without data is removed.
+	// If zero, 10 times the `flush_timeout` is used.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
 }
 
 func (cfg *BatchConfig) Validate() error {
@@ -164,5 +551,17 @@ func (cfg *PartitionConfig) Validate() error {
 		uniq[l] = true
 	}
 
+	if cfg.MaxPartitions < 0 {
+		return fmt.Errorf("`max_partitions` must be non-negative, found %d", cfg.MaxPartitions)
+	}
+
+	if cfg.MaxPartitionSize < 0 {
+		return fmt.Errorf("`max_partition_size` must be non-negative, found %d", cfg.MaxPartitionSize)
+	}
+
+	if cfg.IdleTimeout < 0 {
+		return fmt.Errorf("`idle_timeout` must be non-negative, found %d", cfg.IdleTimeout)
+	}
+
 	return nil
 }
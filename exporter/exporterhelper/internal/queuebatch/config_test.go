@@ -5,6 +5,7 @@ package queuebatch
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
@@ -37,7 +39,19 @@ func TestConfig_Validate(t *testing.T) {
 	cfg = newTestConfig()
 	cfg.WaitForResult = true
 	cfg.StorageID = &storageID
-	require.EqualError(t, xconfmap.Validate(cfg), "`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	require.NoError(t, xconfmap.Validate(cfg))
+	cfg.WaitForResultMode = WaitForResultModePersisted
+	require.NoError(t, xconfmap.Validate(cfg))
+	cfg.WaitForResultMode = "received"
+	require.EqualError(t, xconfmap.Validate(cfg), "`wait_for_result_mode` must be `exported` or `persisted`, found \"received\"")
+	cfg.WaitForResultMode = WaitForResultModeExported
+	cfg.WaitForResult = false
+	require.EqualError(t, xconfmap.Validate(cfg), "`wait_for_result_mode` requires `wait_for_result`")
+
+	cfg = newTestConfig()
+	cfg.WaitForResult = true
+	cfg.WaitForResultMode = WaitForResultModePersisted
+	require.EqualError(t, xconfmap.Validate(cfg), "`wait_for_result_mode` `persisted` requires a persistent queue configured with `storage`")
 
 	cfg = newTestConfig()
 	cfg.QueueSize = cfg.Batch.Get().MinSize - 1
@@ -50,6 +64,176 @@ func TestConfig_Validate(t *testing.T) {
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeBytes
 	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{QueueSize: 10})
+	require.EqualError(t, xconfmap.Validate(cfg), "dead_letter: `dead_letter` requires either a `storage` extension or an `exporter`")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID, ExporterID: component.MustNewID("otlp_grpc")})
+	require.EqualError(t, xconfmap.Validate(cfg), "dead_letter: `dead_letter` requires either a `storage` extension or an `exporter`")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{ExporterID: component.MustNewID("otlp_grpc"), QueueSize: 10})
+	require.EqualError(t, xconfmap.Validate(cfg), "dead_letter: `dead_letter::queue_size` is only supported with a `storage` extension")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{ExporterID: component.MustNewID("otlp_grpc")})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID, QueueSize: -1})
+	require.EqualError(t, xconfmap.Validate(cfg), "dead_letter: `dead_letter::queue_size` must be non-negative")
+
+	cfg = newTestConfig()
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{StorageID: storageID, QueueSize: 10})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.Batch.Get().Partition.MaxPartitionSize = cfg.QueueSize + 1
+	require.EqualError(t, xconfmap.Validate(cfg), "`partition::max_partition_size` must be less than or equal to `queue_size`")
+}
+
+func TestAdaptiveConcurrencyConfig_Validate(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConcurrency: 1, LatencyThreshold: time.Second, DecreaseRatio: 0.7})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConcurrency: cfg.NumConsumers + 1})
+	require.EqualError(t, xconfmap.Validate(cfg), "`adaptive_concurrency::min_concurrency` must be less than or equal to `num_consumers`")
+
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConcurrency: -1})
+	require.EqualError(t, xconfmap.Validate(cfg), "adaptive_concurrency: `min_concurrency` must be non-negative")
+
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{LatencyThreshold: -time.Second})
+	require.EqualError(t, xconfmap.Validate(cfg), "adaptive_concurrency: `latency_threshold` must be non-negative")
+
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{DecreaseRatio: 1})
+	require.EqualError(t, xconfmap.Validate(cfg), "adaptive_concurrency: `decrease_ratio` must be greater or equal to 0 and less than 1")
+}
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RateLimit = configoptional.Some(RateLimitConfig{Limit: 1000})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.RateLimit = configoptional.Some(RateLimitConfig{Sizer: request.SizerTypeBytes, Limit: 1000, Interval: time.Minute, BlockOnLimit: true})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.RateLimit = configoptional.Some(RateLimitConfig{})
+	require.EqualError(t, xconfmap.Validate(cfg), "rate_limit: `limit` must be positive, found 0")
+
+	cfg.RateLimit = configoptional.Some(RateLimitConfig{Limit: 1000, Interval: -time.Second})
+	require.EqualError(t, xconfmap.Validate(cfg), "rate_limit: `interval` must be non-negative, found -1000000000")
+}
+
+func TestCircuitBreakerConfig_Validate(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.CircuitBreaker = configoptional.Some(CircuitBreakerConfig{})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.CircuitBreaker = configoptional.Some(CircuitBreakerConfig{FailureRatio: 1, MinRequests: 5, Window: time.Minute, OpenDuration: time.Second, HalfOpenRequests: 2})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.CircuitBreaker = configoptional.Some(CircuitBreakerConfig{FailureRatio: 1.5})
+	require.EqualError(t, xconfmap.Validate(cfg), "circuit_breaker: `failure_ratio` must be greater or equal to 0 and less or equal to 1, found 1.5")
+
+	cfg.CircuitBreaker = configoptional.Some(CircuitBreakerConfig{MinRequests: -1})
+	require.EqualError(t, xconfmap.Validate(cfg), "circuit_breaker: `min_requests` must be non-negative, found -1")
+
+	cfg.CircuitBreaker = configoptional.Some(CircuitBreakerConfig{OpenDuration: -time.Second})
+	require.EqualError(t, xconfmap.Validate(cfg), "circuit_breaker: `open_duration` must be non-negative, found -1000000000")
+}
+
+func TestEncryptionConfig_Validate(t *testing.T) {
+	cfg := newTestConfig()
+	storageID := component.MustNewID("file_storage")
+	cfg.StorageID = &storageID
+	cfg.Encryption = configoptional.Some(EncryptionConfig{
+		EncryptionKeyConfig: EncryptionKeyConfig{KeyID: "new", KeyFile: "new.key"},
+		PreviousKeys:        []EncryptionKeyConfig{{KeyID: "old", Key: "b2xk"}},
+	})
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.Encryption.Get().PreviousKeys[0].KeyID = "new"
+	require.EqualError(t, xconfmap.Validate(cfg), "encryption: duplicate `key_id` \"new\"")
+
+	cfg.Encryption = configoptional.Some(EncryptionConfig{EncryptionKeyConfig: EncryptionKeyConfig{Key: "a2V5", KeyFile: "new.key"}})
+	require.EqualError(t, xconfmap.Validate(cfg), "encryption: exactly one of `key` or `key_file` must be configured")
+
+	cfg.Encryption = configoptional.Some(EncryptionConfig{
+		EncryptionKeyConfig: EncryptionKeyConfig{Key: "a2V5"},
+		PreviousKeys:        []EncryptionKeyConfig{{KeyID: strings.Repeat("k", 256), Key: "a2V5"}},
+	})
+	require.EqualError(t, xconfmap.Validate(cfg), "encryption: `previous_keys::0`: `key_id` must be at most 255 bytes long, found 256")
+
+	cfg.Encryption = configoptional.Some(EncryptionConfig{EncryptionKeyConfig: EncryptionKeyConfig{Key: "a2V5"}})
+	cfg.StorageID = nil
+	require.EqualError(t, xconfmap.Validate(cfg), "`encryption` requires a persistent queue configured with `storage` or a `dead_letter` queue")
+
+	// The dead letters sent to another exporter are not persisted.
+	cfg.DeadLetter = configoptional.Some(DeadLetterConfig{ExporterID: component.MustNewID("otlp_grpc")})
+	require.EqualError(t, xconfmap.Validate(cfg), "`encryption` requires a persistent queue configured with `storage` or a `dead_letter` queue")
+}
+
+func TestConfig_ValidateCompression(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Compression = configcompression.TypeZstd
+	require.EqualError(t, xconfmap.Validate(cfg), "`compression` requires a persistent queue configured with `storage` or a `dead_letter` queue")
+
+	storageID := component.MustNewID("file_storage")
+	cfg.StorageID = &storageID
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.Compression = configcompression.TypeSnappy
+	cfg.CompressionParams = configcompression.CompressionParams{Level: 3}
+	require.EqualError(t, xconfmap.Validate(cfg), "`compression_params`: unsupported parameters {Level:3} for compression type \"snappy\"")
+
+	// No compression is always valid.
+	cfg = newTestConfig()
+	cfg.Compression = "none"
+	require.NoError(t, xconfmap.Validate(cfg))
+}
+
+func TestPriorityConfig_Validate(t *testing.T) {
+	cfg := newTestPriorityConfig()
+	require.NoError(t, xconfmap.Validate(&cfg))
+
+	cfg = newTestPriorityConfig()
+	cfg.MetadataKey = ""
+	cfg.ResourceAttribute = ""
+	require.EqualError(t, xconfmap.Validate(&cfg), "`priority` requires a `metadata_key` or a `resource_attribute`")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes = nil
+	require.EqualError(t, xconfmap.Validate(&cfg), "`priority::lanes` must not be empty")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes[1].Name = ""
+	require.EqualError(t, xconfmap.Validate(&cfg), "`priority::lanes::name` must not be empty")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes[1].Name = "critical"
+	require.EqualError(t, xconfmap.Validate(&cfg), "duplicate priority lane \"critical\"")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes[1].Weight = 0
+	require.EqualError(t, xconfmap.Validate(&cfg), "priority lane \"normal\": `weight` must be positive")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes[1].QueueSize = -1
+	require.EqualError(t, xconfmap.Validate(&cfg), "priority lane \"normal\": `queue_size` must be non-negative")
+
+	cfg = newTestPriorityConfig()
+	cfg.Lanes[2].Values = []string{"alert"}
+	require.EqualError(t, xconfmap.Validate(&cfg), "value \"alert\" is assigned to both priority lanes \"critical\" and \"debug\"")
+
+	cfg = newTestPriorityConfig()
+	cfg.DefaultLane = "unknown"
+	require.EqualError(t, xconfmap.Validate(&cfg), "`priority::default_lane` \"unknown\" is not a configured lane")
 }
 
 func TestBatchConfig_Validate_MetadataKeys(t *testing.T) {
@@ -91,6 +275,22 @@ func TestBatchConfig_Validate_MetadataKeys(t *testing.T) {
 	})
 }
 
+func TestPartitionConfig_Validate(t *testing.T) {
+	cfg := PartitionConfig{MetadataKeys: []string{"tenant"}, MaxPartitions: 10, MaxPartitionSize: 100, IdleTimeout: time.Minute}
+	require.NoError(t, xconfmap.Validate(&cfg))
+
+	cfg.MaxPartitions = -1
+	require.EqualError(t, xconfmap.Validate(&cfg), "`max_partitions` must be non-negative, found -1")
+
+	cfg.MaxPartitions = 0
+	cfg.MaxPartitionSize = -1
+	require.EqualError(t, xconfmap.Validate(&cfg), "`max_partition_size` must be non-negative, found -1")
+
+	cfg.MaxPartitionSize = 0
+	cfg.IdleTimeout = -1
+	require.EqualError(t, xconfmap.Validate(&cfg), "`idle_timeout` must be non-negative, found -1")
+}
+
 func TestBatchConfig_Validate(t *testing.T) {
 	cfg := newTestBatchConfig()
 	require.NoError(t, xconfmap.Validate(cfg))
@@ -200,6 +400,14 @@ func TestUnmarshal(t *testing.T) {
 			// Batch remains unset, sizer override does not apply.
 			expectedCfg: newBaseCfg,
 		},
+		{
+			path: "dead_letter_exporter.yaml",
+			expectedCfg: func() configoptional.Optional[Config] {
+				cfg := newBaseCfg()
+				cfg.Get().DeadLetter = configoptional.Some(DeadLetterConfig{ExporterID: component.MustNewIDWithName("otlp_grpc", "dlq")})
+				return cfg
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+// maxEncryptionKeyIDLength is the maximum length of a key ID, stored with every encrypted request.
+const maxEncryptionKeyIDLength = 255
+
+// NewEncryptionSettings loads the keys of the given configuration.
+func NewEncryptionSettings(cfg EncryptionConfig) (*persistentqueue.EncryptionSettings, error) {
+	key, err := loadEncryptionKey(cfg.EncryptionKeyConfig)
+	if err != nil {
+		return nil, err
+	}
+	set := &persistentqueue.EncryptionSettings{Key: key}
+	for _, keyCfg := range cfg.PreviousKeys {
+		if key, err = loadEncryptionKey(keyCfg); err != nil {
+			return nil, err
+		}
+		set.PreviousKeys = append(set.PreviousKeys, key)
+	}
+	return set, nil
+}
+
+func loadEncryptionKey(cfg EncryptionKeyConfig) (persistentqueue.EncryptionKey, error) {
+	encoded := []byte(cfg.Key)
+	if cfg.KeyFile != "" {
+		var err error
+		if encoded, err = os.ReadFile(cfg.KeyFile); err != nil {
+			return persistentqueue.EncryptionKey{}, fmt.Errorf("failed to read the encryption key %q: %w", cfg.KeyID, err)
+		}
+	}
+	return persistentqueue.ParseEncryptionKey(cfg.KeyID, encoded)
+}
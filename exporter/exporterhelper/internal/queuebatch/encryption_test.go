@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+)
+
+func TestNewEncryptionSettings(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "queue.key")
+	// The whitespaces around the key are ignored.
+	require.NoError(t, os.WriteFile(keyFile, []byte("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=\n"), 0o600))
+
+	set, err := NewEncryptionSettings(EncryptionConfig{
+		EncryptionKeyConfig: EncryptionKeyConfig{KeyID: "new", KeyFile: keyFile},
+		PreviousKeys:        []EncryptionKeyConfig{{KeyID: "old", Key: "AgICAgICAgICAgICAgICAg=="}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &persistentqueue.EncryptionSettings{
+		Key:          persistentqueue.EncryptionKey{ID: "new", Key: bytes.Repeat([]byte{1}, 32)},
+		PreviousKeys: []persistentqueue.EncryptionKey{{ID: "old", Key: bytes.Repeat([]byte{2}, 16)}},
+	}, set)
+}
+
+func TestNewEncryptionSettings_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     EncryptionKeyConfig
+		wantErr string
+	}{
+		{
+			name:    "missing_file",
+			key:     EncryptionKeyConfig{KeyID: "k", KeyFile: filepath.Join(t.TempDir(), "missing.key")},
+			wantErr: `failed to read the encryption key "k"`,
+		},
+		{
+			name:    "invalid_base64",
+			key:     EncryptionKeyConfig{KeyID: "k", Key: configopaque.String("not base64!")},
+			wantErr: `failed to decode the encryption key "k"`,
+		},
+		{
+			name:    "invalid_length",
+			key:     EncryptionKeyConfig{KeyID: "k", Key: configopaque.String("a2V5")},
+			wantErr: `the encryption key "k" must be 16, 24 or 32 bytes long, found 3`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEncryptionSettings(EncryptionConfig{EncryptionKeyConfig: tt.key})
+			require.ErrorContains(t, err, tt.wantErr)
+
+			_, err = NewEncryptionSettings(EncryptionConfig{
+				EncryptionKeyConfig: EncryptionKeyConfig{KeyID: "valid", Key: "AgICAgICAgICAgICAgICAg=="},
+				PreviousKeys:        []EncryptionKeyConfig{tt.key},
+			})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/xpdata/pref"
@@ -27,11 +29,21 @@ var (
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewLogsQueueBatchSettings() Settings[request.Request] {
 	return Settings[request.Request]{
-		ReferenceCounter: logsReferenceCounter{},
-		Encoding:         logsEncoding{},
+		ReferenceCounter:   logsReferenceCounter{},
+		Encoding:           logsEncoding{},
+		DeadLetterExporter: logsDeadLetterExporter,
 	}
 }
 
+// logsDeadLetterExporter returns the function sending the requests to an exporter of logs.
+func logsDeadLetterExporter(exp component.Component) (sender.SendFunc[request.Request], error) {
+	next, ok := exp.(consumer.Logs)
+	if !ok {
+		return nil, errors.New("the exporter does not export logs")
+	}
+	return RequestConsumeFromLogs(next.ConsumeLogs), nil
+}
+
 var (
 	_ request.Request      = (*logsRequest)(nil)
 	_ request.ErrorHandler = (*logsRequest)(nil)
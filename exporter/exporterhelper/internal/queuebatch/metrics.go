@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/xpdata/pref"
@@ -24,11 +26,21 @@ var (
 
 func NewMetricsQueueBatchSettings() Settings[request.Request] {
 	return Settings[request.Request]{
-		ReferenceCounter: metricsReferenceCounter{},
-		Encoding:         metricsEncoding{},
+		ReferenceCounter:   metricsReferenceCounter{},
+		Encoding:           metricsEncoding{},
+		DeadLetterExporter: metricsDeadLetterExporter,
 	}
 }
 
+// metricsDeadLetterExporter returns the function sending the requests to an exporter of metrics.
+func metricsDeadLetterExporter(exp component.Component) (sender.SendFunc[request.Request], error) {
+	next, ok := exp.(consumer.Metrics)
+	if !ok {
+		return nil, errors.New("the exporter does not export metrics")
+	}
+	return RequestConsumeFromMetrics(next.ConsumeMetrics), nil
+}
+
 var (
 	_ request.Request      = (*metricsRequest)(nil)
 	_ request.ErrorHandler = (*metricsRequest)(nil)
@@ -15,6 +15,10 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 )
 
+// defaultMaxActivePartitions is the number of partitions after which the least recently used one is removed,
+// if the maximum number of partitions is not configured.
+const defaultMaxActivePartitions = 10000
+
 type multiBatcher struct {
 	cfg         BatchConfig
 	wp          *workerPool
@@ -25,6 +29,8 @@ type multiBatcher struct {
 	partitions  *lru.LRU[string, *partitionBatcher]
 	logger      *zap.Logger
 	lock        sync.Mutex
+	// evictWG tracks the shutdown of the evicted partitions.
+	evictWG sync.WaitGroup
 }
 
 func newMultiBatcher(
@@ -46,11 +52,16 @@ func newMultiBatcher(
 		logger:      logger,
 	}
 
-	// Create LRU cache with eviction callback
-	// TODO: make maxActivePartitionsCount configurable
-	cache, err := lru.NewLRU[string, *partitionBatcher](10000, func(_ string, pb *partitionBatcher) {
-		// Flush the partition when evicted
-		mb.wp.execute(pb.shutdownInternal)
+	// Create LRU cache with eviction callback. When the maximum number of partitions is configured, the requests of
+	// new partitions are rejected when they are sent to the queue, so only the partitions without data in the queue
+	// can be evicted.
+	maxPartitions := bCfg.Partition.MaxPartitions
+	if maxPartitions == 0 {
+		maxPartitions = defaultMaxActivePartitions
+	}
+	cache, err := lru.NewLRU[string, *partitionBatcher](maxPartitions, func(_ string, pb *partitionBatcher) {
+		// Flush the partition when evicted. The flush waits for a worker of the pool, so it cannot run in one.
+		mb.evictWG.Go(pb.shutdownInternal)
 	})
 	if err != nil {
 		return nil, err
@@ -111,5 +122,6 @@ func (mb *multiBatcher) Shutdown(ctx context.Context) error {
 	}
 	wg.Wait()
 	mb.partitions.Purge()
+	mb.evictWG.Wait()
 	return nil
 }
@@ -167,3 +167,45 @@ func TestMultiBatcher_PartitionRemovedAfterIdleTimeout(t *testing.T) {
 		return ba.getActivePartitionsCount() == 0
 	}, 500*time.Millisecond, 10*time.Millisecond)
 }
+
+func TestMultiBatcher_MaxPartitions(t *testing.T) {
+	cfg := BatchConfig{
+		FlushTimeout: time.Hour,
+		Sizer:        request.SizerTypeItems,
+		MinSize:      100,
+		Partition:    PartitionConfig{MaxPartitions: 2},
+	}
+	sink := requesttest.NewSink()
+
+	type partitionKey struct{}
+
+	ba, err := newMultiBatcher(cfg,
+		request.NewItemsSizer(),
+		newWorkerPool(1),
+		NewPartitioner(func(ctx context.Context, _ request.Request) string {
+			return ctx.Value(partitionKey{}).(string)
+		}),
+		nil,
+		sink.Export,
+		zap.NewNop(),
+	)
+
+	require.NoError(t, err)
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, ba.Shutdown(context.Background()))
+	})
+
+	done := newFakeDone()
+	ba.Consume(context.WithValue(context.Background(), partitionKey{}, "p1"), &requesttest.FakeRequest{Items: 5}, done)
+	ba.Consume(context.WithValue(context.Background(), partitionKey{}, "p2"), &requesttest.FakeRequest{Items: 5}, done)
+	assert.Equal(t, int64(2), ba.getActivePartitionsCount())
+
+	// When the limit is reached, the least recently used partition is flushed and evicted.
+	ba.Consume(context.WithValue(context.Background(), partitionKey{}, "p3"), &requesttest.FakeRequest{Items: 5}, done)
+	assert.Equal(t, int64(2), ba.getActivePartitionsCount())
+	assert.Eventually(t, func() bool {
+		return sink.RequestsCount() == 1 && sink.ItemsCount() == 5
+	}, time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 0, done.errors.Load())
+}
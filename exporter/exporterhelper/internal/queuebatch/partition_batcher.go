@@ -17,8 +17,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 )
 
-// partitionIdleCycles*FlushTimeout is the duration after which an empty partition is removed.
-// TODO make this configurable.
+// partitionIdleCycles*FlushTimeout is the default duration after which an empty partition is removed.
 const partitionIdleCycles = 10
 
 var _ Batcher[request.Request] = (*partitionBatcher)(nil)
@@ -254,6 +253,14 @@ func (qb *partitionBatcher) Shutdown(context.Context) error {
 	return nil
 }
 
+// idleTimeout returns the duration after which the partition is removed if it has no data.
+func (qb *partitionBatcher) idleTimeout() time.Duration {
+	if qb.cfg.Partition.IdleTimeout > 0 {
+		return qb.cfg.Partition.IdleTimeout
+	}
+	return partitionIdleCycles * qb.cfg.FlushTimeout
+}
+
 // flushCurrentBatchOrRemovePartition flushes the current batch if not empty,
 // or removes the partition from the parent if it's been idle for too long.
 func (qb *partitionBatcher) flushCurrentBatchOrRemovePartition() {
@@ -262,7 +269,7 @@ func (qb *partitionBatcher) flushCurrentBatchOrRemovePartition() {
 		// No data to flush - check if idle for too long AND no one holding a reference
 		idleDuration := time.Since(qb.lastDataTime)
 
-		if idleDuration >= (partitionIdleCycles*qb.cfg.FlushTimeout) && qb.onEmpty != nil {
+		if idleDuration >= qb.idleTimeout() && qb.onEmpty != nil {
 			qb.currentBatchMu.Unlock()
 			qb.onEmpty()
 			return
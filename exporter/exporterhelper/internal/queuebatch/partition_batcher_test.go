@@ -629,3 +629,26 @@ func TestPartitionBatcher_OnEmptyNotCalledWithActiveData(t *testing.T) {
 	// But data should have been flushed
 	assert.GreaterOrEqual(t, sink.RequestsCount(), 1)
 }
+
+func TestPartitionBatcher_IdleTimeout(t *testing.T) {
+	cfg := BatchConfig{
+		FlushTimeout: 10 * time.Millisecond,
+		Sizer:        request.SizerTypeItems,
+		Partition:    PartitionConfig{IdleTimeout: time.Hour},
+	}
+	sink := requesttest.NewSink()
+	onEmptyCalled := &atomic.Int64{}
+	ba := newPartitionBatcher(cfg, request.NewItemsSizer(), nil, newWorkerPool(1), sink.Export, zap.NewNop(), func() {
+		onEmptyCalled.Add(1)
+	})
+	assert.Equal(t, time.Hour, ba.idleTimeout())
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The default idle timeout (partitionIdleCycles * FlushTimeout) is not used.
+	time.Sleep(20 * partitionIdleCycles * time.Millisecond)
+	assert.Equal(t, int64(0), onEmptyCalled.Load())
+	require.NoError(t, ba.Shutdown(context.Background()))
+
+	cfg.Partition.IdleTimeout = 0
+	assert.Equal(t, partitionIdleCycles*cfg.FlushTimeout, newPartitionBatcher(cfg, request.NewItemsSizer(), nil, newWorkerPool(1), sink.Export, zap.NewNop(), nil).idleTimeout())
+}
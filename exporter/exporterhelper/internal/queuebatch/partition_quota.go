@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+)
+
+const (
+	// exporterKey used to identify exporters in metrics and traces.
+	exporterKey = "exporter"
+	// dataTypeKey used to identify the data type in the queue size metric.
+	dataTypeKey = "data_type"
+	// partitionKey used to identify the partition in the partition size metric.
+	partitionKey = "partition"
+)
+
+var (
+	errPartitionIsFull   = errors.New("sending queue partition is full")
+	errTooManyPartitions = errors.New("too many batcher metadata-value combinations")
+)
+
+// partitionQuota tracks the size of the sending queue used by every partition, and rejects the requests of
+// a partition that would use more than the configured maximum size, or that would create more than the configured
+// maximum number of partitions.
+type partitionQuota struct {
+	partitioner   Partitioner[request.Request]
+	sizer         request.Sizer
+	maxSize       int64
+	maxPartitions int
+	tb            *metadata.TelemetryBuilder
+
+	mu    sync.Mutex
+	sizes map[string]int64
+}
+
+// partitionUsage is the size of the queue used by a request, it is released only once.
+type partitionUsage struct {
+	pq       *partitionQuota
+	key      string
+	size     int64
+	released atomic.Bool
+}
+
+type partitionUsageCtxKey struct{}
+
+func newPartitionQuota(set AllSettings[request.Request], sizer request.Sizer, cfg PartitionConfig) (*partitionQuota, error) {
+	pq := &partitionQuota{
+		partitioner:   set.Partitioner,
+		sizer:         sizer,
+		maxSize:       cfg.MaxPartitionSize,
+		maxPartitions: cfg.MaxPartitions,
+		sizes:         map[string]int64{},
+	}
+
+	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+	exporterAttr := attribute.String(exporterKey, set.ID.String())
+	dataTypeAttr := attribute.String(dataTypeKey, set.Signal.String())
+	err = tb.RegisterExporterQueuePartitionSizeCallback(func(_ context.Context, o metric.Int64Observer) error {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+		for key, size := range pq.sizes {
+			o.Observe(size, metric.WithAttributeSet(attribute.NewSet(
+				exporterAttr, dataTypeAttr, attribute.String(partitionKey, partitionLabel(key)))))
+		}
+		return nil
+	})
+	if err != nil {
+		tb.Shutdown()
+		return nil, err
+	}
+	pq.tb = tb
+	return pq, nil
+}
+
+// acquire reserves the size of the request for its partition, and returns a context that carries the reservation
+// to the consumer of the queue.
+func (pq *partitionQuota) acquire(ctx context.Context, req request.Request) (context.Context, *partitionUsage, error) {
+	u := &partitionUsage{pq: pq, key: pq.partitioner.GetKey(ctx, req), size: pq.sizer.Sizeof(req)}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	// A partition is active as long as some of its data is in the queue.
+	if _, ok := pq.sizes[u.key]; !ok && pq.maxPartitions > 0 && len(pq.sizes) >= pq.maxPartitions {
+		return ctx, nil, errTooManyPartitions
+	}
+	if pq.maxSize > 0 && pq.sizes[u.key]+u.size > pq.maxSize {
+		return ctx, nil, errPartitionIsFull
+	}
+	pq.sizes[u.key] += u.size
+	return context.WithValue(ctx, partitionUsageCtxKey{}, u), u, nil
+}
+
+func (pq *partitionQuota) release(key string, size int64) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.sizes[key] <= size {
+		delete(pq.sizes, key)
+		return
+	}
+	pq.sizes[key] -= size
+}
+
+// wrapConsume returns a queue.ConsumeFunc that releases the size of the request when it is done.
+func (pq *partitionQuota) wrapConsume(next queue.ConsumeFunc[request.Request]) queue.ConsumeFunc[request.Request] {
+	return func(ctx context.Context, req request.Request, done queue.Done) {
+		u, ok := ctx.Value(partitionUsageCtxKey{}).(*partitionUsage)
+		if !ok {
+			if queue.IsRestored(ctx) {
+				// Persisted before the start, the request was never acquired by this instance.
+				next(ctx, req, done)
+				return
+			}
+			// The context is not preserved by the persistent queue, compute the partition again.
+			u = &partitionUsage{pq: pq, key: pq.partitioner.GetKey(ctx, req), size: pq.sizer.Sizeof(req)}
+		}
+		next(ctx, req, &releaseDone{Done: done, usage: u})
+	}
+}
+
+func (pq *partitionQuota) shutdown() {
+	pq.tb.Shutdown()
+}
+
+func (u *partitionUsage) release() {
+	if u.released.CompareAndSwap(false, true) {
+		u.pq.release(u.key, u.size)
+	}
+}
+
+type releaseDone struct {
+	queue.Done
+	usage *partitionUsage
+}
+
+func (d *releaseDone) OnDone(err error) {
+	d.usage.release()
+	d.Done.OnDone(err)
+}
+
+// partitionLabel returns a printable version of the partition key, the metadata keys and values are separated by a
+// zero byte in the key.
+func partitionLabel(key string) string {
+	return strings.ReplaceAll(key, "\x00", ",")
+}
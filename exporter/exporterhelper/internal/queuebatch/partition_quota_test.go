@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sendertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func contextWithTenant(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+	})
+}
+
+func TestQueueBatchPartitionQuota(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := requesttest.NewSink()
+	cfg := newTestConfig()
+	cfg.QueueSize = 1000
+	cfg.BlockOnOverflow = false
+	cfg.Batch.Get().FlushTimeout = time.Hour
+	cfg.Batch.Get().MinSize = 100
+	cfg.Batch.Get().Partition = PartitionConfig{MetadataKeys: []string{"tenant"}, MaxPartitionSize: 10}
+	set := newFakeRequestSettings()
+	set.Telemetry = tt.NewTelemetrySettings()
+	set.Partitioner = NewMetadataKeysPartitioner(cfg.Batch.Get().Partition.MetadataKeys)
+	qb, err := NewQueueBatch(set, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The data is kept in the batches until the shutdown, so the partitions keep using the queue.
+	require.NoError(t, qb.Send(contextWithTenant("noisy"), &requesttest.FakeRequest{Items: 6}))
+	require.NoError(t, qb.Send(contextWithTenant("noisy"), &requesttest.FakeRequest{Items: 4}))
+	err = qb.Send(contextWithTenant("noisy"), &requesttest.FakeRequest{Items: 1})
+	require.ErrorIs(t, err, errPartitionIsFull)
+	fill, ok := xconsumererror.BackpressureFill(err)
+	require.True(t, ok)
+	assert.InDelta(t, 0.01, fill, 0.001)
+	// Other partitions are not affected.
+	require.NoError(t, qb.Send(contextWithTenant("quiet"), &requesttest.FakeRequest{Items: 3}))
+
+	partitionAttrs := func(key string) attribute.Set {
+		return attribute.NewSet(
+			attribute.String(exporterKey, set.ID.String()),
+			attribute.String(dataTypeKey, pipeline.SignalMetrics.String()),
+			attribute.String(partitionKey, key))
+	}
+	metadatatest.AssertEqualExporterQueuePartitionSize(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{Attributes: partitionAttrs("tenant,noisy"), Value: 10},
+			{Attributes: partitionAttrs("tenant,quiet"), Value: 3},
+		}, metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 13, sink.ItemsCount())
+	assert.Empty(t, qb.quota.sizes)
+}
+
+func TestQueueBatchPartitionQuotaPersistent(t *testing.T) {
+	fgOrigReadState := queue.PersistRequestContextOnRead
+	fgOrigWriteState := queue.PersistRequestContextOnWrite
+	t.Cleanup(func() {
+		queue.PersistRequestContextOnRead = fgOrigReadState
+		queue.PersistRequestContextOnWrite = fgOrigWriteState
+	})
+
+	cfg := newTestConfig()
+	cfg.Batch.Get().Partition = PartitionConfig{MetadataKeys: []string{"tenant"}, MaxPartitionSize: 10}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+	set := newFakeRequestSettings()
+	set.Partitioner = NewMetadataKeysPartitioner(cfg.Batch.Get().Partition.MetadataKeys)
+
+	// The partition of the restored requests is unknown without the request context.
+	queue.PersistRequestContextOnRead = func() bool { return false }
+	queue.PersistRequestContextOnWrite = func() bool { return false }
+	_, err := NewQueueBatch(set, cfg, sendertest.NewNopSenderFunc[request.Request]())
+	require.ErrorContains(t, err, "require the `exporter.PersistRequestContext` feature gate")
+
+	queue.PersistRequestContextOnRead = func() bool { return true }
+	queue.PersistRequestContextOnWrite = func() bool { return true }
+	qb, err := NewQueueBatch(set, cfg, sendertest.NewNopSenderFunc[request.Request]())
+	require.NoError(t, err)
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchMaxPartitions(t *testing.T) {
+	sink := requesttest.NewSink()
+	cfg := newTestConfig()
+	cfg.QueueSize = 1000
+	cfg.BlockOnOverflow = false
+	cfg.Batch.Get().FlushTimeout = time.Hour
+	cfg.Batch.Get().MinSize = 100
+	cfg.Batch.Get().Partition = PartitionConfig{MetadataKeys: []string{"tenant"}, MaxPartitions: 2}
+	set := newFakeRequestSettings()
+	set.Partitioner = NewMetadataKeysPartitioner(cfg.Batch.Get().Partition.MetadataKeys)
+	qb, err := NewQueueBatch(set, cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, qb.Send(contextWithTenant("a"), &requesttest.FakeRequest{Items: 5}))
+	require.NoError(t, qb.Send(contextWithTenant("b"), &requesttest.FakeRequest{Items: 5}))
+
+	// The caller is asked to retry the data of a new partition, the existing partitions are not affected.
+	err = qb.Send(contextWithTenant("c"), &requesttest.FakeRequest{Items: 5})
+	require.ErrorIs(t, err, errTooManyPartitions)
+	_, ok := xconsumererror.BackpressureFill(err)
+	assert.True(t, ok)
+	require.NoError(t, qb.Send(contextWithTenant("a"), &requesttest.FakeRequest{Items: 95}))
+
+	// The new partition is accepted once the data of a partition is exported.
+	assert.Eventually(t, func() bool {
+		return qb.Send(contextWithTenant("c"), &requesttest.FakeRequest{Items: 5}) == nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 110, sink.ItemsCount())
+}
+
+// tenantEncoding persists the tenant of the requests, like the request context.
+type tenantEncoding struct{}
+
+func (tenantEncoding) Marshal(ctx context.Context, req request.Request) ([]byte, error) {
+	return []byte(strconv.Itoa(req.ItemsCount()) + "," + strings.Join(client.FromContext(ctx).Metadata.Get("tenant"), "")), nil
+}
+
+func (tenantEncoding) Unmarshal(buf []byte) (context.Context, request.Request, error) {
+	items, tenant, _ := strings.Cut(string(buf), ",")
+	n, err := strconv.Atoi(items)
+	return contextWithTenant(tenant), &requesttest.FakeRequest{Items: n}, err
+}
+
+func TestQueueBatchPartitionQuotaRestored(t *testing.T) {
+	fgOrigReadState := queue.PersistRequestContextOnRead
+	fgOrigWriteState := queue.PersistRequestContextOnWrite
+	t.Cleanup(func() {
+		queue.PersistRequestContextOnRead = fgOrigReadState
+		queue.PersistRequestContextOnWrite = fgOrigWriteState
+	})
+	queue.PersistRequestContextOnRead = func() bool { return true }
+	queue.PersistRequestContextOnWrite = func() bool { return true }
+
+	cfg := newTestConfig()
+	cfg.BlockOnOverflow = false
+	cfg.Batch.Get().MinSize = 0
+	cfg.Batch.Get().Partition = PartitionConfig{MetadataKeys: []string{"tenant"}, MaxPartitionSize: 10}
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	set := newFakeRequestSettings()
+	set.Encoding = tenantEncoding{}
+	set.Partitioner = NewMetadataKeysPartitioner(cfg.Batch.Get().Partition.MetadataKeys)
+
+	// The request is still in the storage when the collector stops.
+	stopped := make(chan struct{})
+	qb, err := NewQueueBatch(set, cfg, func(context.Context, request.Request) error {
+		close(stopped)
+		return experr.NewShutdownErr(errors.New("stopped"))
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	require.NoError(t, qb.Send(contextWithTenant("a"), &requesttest.FakeRequest{Items: 8}))
+	<-stopped
+	require.NoError(t, qb.Shutdown(context.Background()))
+
+	consumed := make(chan int, 2)
+	unblock := map[int]chan struct{}{8: make(chan struct{}), 10: make(chan struct{})}
+	qb, err = NewQueueBatch(set, cfg, func(_ context.Context, req request.Request) error {
+		consumed <- req.ItemsCount()
+		<-unblock[req.ItemsCount()]
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	assert.Equal(t, 8, <-consumed)
+
+	// The restored request was not acquired, the partition can use the whole quota.
+	require.NoError(t, qb.Send(contextWithTenant("a"), &requesttest.FakeRequest{Items: 10}))
+
+	// Releasing the restored request does not release the quota used by the new one.
+	close(unblock[8])
+	require.Eventually(t, func() bool { return qb.Size() == 10 }, time.Second, time.Millisecond)
+	assert.Equal(t, map[string]int64{"tenant\x00a": 10}, qb.quota.sizes)
+	require.ErrorIs(t, qb.Send(contextWithTenant("a"), &requesttest.FakeRequest{Items: 1}), errPartitionIsFull)
+
+	assert.Equal(t, 10, <-consumed)
+	close(unblock[10])
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Empty(t, qb.quota.sizes)
+}
+
+func TestPartitionQuotaRelease(t *testing.T) {
+	set := newFakeRequestSettings()
+	set.Partitioner = NewMetadataKeysPartitioner([]string{"tenant"})
+	pq, err := newPartitionQuota(set, request.NewItemsSizer(), PartitionConfig{MaxPartitionSize: 10})
+	require.NoError(t, err)
+	t.Cleanup(pq.shutdown)
+
+	ctx, usage, err := pq.acquire(contextWithTenant("a"), &requesttest.FakeRequest{Items: 8})
+	require.NoError(t, err)
+	var consumed bool
+	consume := pq.wrapConsume(func(_ context.Context, _ request.Request, done queue.Done) {
+		consumed = true
+		done.OnDone(nil)
+	})
+
+	// The usage is released only once, even if the request is also released by the sender.
+	consume(ctx, &requesttest.FakeRequest{Items: 8}, newFakeDone())
+	assert.True(t, consumed)
+	usage.release()
+	assert.Empty(t, pq.sizes)
+
+	// Requests read from a persistent queue do not carry the usage, it is computed again.
+	_, _, err = pq.acquire(contextWithTenant("a"), &requesttest.FakeRequest{Items: 10})
+	require.NoError(t, err)
+	consume(contextWithTenant("a"), &requesttest.FakeRequest{Items: 10}, newFakeDone())
+	assert.Empty(t, pq.sizes)
+}
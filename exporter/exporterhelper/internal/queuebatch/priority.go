@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// newPrioritySettings returns the settings of the queue lanes for the given configuration.
+func newPrioritySettings(cfg PriorityConfig, queueSize int64) *queue.PrioritySettings[request.Request] {
+	set := &queue.PrioritySettings[request.Request]{
+		Lanes:       make([]queue.LaneSettings, len(cfg.Lanes)),
+		DefaultLane: len(cfg.Lanes) - 1,
+	}
+	laneIndex := map[string]int{}
+	for i, lane := range cfg.Lanes {
+		capacity := lane.QueueSize
+		if capacity == 0 {
+			capacity = queueSize
+		}
+		set.Lanes[i] = queue.LaneSettings{Name: lane.Name, Weight: lane.Weight, Capacity: capacity}
+		if lane.Name == cfg.DefaultLane {
+			set.DefaultLane = i
+		}
+		for _, v := range lane.Values {
+			laneIndex[v] = i
+		}
+	}
+
+	set.LaneFunc = func(ctx context.Context, req request.Request) int {
+		if cfg.MetadataKey != "" {
+			if values := client.FromContext(ctx).Metadata.Get(cfg.MetadataKey); len(values) > 0 {
+				return lookupLane(laneIndex, values[0])
+			}
+		}
+		if cfg.ResourceAttribute != "" {
+			if value, ok := resourceAttribute(req, cfg.ResourceAttribute); ok {
+				return lookupLane(laneIndex, value)
+			}
+		}
+		return -1
+	}
+	return set
+}
+
+// lookupLane returns the index of the lane for the given value, or -1 if no lane matches.
+func lookupLane(laneIndex map[string]int, value string) int {
+	if idx, ok := laneIndex[value]; ok {
+		return idx
+	}
+	return -1
+}
+
+// resourceAttribute returns the value of the attribute of the first resource of the request that has it.
+func resourceAttribute(req request.Request, key string) (string, bool) {
+	switch r := req.(type) {
+	case *logsRequest:
+		rls := r.ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			if value, ok := resourceAttributeValue(rls.At(i).Resource(), key); ok {
+				return value, true
+			}
+		}
+	case *metricsRequest:
+		rms := r.md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			if value, ok := resourceAttributeValue(rms.At(i).Resource(), key); ok {
+				return value, true
+			}
+		}
+	case *tracesRequest:
+		rss := r.td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			if value, ok := resourceAttributeValue(rss.At(i).Resource(), key); ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+func resourceAttributeValue(res pcommon.Resource, key string) (string, bool) {
+	value, ok := res.Attributes().Get(key)
+	if !ok {
+		return "", false
+	}
+	return value.AsString(), true
+}
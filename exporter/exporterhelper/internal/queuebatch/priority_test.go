@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestPriorityConfig() PriorityConfig {
+	return PriorityConfig{
+		MetadataKey:       "x-priority",
+		ResourceAttribute: "priority",
+		Lanes: []PriorityLaneConfig{
+			{Name: "critical", Values: []string{"critical", "alert"}, Weight: 4, QueueSize: 10},
+			{Name: "normal", Values: []string{"normal"}, Weight: 2},
+			{Name: "debug", Values: []string{"debug"}, Weight: 1},
+		},
+		DefaultLane: "normal",
+	}
+}
+
+func contextWithPriority(value string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-priority": {value}}),
+	})
+}
+
+func TestNewPrioritySettings(t *testing.T) {
+	set := newPrioritySettings(newTestPriorityConfig(), 100)
+	require.Len(t, set.Lanes, 3)
+	assert.Equal(t, "critical", set.Lanes[0].Name)
+	assert.Equal(t, 4, set.Lanes[0].Weight)
+	assert.EqualValues(t, 10, set.Lanes[0].Capacity)
+	assert.EqualValues(t, 100, set.Lanes[1].Capacity)
+	assert.Equal(t, 1, set.DefaultLane)
+
+	cfg := newTestPriorityConfig()
+	cfg.DefaultLane = ""
+	assert.Equal(t, 2, newPrioritySettings(cfg, 100).DefaultLane)
+}
+
+func TestPriorityLaneFunc(t *testing.T) {
+	laneFunc := newPrioritySettings(newTestPriorityConfig(), 100).LaneFunc
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("priority", "debug")
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("priority", "alert")
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("priority", "unknown")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  request.Request
+		want int
+	}{
+		{name: "metadata", ctx: contextWithPriority("alert"), req: &requesttest.FakeRequest{}, want: 0},
+		{name: "metadata_unknown", ctx: contextWithPriority("unknown"), req: newTracesRequest(td), want: -1},
+		{name: "no_value", ctx: context.Background(), req: &requesttest.FakeRequest{}, want: -1},
+		{name: "traces_resource", ctx: context.Background(), req: newTracesRequest(td), want: 2},
+		{name: "metrics_resource", ctx: context.Background(), req: newMetricsRequest(md), want: 0},
+		{name: "logs_resource_unknown", ctx: context.Background(), req: newLogsRequest(ld), want: -1},
+		{name: "metadata_first", ctx: contextWithPriority("normal"), req: newMetricsRequest(md), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, laneFunc(tt.ctx, tt.req))
+		})
+	}
+}
+
+func TestQueueBatchPriority(t *testing.T) {
+	sink := requesttest.NewSink()
+	cfg := newTestConfig()
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	cfg.Priority = configoptional.Some(newTestPriorityConfig())
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, qb.Send(contextWithPriority("critical"), &requesttest.FakeRequest{Items: 4}))
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.RequestsCount())
+	assert.Equal(t, 7, sink.ItemsCount())
+}
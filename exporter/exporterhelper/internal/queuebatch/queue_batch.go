@@ -8,9 +8,12 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -20,6 +23,9 @@ type Settings[T any] struct {
 	Encoding         queue.Encoding[T]
 	Partitioner      Partitioner[T]
 	MergeCtx         func(context.Context, context.Context) context.Context
+	// DeadLetterExporter returns the function sending the requests to the given exporter, used when the dead letters
+	// are sent to another exporter. If nil, the dead letters can only be persisted with a storage extension.
+	DeadLetterExporter func(component.Component) (sender.SendFunc[T], error)
 }
 
 // AllSettings defines settings for creating a QueueBatch.
@@ -33,6 +39,8 @@ type AllSettings[T any] struct {
 type QueueBatch struct {
 	queue   queue.Queue[request.Request]
 	batcher Batcher[request.Request]
+	// quota is nil if the batches are not partitioned.
+	quota *partitionQuota
 }
 
 func NewQueueBatch(
@@ -56,11 +64,40 @@ func NewQueueBatch(
 		cfg.NumConsumers = 1
 	}
 
+	var priority *queue.PrioritySettings[request.Request]
+	if cfg.Priority.HasValue() {
+		priority = newPrioritySettings(*cfg.Priority.Get(), cfg.QueueSize)
+	}
+
+	var encryption *persistentqueue.EncryptionSettings
+	if cfg.Encryption.HasValue() && cfg.StorageID != nil {
+		if encryption, err = NewEncryptionSettings(*cfg.Encryption.Get()); err != nil {
+			return nil, err
+		}
+	}
+
+	consumeFunc := b.Consume
+	var quota *partitionQuota
+	if cfg.Batch.HasValue() && set.Partitioner != nil {
+		// The partition of the requests restored from the storage is computed from their persisted context,
+		// without it the size used by the partitions could never be released.
+		pCfg := cfg.Batch.Get().Partition
+		if (pCfg.MaxPartitionSize > 0 || pCfg.MaxPartitions > 0) && cfg.StorageID != nil &&
+			(!queue.PersistRequestContextOnWrite() || !queue.PersistRequestContextOnRead()) {
+			return nil, errors.New("`partition::max_partition_size` and `partition::max_partitions` with a persistent queue require the `exporter.PersistRequestContext` feature gate")
+		}
+		quota, err = newPartitionQuota(set, request.NewSizer(cfg.Sizer), pCfg)
+		if err != nil {
+			return nil, err
+		}
+		consumeFunc = quota.wrapConsume(consumeFunc)
+	}
+
 	q, err := queue.NewQueue(queue.Settings[request.Request]{
 		SizerType:        cfg.Sizer,
 		Capacity:         cfg.QueueSize,
 		NumConsumers:     cfg.NumConsumers,
-		WaitForResult:    cfg.WaitForResult,
+		WaitForResult:    cfg.WaitForResult && (cfg.StorageID == nil || cfg.WaitForResultMode != WaitForResultModePersisted),
 		BlockOnOverflow:  cfg.BlockOnOverflow,
 		Signal:           set.Signal,
 		StorageID:        cfg.StorageID,
@@ -68,12 +105,18 @@ func NewQueueBatch(
 		Encoding:         set.Encoding,
 		ID:               set.ID,
 		Telemetry:        set.Telemetry,
-	}, b.Consume)
+		Priority:         priority,
+		Encryption:       encryption,
+		Compression:      NewCompressionSettings(cfg),
+	}, consumeFunc)
 	if err != nil {
+		if quota != nil {
+			quota.shutdown()
+		}
 		return nil, err
 	}
 
-	return &QueueBatch{queue: q, batcher: b}, nil
+	return &QueueBatch{queue: q, batcher: b, quota: quota}, nil
 }
 
 // Start is invoked during service startup.
@@ -91,10 +134,38 @@ func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
 func (qs *QueueBatch) Shutdown(ctx context.Context) error {
 	// Stop the queue and batcher, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
-	return errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx))
+	err := errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx))
+	if qs.quota != nil {
+		qs.quota.shutdown()
+	}
+	return err
+}
+
+// Size returns the size of the queue, including the requests being batched or exported.
+func (qs *QueueBatch) Size() int64 {
+	return qs.queue.Size()
 }
 
 // Send implements the requestSender interface. It puts the request in the queue.
 func (qs *QueueBatch) Send(ctx context.Context, req request.Request) error {
-	return qs.queue.Offer(ctx, req)
+	if qs.quota == nil {
+		return qs.withBackpressure(qs.queue.Offer(ctx, req))
+	}
+	ctx, usage, err := qs.quota.acquire(ctx, req)
+	if err != nil {
+		return qs.withBackpressure(err)
+	}
+	if err = qs.queue.Offer(ctx, req); err != nil {
+		usage.release()
+	}
+	return qs.withBackpressure(err)
+}
+
+// withBackpressure attaches the fill level of the queue to the retryable errors, so the receivers can compute
+// how long their clients should wait before retrying.
+func (qs *QueueBatch) withBackpressure(err error) error {
+	if err == nil || consumererror.IsPermanent(err) || qs.queue.Capacity() <= 0 {
+		return err
+	}
+	return xconsumererror.NewBackpressure(err, float64(qs.queue.Size())/float64(qs.queue.Capacity()))
 }
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
@@ -108,7 +109,11 @@ func TestQueueBatchHappyPath(t *testing.T) {
 	}
 
 	// expect queue to be full
-	require.Error(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	err = qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	require.ErrorIs(t, err, queue.ErrQueueIsFull)
+	fill, ok := xconsumererror.BackpressureFill(err)
+	require.True(t, ok)
+	assert.InDelta(t, 55.0/56.0, fill, 1e-9)
 
 	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
 	assert.Eventually(t, func() bool {
@@ -163,6 +168,45 @@ func TestQueueBatchPersistenceEnabled(t *testing.T) {
 	require.NoError(t, qb.Shutdown(context.Background()))
 }
 
+func TestQueueBatchPersistentWaitForResult(t *testing.T) {
+	exportErr := errors.New("export failed")
+	for _, tt := range []struct {
+		mode    WaitForResultMode
+		wantErr error
+	}{
+		{mode: "", wantErr: exportErr},
+		{mode: WaitForResultModeExported, wantErr: exportErr},
+		{mode: WaitForResultModePersisted},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Batch = configoptional.None[BatchConfig]()
+			cfg.WaitForResult = true
+			cfg.WaitForResultMode = tt.mode
+			storageID := component.MustNewIDWithName("file_storage", "storage")
+			cfg.StorageID = &storageID
+			mockReq := &requesttest.FakeRequest{Items: 2}
+			qSet := newFakeRequestSettings()
+			qSet.Encoding = newFakeEncoding(mockReq)
+			qb, err := NewQueueBatch(qSet, cfg, func(context.Context, request.Request) error {
+				return exportErr
+			})
+			require.NoError(t, err)
+			host := hosttest.NewHost(map[component.ID]component.Component{
+				storageID: storagetest.NewMockStorageExtension(nil),
+			})
+			require.NoError(t, qb.Start(context.Background(), host))
+			err = qb.Send(context.Background(), mockReq)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, qb.Shutdown(context.Background()))
+		})
+	}
+}
+
 func TestQueueBatchPersistenceEnabledStorageError(t *testing.T) {
 	storageError := errors.New("could not get storage client")
 	cfg := newTestConfig()
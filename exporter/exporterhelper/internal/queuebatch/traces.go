@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/xpdata/pref"
@@ -27,11 +29,21 @@ var (
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewTracesQueueBatchSettings() Settings[request.Request] {
 	return Settings[request.Request]{
-		ReferenceCounter: tracesReferenceCounter{},
-		Encoding:         tracesEncoding{},
+		ReferenceCounter:   tracesReferenceCounter{},
+		Encoding:           tracesEncoding{},
+		DeadLetterExporter: tracesDeadLetterExporter,
 	}
 }
 
+// tracesDeadLetterExporter returns the function sending the requests to an exporter of traces.
+func tracesDeadLetterExporter(exp component.Component) (sender.SendFunc[request.Request], error) {
+	next, ok := exp.(consumer.Traces)
+	if !ok {
+		return nil, errors.New("the exporter does not export traces")
+	}
+	return RequestConsumeFromTraces(next.ConsumeTraces), nil
+}
+
 var (
 	_ request.Request      = (*tracesRequest)(nil)
 	_ request.ErrorHandler = (*tracesRequest)(nil)
@@ -4,12 +4,17 @@
 package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
@@ -21,3 +26,23 @@ func TestTracesRequest(t *testing.T) {
 	traceErr := consumererror.NewTraces(errors.New("some error"), ptrace.NewTraces())
 	assert.Equal(t, newTracesRequest(ptrace.NewTraces()), mr.(request.ErrorHandler).OnError(traceErr))
 }
+
+type tracesExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+func TestTracesDeadLetterExporter(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	export, err := NewTracesQueueBatchSettings().DeadLetterExporter(tracesExporter{Traces: sink})
+	require.NoError(t, err)
+	require.NoError(t, export(context.Background(), newTracesRequest(testdata.GenerateTraces(2))))
+	assert.Equal(t, 2, sink.SpanCount())
+
+	_, err = NewTracesQueueBatchSettings().DeadLetterExporter(struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{})
+	require.EqualError(t, err, "the exporter does not export traces")
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+const defaultRateLimitInterval = time.Second
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// rateLimitSender is a requestSender that limits the size of the data sent per time window using a token bucket.
+// The bucket holds up to "limit" tokens and is refilled continuously at "limit" tokens per "interval". A request
+// larger than the limit is sent when the bucket is full and the next requests wait until the debt is paid off.
+type rateLimitSender struct {
+	component.StartFunc
+	sizer    request.Sizer
+	capacity float64
+	// rate is the number of tokens added per second.
+	rate  float64
+	block bool
+	tb    *metadata.TelemetryBuilder
+	attrs metric.MeasurementOption
+	next  sender.Sender[request.Request]
+
+	// mu guards everything declared below.
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newRateLimitSender(
+	set exporter.Settings,
+	cfg queuebatch.RateLimitConfig,
+	queueSizer request.SizerType,
+	next sender.Sender[request.Request],
+) (*rateLimitSender, error) {
+	sizerType := cfg.Sizer
+	if sizerType == (request.SizerType{}) {
+		sizerType = queueSizer
+	}
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultRateLimitInterval
+	}
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitSender{
+		sizer:      request.NewSizer(sizerType),
+		capacity:   float64(cfg.Limit),
+		rate:       float64(cfg.Limit) / interval.Seconds(),
+		block:      cfg.BlockOnLimit,
+		tb:         tb,
+		attrs:      metric.WithAttributeSet(attribute.NewSet(attribute.String(ExporterKey, set.ID.String()))),
+		next:       next,
+		tokens:     float64(cfg.Limit),
+		lastRefill: time.Now(),
+	}, nil
+}
+
+func (rs *rateLimitSender) Shutdown(context.Context) error {
+	rs.tb.Shutdown()
+	return nil
+}
+
+// Send implements the requestSender interface
+func (rs *rateLimitSender) Send(ctx context.Context, req request.Request) error {
+	size := float64(rs.sizer.Sizeof(req))
+	delay := rs.reserve(size)
+	if delay == 0 {
+		return rs.next.Send(ctx, req)
+	}
+	if !rs.block {
+		// Let the retry sender try again after the delay. Nothing waited, so only the attempt is counted.
+		rs.tb.ExporterRateLimitThrottledRequests.Add(ctx, 1, rs.attrs)
+		return NewThrottleRetry(errRateLimited, delay)
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for delay > 0 {
+		select {
+		case <-ctx.Done():
+			rs.tb.ExporterRateLimitThrottledTime.Add(ctx, time.Since(start).Seconds(), rs.attrs)
+			return ctx.Err()
+		case <-timer.C:
+		}
+		// Other requests may have taken the tokens in the meantime.
+		if delay = rs.reserve(size); delay > 0 {
+			timer.Reset(delay)
+		}
+	}
+	rs.tb.ExporterRateLimitThrottledTime.Add(ctx, time.Since(start).Seconds(), rs.attrs)
+	return rs.next.Send(ctx, req)
+}
+
+// reserve takes the tokens for the given size if they are available, otherwise returns the time after which they
+// are expected to be available.
+func (rs *rateLimitSender) reserve(size float64) time.Duration {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	now := time.Now()
+	rs.tokens = min(rs.tokens+now.Sub(rs.lastRefill).Seconds()*rs.rate, rs.capacity)
+	rs.lastRefill = now
+
+	needed := min(size, rs.capacity)
+	if rs.tokens >= needed {
+		rs.tokens -= size
+		return 0
+	}
+	// Round up so the tokens are available after the delay.
+	return time.Duration((needed-rs.tokens)/rs.rate*float64(time.Second)) + 1
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newCountingSender(items *atomic.Int64) sender.Sender[request.Request] {
+	return sender.NewSender(func(_ context.Context, req request.Request) error {
+		items.Add(int64(req.ItemsCount()))
+		return nil
+	})
+}
+
+func TestRateLimitSender_Shed(t *testing.T) {
+	var items atomic.Int64
+	rs, err := newRateLimitSender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.RateLimitConfig{Limit: 10, Interval: time.Hour}, request.SizerTypeItems, newCountingSender(&items))
+	require.NoError(t, err)
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 6}))
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 4}))
+
+	err = rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	require.ErrorIs(t, err, errRateLimited)
+	throttleErr := throttleRetry{}
+	require.ErrorAs(t, err, &throttleErr)
+	// The bucket needs 1 token, added every 6 minutes.
+	assert.InDelta(t, 6*time.Minute, throttleErr.delay, float64(time.Second))
+	assert.EqualValues(t, 10, items.Load())
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestRateLimitSender_Block(t *testing.T) {
+	var items atomic.Int64
+	rs, err := newRateLimitSender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.RateLimitConfig{Sizer: request.SizerTypeRequests, Limit: 2, Interval: 100 * time.Millisecond, BlockOnLimit: true},
+		request.SizerTypeItems, newCountingSender(&items))
+	require.NoError(t, err)
+
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	}
+	// The first 2 requests are sent immediately, the other ones wait for the bucket to be refilled.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.EqualValues(t, 20, items.Load())
+
+	// Waiting is interrupted by the context, the bucket is empty and needs 50ms to get a token.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, rs.Send(ctx, &requesttest.FakeRequest{Items: 5}), context.DeadlineExceeded)
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestRateLimitSender_LargeRequest(t *testing.T) {
+	var items atomic.Int64
+	rs, err := newRateLimitSender(exportertest.NewNopSettings(exportertest.NopType),
+		queuebatch.RateLimitConfig{Limit: 10, Interval: time.Hour}, request.SizerTypeItems, newCountingSender(&items))
+	require.NoError(t, err)
+
+	// A request larger than the limit is sent when the bucket is full, the next requests pay off the debt.
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 30}))
+	err = rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	throttleErr := throttleRetry{}
+	require.ErrorAs(t, err, &throttleErr)
+	assert.InDelta(t, 2*time.Hour+6*time.Minute, throttleErr.delay, float64(time.Second))
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestRateLimitSender_Metric(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := exportertest.NewNopSettings(exportertest.NopType)
+	set.TelemetrySettings = tt.NewTelemetrySettings()
+	attrs := attribute.NewSet(attribute.String(ExporterKey, set.ID.String()))
+	var items atomic.Int64
+	rs, err := newRateLimitSender(set, queuebatch.RateLimitConfig{Limit: 1, Interval: time.Minute}, request.SizerTypeRequests, newCountingSender(&items))
+	require.NoError(t, err)
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.Error(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+
+	// The second request fails without waiting, so it is only counted.
+	metadatatest.AssertEqualExporterRateLimitThrottledRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Attributes: attrs, Value: 1}}, metricdatatest.IgnoreTimestamp())
+	_, err = tt.GetMetric("otelcol_exporter_rate_limit_throttled_time")
+	require.Error(t, err)
+	require.NoError(t, rs.Shutdown(context.Background()))
+
+	rs, err = newRateLimitSender(set, queuebatch.RateLimitConfig{Limit: 1, Interval: 50 * time.Millisecond, BlockOnLimit: true},
+		request.SizerTypeRequests, newCountingSender(&items))
+	require.NoError(t, err)
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+
+	// The second request waits about 50ms for the bucket to be refilled.
+	metadatatest.AssertEqualExporterRateLimitThrottledTime(t, tt,
+		[]metricdata.DataPoint[float64]{{Attributes: attrs}}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	got, err := tt.GetMetric("otelcol_exporter_rate_limit_throttled_time")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05, got.Data.(metricdata.Sum[float64]).DataPoints[0].Value, 0.04)
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestBaseExporterRateLimit(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.RateLimit = configoptional.Some(queuebatch.RateLimitConfig{Limit: 100})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithRetry(configretry.NewDefaultBackOffConfig()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NotNil(t, be.RateLimitSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	assert.Nil(t, be.RateLimitSender)
+
+	// Without retries, the throttled data would be dropped.
+	_, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.ErrorContains(t, err, "requires `block_on_limit`")
+
+	qCfg.RateLimit = configoptional.Some(queuebatch.RateLimitConfig{Limit: 100, BlockOnLimit: true})
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	assert.NotNil(t, be.RateLimitSender)
+}
@@ -25,6 +25,40 @@ type QueueBatchConfig = queuebatch.Config
 // BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.
 type BatchConfig = queuebatch.BatchConfig
 
+// DeadLetterConfig defines a configuration for storing the requests that permanently failed to be exported.
+type DeadLetterConfig = queuebatch.DeadLetterConfig
+
+// PriorityConfig defines a configuration for assigning requests to priority lanes.
+type PriorityConfig = queuebatch.PriorityConfig
+
+// PriorityLaneConfig defines a priority lane of the sending queue.
+type PriorityLaneConfig = queuebatch.PriorityLaneConfig
+
+// AdaptiveConcurrencyConfig defines a configuration for adjusting the number of concurrent exports.
+type AdaptiveConcurrencyConfig = queuebatch.AdaptiveConcurrencyConfig
+
+// RateLimitConfig defines a configuration for limiting the size of the data sent to the backend.
+type RateLimitConfig = queuebatch.RateLimitConfig
+
+// CircuitBreakerConfig defines a configuration for stopping the exports when the backend is failing.
+type CircuitBreakerConfig = queuebatch.CircuitBreakerConfig
+
+// EncryptionConfig defines a configuration for encrypting the persisted requests.
+type EncryptionConfig = queuebatch.EncryptionConfig
+
+// EncryptionKeyConfig defines an encryption key of the persisted requests.
+type EncryptionKeyConfig = queuebatch.EncryptionKeyConfig
+
+// WaitForResultMode determines when the requests are acknowledged with a persistent queue and `wait_for_result`.
+type WaitForResultMode = queuebatch.WaitForResultMode
+
+const (
+	// WaitForResultModeExported acknowledges the requests once they are exported.
+	WaitForResultModeExported = queuebatch.WaitForResultModeExported
+	// WaitForResultModePersisted acknowledges the requests once they are written to the storage.
+	WaitForResultModePersisted = queuebatch.WaitForResultModePersisted
+)
+
 // QueueBatchEncoding defines the encoding to be used if persistent queue is configured.
 // Duplicate definition with queuebatch.Encoding since aliasing generics is not supported by default.
 type QueueBatchEncoding[T any] interface {
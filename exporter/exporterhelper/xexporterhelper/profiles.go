@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -36,11 +37,21 @@ var (
 // until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
 func NewProfilesQueueBatchSettings() QueueBatchSettings {
 	return QueueBatchSettings{
-		ReferenceCounter: profilesReferenceCounter{},
-		Encoding:         profilesEncoding{},
+		ReferenceCounter:   profilesReferenceCounter{},
+		Encoding:           profilesEncoding{},
+		DeadLetterExporter: profilesDeadLetterExporter,
 	}
 }
 
+// profilesDeadLetterExporter returns the function sending the requests to an exporter of profiles.
+func profilesDeadLetterExporter(exp component.Component) (sender.SendFunc[Request], error) {
+	next, ok := exp.(xconsumer.Profiles)
+	if !ok {
+		return nil, errors.New("the exporter does not export profiles")
+	}
+	return requestConsumeFromProfiles(next.ConsumeProfiles), nil
+}
+
 var (
 	_ request.Request      = (*profilesRequest)(nil)
 	_ request.ErrorHandler = (*profilesRequest)(nil)
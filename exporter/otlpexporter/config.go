@@ -7,6 +7,7 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -23,6 +24,20 @@ type Config struct {
 	RetryConfig   configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
 	ClientConfig  configgrpc.ClientConfig                                  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
+	// Streaming enables the streaming export mode: the requests are sent over long-lived bidirectional
+	// gRPC streams, one per signal, instead of one unary Export call per request.
+	Streaming configoptional.Optional[StreamingConfig] `mapstructure:"streaming"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// StreamingConfig defines the configuration of the streaming export mode.
+type StreamingConfig struct {
+	// MaxStreamLifetime is the duration after which a stream is replaced by a new one, so the load is
+	// rebalanced across the backends behind a load-balancer. Zero means the streams are never replaced.
+	MaxStreamLifetime time.Duration `mapstructure:"max_stream_lifetime"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -36,6 +51,9 @@ func (c *Config) Validate() error {
 	if endpoint := c.sanitizedEndpoint(); endpoint == "" {
 		return errors.New(`requires a non-empty "endpoint"`)
 	}
+	if streaming := c.Streaming.Get(); streaming != nil && streaming.MaxStreamLifetime < 0 {
+		return errors.New(`requires a non-negative "streaming::max_stream_lifetime"`)
+	}
 	return nil
 }
 
@@ -85,6 +85,9 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            configoptional.Some(configauth.Config{AuthenticatorID: component.MustNewID("nop")}),
 			},
+			Streaming: configoptional.Some(StreamingConfig{
+				MaxStreamLifetime: 5 * time.Minute,
+			}),
 		}, cfg)
 }
 
@@ -117,6 +120,9 @@ func TestUnmarshalDefaultBatchConfig(t *testing.T) {
 				Compression:     "gzip",
 				WriteBufferSize: 512 * 1024,
 			},
+			Streaming: configoptional.Default(StreamingConfig{
+				MaxStreamLifetime: 10 * time.Minute,
+			}),
 		}, cfg)
 }
 
@@ -164,6 +170,10 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_unix_socket",
 			errorMsg: "unix socket path cannot be empty",
 		},
+		{
+			name:     "invalid_max_stream_lifetime",
+			errorMsg: `requires a non-negative "streaming::max_stream_lifetime"`,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
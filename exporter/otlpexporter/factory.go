@@ -7,6 +7,7 @@ import (
 	"context"
 	"net"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
@@ -51,6 +52,9 @@ func createDefaultConfig() component.Config {
 		RetryConfig:   configretry.NewDefaultBackOffConfig(),
 		QueueConfig:   configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
 		ClientConfig:  clientCfg,
+		Streaming: configoptional.Default(StreamingConfig{
+			MaxStreamLifetime: 10 * time.Minute,
+		}),
 	}
 }
 
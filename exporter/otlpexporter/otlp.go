@@ -7,7 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -16,11 +20,13 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/internal/statusutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -45,6 +51,15 @@ type baseExporter struct {
 	metadata        metadata.MD
 	callOptions     []grpc.CallOption
 
+	// Stream clients, set when the streaming export mode is enabled.
+	traceStream   *otlpstream.Client
+	metricStream  *otlpstream.Client
+	logStream     *otlpstream.Client
+	profileStream *otlpstream.Client
+	// streamingUnsupported is set when the server does not support the streams, the requests are then
+	// sent with the unary Export calls.
+	streamingUnsupported atomic.Bool
+
 	settings component.TelemetrySettings
 
 	// Default user-agent header.
@@ -79,11 +94,22 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 	e.callOptions = []grpc.CallOption{
 		grpc.WaitForReady(e.config.ClientConfig.WaitForReady),
 	}
+	if streaming := e.config.Streaming.Get(); streaming != nil {
+		e.traceStream = otlpstream.NewClient(e.clientConn, otlpstream.TracesMethod, streaming.MaxStreamLifetime, e.callOptions...)
+		e.metricStream = otlpstream.NewClient(e.clientConn, otlpstream.MetricsMethod, streaming.MaxStreamLifetime, e.callOptions...)
+		e.logStream = otlpstream.NewClient(e.clientConn, otlpstream.LogsMethod, streaming.MaxStreamLifetime, e.callOptions...)
+		e.profileStream = otlpstream.NewClient(e.clientConn, otlpstream.ProfilesMethod, streaming.MaxStreamLifetime, e.callOptions...)
+	}
 
 	return err
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
+	for _, stream := range []*otlpstream.Client{e.traceStream, e.metricStream, e.logStream, e.profileStream} {
+		if stream != nil {
+			stream.Close()
+		}
+	}
 	if e.clientConn != nil {
 		return e.clientConn.Close()
 	}
@@ -96,7 +122,11 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	}
 
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(ctx, req, e.callOptions...)
+	resp := ptraceotlp.NewExportResponse()
+	streamed, respErr := e.exportStream(ctx, e.traceStream, req, resp)
+	if !streamed {
+		resp, respErr = e.traceExporter.Export(ctx, req, e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -116,7 +146,11 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	}
 
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(ctx, req, e.callOptions...)
+	resp := pmetricotlp.NewExportResponse()
+	streamed, respErr := e.exportStream(ctx, e.metricStream, req, resp)
+	if !streamed {
+		resp, respErr = e.metricExporter.Export(ctx, req, e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -136,7 +170,11 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	}
 
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(ctx, req, e.callOptions...)
+	resp := plogotlp.NewExportResponse()
+	streamed, respErr := e.exportStream(ctx, e.logStream, req, resp)
+	if !streamed {
+		resp, respErr = e.logExporter.Export(ctx, req, e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -156,7 +194,11 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 	}
 
 	req := pprofileotlp.NewExportRequestFromProfiles(td)
-	resp, respErr := e.profileExporter.Export(ctx, req, e.callOptions...)
+	resp := pprofileotlp.NewExportResponse()
+	streamed, respErr := e.exportStream(ctx, e.profileStream, req, resp)
+	if !streamed {
+		resp, respErr = e.profileExporter.Export(ctx, req, e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -170,6 +212,71 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 	return nil
 }
 
+type protoRequest interface {
+	MarshalProto() ([]byte, error)
+}
+
+type protoResponse interface {
+	UnmarshalProto([]byte) error
+}
+
+// exportStream sends the request over the stream of its signal and decodes the response into resp. It returns
+// false when the streaming export mode is disabled or not supported by the server, the request must then be
+// sent with the unary Export call.
+func (e *baseExporter) exportStream(ctx context.Context, stream *otlpstream.Client, req protoRequest, resp protoResponse) (bool, error) {
+	if stream == nil || e.streamingUnsupported.Load() {
+		return false, nil
+	}
+	payload, err := req.MarshalProto()
+	if err != nil {
+		return true, err
+	}
+	respPayload, err := stream.Export(ctx, streamKey(ctx), payload)
+	if errors.Is(err, otlpstream.ErrUnimplemented) {
+		if e.streamingUnsupported.CompareAndSwap(false, true) {
+			e.settings.Logger.Warn("The server does not support the streaming export mode, falling back to unary Export calls", zap.Error(err))
+		}
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	return true, resp.UnmarshalProto(respPayload)
+}
+
+// streamKey identifies the headers of the streams opened with the context: its outgoing metadata, and the client
+// metadata and authentication data used by the authenticators setting the headers, e.g. the tenants of the
+// partitions of the batcher. The requests are sent on the streams opened with the same headers.
+func streamKey(ctx context.Context) string {
+	var kb strings.Builder
+	writeValues := func(prefix, key string, values ...string) {
+		kb.WriteString(prefix)
+		kb.WriteString(key)
+		for _, v := range values {
+			kb.WriteByte(0)
+			kb.WriteString(v)
+		}
+		kb.WriteByte(0)
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for _, k := range slices.Sorted(maps.Keys(md)) {
+		writeValues("md:", k, md[k]...)
+	}
+	info := client.FromContext(ctx)
+	for _, k := range slices.Sorted(info.Metadata.Keys()) {
+		writeValues("client:", k, info.Metadata.Get(k)...)
+	}
+	if info.Auth != nil {
+		names := slices.Clone(info.Auth.GetAttributeNames())
+		slices.Sort(names)
+		for _, name := range names {
+			writeValues("auth:", name, fmt.Sprint(info.Auth.GetAttribute(name)))
+		}
+	}
+	return kb.String()
+}
+
 func processError(err error) error {
 	if err == nil {
 		// Request is successful, we are done.
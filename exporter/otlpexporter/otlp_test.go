@@ -25,15 +25,18 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -1082,3 +1085,143 @@ func TestSendProfilesWhenEndpointHasHTTPScheme(t *testing.T) {
 		})
 	}
 }
+
+// otlpTracesReceiverWithStreamsOnGRPCServer runs a mock traces receiver serving both the unary Export
+// calls and the streams, it returns the receiver and the number of requests received on the streams.
+func otlpTracesReceiverWithStreamsOnGRPCServer(ln net.Listener) (*mockTracesReceiver, *atomic.Int64) {
+	rcv := &mockTracesReceiver{
+		mockReceiver: mockReceiver{
+			srv:          grpc.NewServer(),
+			requestCount: new(atomic.Int64),
+			totalItems:   new(atomic.Int64),
+		},
+		exportResponse: ptraceotlp.NewExportResponse,
+	}
+	streamRequests := new(atomic.Int64)
+
+	ptraceotlp.RegisterGRPCServer(rcv.srv, rcv)
+	otlpstream.RegisterServer(rcv.srv, otlpstream.Handlers{
+		Traces: func(ctx context.Context, payload []byte) ([]byte, error) {
+			streamRequests.Add(1)
+			req := ptraceotlp.NewExportRequest()
+			if err := req.UnmarshalProto(payload); err != nil {
+				return nil, err
+			}
+			resp, err := rcv.Export(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return resp.MarshalProto()
+		},
+	})
+	go func() {
+		_ = rcv.srv.Serve(ln)
+	}()
+
+	return rcv, streamRequests
+}
+
+func TestSendTracesStreaming(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, streamRequests := otlpTracesReceiverWithStreamsOnGRPCServer(ln)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	// Disable queuing and retries to get the errors of the requests when calling ConsumeTraces.
+	cfg.QueueConfig = configoptional.None[exporterhelper.QueueBatchConfig]()
+	cfg.RetryConfig.Enabled = false
+	cfg.Streaming = configoptional.Some(StreamingConfig{})
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLS: configtls.ClientConfig{
+			Insecure: true,
+		},
+		Headers: configopaque.MapList{
+			{Name: "header", Value: "header-value"},
+		},
+	}
+	set := exportertest.NewNopSettings(factory.Type())
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.EqualValues(t, 1, rcv.requestCount.Load())
+	assert.EqualValues(t, 1, streamRequests.Load())
+	assert.EqualValues(t, 2, rcv.totalItems.Load())
+	assert.Equal(t, td, rcv.getLastRequest())
+	// The headers are sent when the stream is opened.
+	assert.Equal(t, []string{"header-value"}, rcv.getMetadata().Get("header"))
+
+	// The failures of the requests are mapped as the failures of the unary Export calls.
+	rcv.setExportError(status.Error(codes.InvalidArgument, "invalid argument"))
+	err = exp.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	rcv.setExportError(status.Error(codes.Unavailable, "unavailable"))
+	err = exp.ConsumeTraces(context.Background(), td)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	rcv.setExportError(nil)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.EqualValues(t, 4, streamRequests.Load())
+}
+
+func TestStreamKey(t *testing.T) {
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+		})
+	}
+	assert.Equal(t, streamKey(tenantCtx("acme")), streamKey(tenantCtx("acme")))
+	assert.NotEqual(t, streamKey(tenantCtx("acme")), streamKey(tenantCtx("globex")))
+	assert.NotEqual(t, streamKey(context.Background()), streamKey(tenantCtx("acme")))
+
+	outgoing := func(tenant string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-tenant", tenant)
+	}
+	assert.NotEqual(t, streamKey(outgoing("acme")), streamKey(outgoing("globex")))
+	// The client metadata and the outgoing metadata are not confused.
+	assert.NotEqual(t, streamKey(outgoing("acme")), streamKey(tenantCtx("acme")))
+}
+
+func TestSendTracesStreamingUnsupported(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig = configoptional.None[exporterhelper.QueueBatchConfig]()
+	cfg.Streaming = configoptional.Some(StreamingConfig{})
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLS: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	set := exportertest.NewNopSettings(factory.Type())
+	logger, observed := observer.New(zap.DebugLevel)
+	set.Logger = zap.New(logger)
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The requests are sent with the unary Export calls, the fallback is logged once.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+	assert.Equal(t, 1, observed.FilterMessageSnippet("does not support the streaming export mode").Len())
+}
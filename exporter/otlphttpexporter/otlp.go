@@ -90,6 +90,14 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// shutdown closes the idle connections of the client, releasing the UDP sockets of the HTTP/3 connections.
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.client != nil {
+		e.client.CloseIdleConnections()
+	}
+	return nil
+}
+
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	tr := ptraceotlp.NewExportRequestFromTraces(td)
 
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension // import "go.opentelemetry.io/collector/extension/inflightlimiterextension"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	errTooLarge  = errors.New("the request is larger than the in-flight bytes limit")
+	errExhausted = errors.New("the in-flight bytes limit is exceeded")
+)
+
+// budget is a number of bytes acquired by the requests and released once they are processed. The requests
+// waiting for the budget are admitted in their arrival order, so the large requests are not starved by the
+// small ones.
+type budget struct {
+	limit   int64
+	maxWait time.Duration
+
+	// mu guards everything declared below.
+	mu      sync.Mutex
+	used    int64
+	waiters list.List // of *waiter
+}
+
+type waiter struct {
+	size  int64
+	ready chan struct{}
+}
+
+func newBudget(limit int64, maxWait time.Duration) *budget {
+	return &budget{limit: limit, maxWait: maxWait}
+}
+
+// acquire acquires size bytes, waiting up to maxWait for them to be released. onWait is called before waiting,
+// and the returned function once the wait ends.
+func (b *budget) acquire(ctx context.Context, size int64, onWait func() func()) error {
+	if size > b.limit {
+		return errTooLarge
+	}
+
+	b.mu.Lock()
+	if b.waiters.Len() == 0 && b.used+size <= b.limit {
+		b.used += size
+		b.mu.Unlock()
+		return nil
+	}
+	if b.maxWait == 0 {
+		b.mu.Unlock()
+		return errExhausted
+	}
+	w := &waiter{size: size, ready: make(chan struct{})}
+	elem := b.waiters.PushBack(w)
+	b.mu.Unlock()
+
+	defer onWait()()
+	timer := time.NewTimer(b.maxWait)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-w.ready:
+		return nil
+	case <-timer.C:
+		err = errExhausted
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-w.ready:
+		// The bytes were acquired concurrently with the end of the wait.
+		return nil
+	default:
+	}
+	b.waiters.Remove(elem)
+	// The next waiters may fit now that the first one is gone.
+	b.notifyLocked()
+	return err
+}
+
+// release releases size bytes acquired before.
+func (b *budget) release(size int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= size
+	b.notifyLocked()
+}
+
+// notifyLocked admits the waiters that fit in the budget, in their arrival order.
+func (b *budget) notifyLocked() {
+	for elem := b.waiters.Front(); elem != nil; elem = b.waiters.Front() {
+		w := elem.Value.(*waiter)
+		if b.used+w.size > b.limit {
+			return
+		}
+		b.used += w.size
+		b.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
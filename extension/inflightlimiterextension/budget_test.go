@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noWait() func() {
+	return func() {}
+}
+
+func TestBudget_Acquire(t *testing.T) {
+	b := newBudget(10, 0)
+	require.NoError(t, b.acquire(context.Background(), 6, noWait))
+	require.NoError(t, b.acquire(context.Background(), 4, noWait))
+	require.ErrorIs(t, b.acquire(context.Background(), 1, noWait), errExhausted)
+	require.ErrorIs(t, b.acquire(context.Background(), 11, noWait), errTooLarge)
+
+	b.release(6)
+	require.NoError(t, b.acquire(context.Background(), 5, noWait))
+	assert.EqualValues(t, 9, b.used)
+}
+
+func TestBudget_Wait(t *testing.T) {
+	b := newBudget(10, time.Minute)
+	require.NoError(t, b.acquire(context.Background(), 10, noWait))
+
+	waiting := make(chan struct{}, 2)
+	onWait := func() func() {
+		waiting <- struct{}{}
+		return func() {}
+	}
+	// The waiters are admitted in their arrival order, the small request does not pass the large one.
+	largeDone := make(chan error, 1)
+	go func() { largeDone <- b.acquire(context.Background(), 8, onWait) }()
+	<-waiting
+	smallDone := make(chan error, 1)
+	go func() { smallDone <- b.acquire(context.Background(), 2, onWait) }()
+	<-waiting
+
+	b.release(5)
+	select {
+	case <-largeDone:
+		require.Fail(t, "the large request must wait")
+	case <-smallDone:
+		require.Fail(t, "the small request must wait for the large one")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	b.release(5)
+	require.NoError(t, <-largeDone)
+	require.NoError(t, <-smallDone)
+	assert.EqualValues(t, 10, b.used)
+}
+
+func TestBudget_WaitTimeout(t *testing.T) {
+	b := newBudget(10, 10*time.Millisecond)
+	require.NoError(t, b.acquire(context.Background(), 10, noWait))
+	require.ErrorIs(t, b.acquire(context.Background(), 1, noWait), errExhausted)
+	assert.Equal(t, 0, b.waiters.Len())
+}
+
+func TestBudget_WaitCanceled(t *testing.T) {
+	b := newBudget(10, time.Minute)
+	require.NoError(t, b.acquire(context.Background(), 5, noWait))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- b.acquire(ctx, 10, func() func() {
+			close(waiting)
+			return func() {}
+		})
+	}()
+	<-waiting
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// The next request does not wait behind the canceled one.
+	require.NoError(t, b.acquire(context.Background(), 5, noWait))
+	assert.Equal(t, 0, b.waiters.Len())
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension // import "go.opentelemetry.io/collector/extension/inflightlimiterextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration of the in-flight limiter extension.
+type Config struct {
+	// MaxInflightBytes is the maximum number of uncompressed bytes of the requests admitted by all the receivers
+	// using the extension and not yet processed by their pipelines.
+	MaxInflightBytes int64 `mapstructure:"max_inflight_bytes"`
+
+	// MaxWait is the maximum time a request waits for the in-flight bytes to be released before being rejected.
+	// Zero means that the requests exceeding the limit are rejected immediately.
+	MaxWait time.Duration `mapstructure:"max_wait"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.MaxInflightBytes <= 0 {
+		errs = append(errs, errors.New(`"max_inflight_bytes" must be positive`))
+	}
+	if cfg.MaxWait < 0 {
+		errs = append(errs, errors.New(`"max_wait" must be non-negative`))
+	}
+	return errors.Join(errs...)
+}
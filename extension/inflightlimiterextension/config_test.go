@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := createDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t, &Config{
+		MaxInflightBytes: 100 * 1024 * 1024,
+		MaxWait:          5 * time.Second,
+	}, cfg)
+	assert.NoError(t, xconfmap.Validate(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.EqualError(t, xconfmap.Validate(createDefaultConfig()), `"max_inflight_bytes" must be positive`)
+	assert.EqualError(t, xconfmap.Validate(&Config{MaxInflightBytes: -1, MaxWait: -time.Second}),
+		"\"max_inflight_bytes\" must be positive\n\"max_wait\" must be non-negative")
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension // import "go.opentelemetry.io/collector/extension/inflightlimiterextension"
+
+//go:generate mdatagen metadata.yaml
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/inflightlimiterextension/internal/metadata"
+)
+
+const defaultMaxWait = time.Second
+
+// NewFactory returns a new factory for the in-flight limiter extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		create,
+		metadata.ExtensionStability)
+}
+
+// createDefaultConfig creates the default configuration for extension. Notice
+// that the default configuration is expected to fail for this extension, the
+// limit depends on the memory available to the collector.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxWait: defaultMaxWait,
+	}
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newInflightLimiter(cfg.(*Config), set.TelemetrySettings)
+}
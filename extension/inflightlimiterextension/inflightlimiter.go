@@ -0,0 +1,422 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension // import "go.opentelemetry.io/collector/extension/inflightlimiterextension"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	grpcproto "google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp/xconfighttp"
+	"go.opentelemetry.io/collector/extension/extensionmiddleware"
+	"go.opentelemetry.io/collector/extension/inflightlimiterextension/internal/metadata"
+)
+
+var (
+	_ extensionmiddleware.GRPCServer = (*inflightLimiter)(nil)
+	_ extensionmiddleware.HTTPServer = (*inflightLimiter)(nil)
+)
+
+// inflightLimiter limits the bytes of the requests in flight across all the receivers using it. A request is
+// admitted once its uncompressed size is acquired from the budget, and the size is released when the pipeline
+// returns.
+type inflightLimiter struct {
+	budget           *budget
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	// pending are the admissions of the gRPC messages by the codec, by message, until they are attached to
+	// their RPC.
+	pending sync.Map
+}
+
+func newInflightLimiter(cfg *Config, set component.TelemetrySettings) (*inflightLimiter, error) {
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	return &inflightLimiter{
+		budget:           newBudget(cfg.MaxInflightBytes, cfg.MaxWait),
+		telemetryBuilder: tb,
+	}, nil
+}
+
+func (*inflightLimiter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (il *inflightLimiter) Shutdown(context.Context) error {
+	il.telemetryBuilder.Shutdown()
+	return nil
+}
+
+// admit acquires the uncompressed size of a request, the compressed size is only reported. If admitted, the
+// returned function must be called once the request is processed.
+func (il *inflightLimiter) admit(ctx context.Context, uncompressed, compressed int64) (func(), error) {
+	if err := il.acquire(ctx, uncompressed); err != nil {
+		il.telemetryBuilder.InflightLimiterRejectedRequests.Add(ctx, 1)
+		return nil, err
+	}
+	return il.track(ctx, uncompressed, uncompressed, compressed), nil
+}
+
+// acquire acquires size bytes from the budget, reporting the waiting requests.
+func (il *inflightLimiter) acquire(ctx context.Context, size int64) error {
+	return il.budget.acquire(ctx, size, func() func() {
+		il.telemetryBuilder.InflightLimiterWaitingRequests.Add(ctx, 1)
+		return func() { il.telemetryBuilder.InflightLimiterWaitingRequests.Add(ctx, -1) }
+	})
+}
+
+// track reports the sizes of an admitted request, which acquired the given bytes. The returned function releases
+// them once the request is processed.
+func (il *inflightLimiter) track(ctx context.Context, acquired, uncompressed, compressed int64) func() {
+	il.telemetryBuilder.InflightLimiterInflightBytes.Add(ctx, uncompressed)
+	il.telemetryBuilder.InflightLimiterInflightCompressedBytes.Add(ctx, compressed)
+	return func() {
+		il.budget.release(acquired)
+		// The request context may be canceled, the telemetry must still be recorded.
+		ctx := context.WithoutCancel(ctx)
+		il.telemetryBuilder.InflightLimiterInflightBytes.Add(ctx, -uncompressed)
+		il.telemetryBuilder.InflightLimiterInflightCompressedBytes.Add(ctx, -compressed)
+	}
+}
+
+// GetHTTPHandler implements extensionmiddleware.HTTPServer.
+func (il *inflightLimiter) GetHTTPHandler(context.Context) (extensionmiddleware.WrapHTTPHandlerFunc, error) {
+	return il.wrapHTTPHandler, nil
+}
+
+func (il *inflightLimiter) wrapHTTPHandler(_ context.Context, base http.Handler) (http.Handler, error) {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		// The middlewares run after the decompression, the length of the body is only known when it was not
+		// compressed and not chunked.
+		if req.ContentLength >= 0 {
+			release, err := il.admit(req.Context(), req.ContentLength, req.ContentLength)
+			if err != nil {
+				il.writeHTTPError(resp, req, err)
+				return
+			}
+			defer release()
+			base.ServeHTTP(resp, req)
+			return
+		}
+
+		body, release, err := il.admitBody(req)
+		if err != nil {
+			il.writeHTTPError(resp, req, err)
+			return
+		}
+		defer release()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		base.ServeHTTP(resp, req)
+	}), nil
+}
+
+// writeHTTPError writes a rejection as an OTLP error response, the google.rpc.Status is encoded in protobuf or
+// in JSON as the request. The requests larger than the budget are rejected with a permanent error, and the
+// requests rejected because the budget is exhausted can be retried after the Retry-After delay, the time they
+// waited for the budget.
+func (il *inflightLimiter) writeHTTPError(resp http.ResponseWriter, req *http.Request, err error) {
+	var (
+		statusCode int
+		st         *status.Status
+	)
+	switch {
+	case errors.Is(err, errTooLarge):
+		statusCode = http.StatusRequestEntityTooLarge
+		st = status.New(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errExhausted):
+		statusCode = http.StatusTooManyRequests
+		st = status.New(codes.ResourceExhausted, err.Error())
+		retryAfter := max(time.Second, il.budget.maxWait+time.Second-1).Truncate(time.Second)
+		if withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); detailsErr == nil {
+			st = withDetails
+		}
+		resp.Header().Set("Retry-After", strconv.FormatInt(int64(retryAfter/time.Second), 10))
+	default:
+		statusCode = http.StatusBadRequest
+		st = status.New(codes.InvalidArgument, err.Error())
+	}
+
+	contentType := "application/json"
+	var msg []byte
+	var marshalErr error
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType == "application/x-protobuf" {
+		contentType = mediaType
+		msg, marshalErr = proto.Marshal(st.Proto())
+	} else {
+		msg, marshalErr = protojson.Marshal(st.Proto())
+	}
+	if marshalErr != nil {
+		http.Error(resp, err.Error(), statusCode)
+		return
+	}
+	resp.Header().Set("Content-Type", contentType)
+	resp.WriteHeader(statusCode)
+	_, _ = resp.Write(msg)
+}
+
+// bodyChunkSize is the number of bytes read at once from a body of unknown length, before acquiring them.
+const bodyChunkSize = 32 * 1024
+
+// admitBody reads a body of unknown length, e.g. a decompressed or a chunked body. The request is first admitted
+// with the length of the compressed body as received when it is known, then the bytes are acquired by chunks as
+// they are read, so the bytes read exceed the budget by one chunk at most. If admitted, the returned function
+// must be called once the request is processed.
+func (il *inflightLimiter) admitBody(req *http.Request) ([]byte, func(), error) {
+	ctx := req.Context()
+	compressedBody, isCompressed := xconfighttp.CompressedBodyFromContext(ctx)
+
+	var acquired int64
+	acquireMore := func(size int64) error {
+		err := errTooLarge
+		if acquired+size <= il.budget.limit {
+			err = il.acquire(ctx, size)
+		}
+		if err != nil {
+			il.telemetryBuilder.InflightLimiterRejectedRequests.Add(ctx, 1)
+			return err
+		}
+		acquired += size
+		return nil
+	}
+	fail := func(err error) ([]byte, func(), error) {
+		il.budget.release(acquired)
+		return nil, nil, err
+	}
+
+	if isCompressed && compressedBody.ContentLength() > 0 {
+		// The uncompressed body is usually larger, the compressed length is acquired before reading anything.
+		if err := acquireMore(compressedBody.ContentLength()); err != nil {
+			return fail(err)
+		}
+	}
+	var buf bytes.Buffer
+	for {
+		_, err := io.CopyN(&buf, req.Body, bodyChunkSize)
+		if read := int64(buf.Len()); read > acquired {
+			if acquireErr := acquireMore(read - acquired); acquireErr != nil {
+				return fail(acquireErr)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	uncompressed := int64(buf.Len())
+	compressed := uncompressed
+	if isCompressed {
+		compressed = compressedBody.BytesRead()
+	}
+	// The bytes acquired but not read are released now.
+	il.budget.release(acquired - uncompressed)
+	return buf.Bytes(), il.track(ctx, uncompressed, uncompressed, compressed), nil
+}
+
+// GetGRPCServerOptions implements extensionmiddleware.GRPCServer. The messages are admitted by the codec of the
+// server, once they are received and decompressed but before they are decoded, then the stats handler attaches
+// the admitted messages to their RPC. The messages of a unary call are released when the call returns, and the
+// messages of a stream in their arrival order as the server sends its responses, the remaining ones once the
+// stream ends.
+func (il *inflightLimiter) GetGRPCServerOptions(context.Context) ([]grpc.ServerOption, error) {
+	return []grpc.ServerOption{
+		grpc.ForceServerCodecV2(&admittingCodec{
+			CodecV2: encoding.GetCodecV2(grpcproto.Name),
+			il:      il,
+		}),
+		grpc.StatsHandler(&admissionStatsHandler{il: il}),
+		grpc.ChainUnaryInterceptor(
+			func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				adm := rpcAdmissionsFromContext(ctx)
+				if err := adm.takeErr(); err != nil {
+					return nil, grpcError(err)
+				}
+				defer adm.releaseAll()
+				return handler(ctx, req)
+			}),
+		grpc.ChainStreamInterceptor(
+			func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &admittedServerStream{ServerStream: ss, adm: rpcAdmissionsFromContext(ss.Context())})
+			}),
+	}, nil
+}
+
+func grpcError(err error) error {
+	if errors.Is(err, errTooLarge) || errors.Is(err, errExhausted) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.FromContextError(err).Err()
+}
+
+// admission is the result of the admission of a received message by the codec.
+type admission struct {
+	size int64
+	err  error
+}
+
+// admittingCodec acquires the size of the received messages before decoding them. The codec does not know the
+// RPC of a message, the admission is kept by message until the stats handler attaches it to the RPC.
+type admittingCodec struct {
+	encoding.CodecV2
+	il *inflightLimiter
+}
+
+func (c *admittingCodec) Unmarshal(data mem.BufferSlice, v any) error {
+	size := int64(data.Len())
+	if err := c.il.acquire(context.Background(), size); err != nil {
+		// An error of the codec is reported with the Internal code, the message is left empty and the
+		// rejection is returned by the interceptors instead.
+		c.il.pending.Store(v, admission{err: err})
+		return nil
+	}
+	if err := c.CodecV2.Unmarshal(data, v); err != nil {
+		c.il.budget.release(size)
+		return err
+	}
+	c.il.pending.Store(v, admission{size: size})
+	return nil
+}
+
+// rpcAdmissions are the admitted messages of an RPC which are not released yet.
+type rpcAdmissions struct {
+	mu       sync.Mutex
+	err      error
+	releases []func()
+}
+
+type rpcAdmissionsKey struct{}
+
+func rpcAdmissionsFromContext(ctx context.Context) *rpcAdmissions {
+	if adm, ok := ctx.Value(rpcAdmissionsKey{}).(*rpcAdmissions); ok {
+		return adm
+	}
+	return &rpcAdmissions{}
+}
+
+func (a *rpcAdmissions) add(release func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.releases = append(a.releases, release)
+}
+
+func (a *rpcAdmissions) reject(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.err = err
+}
+
+// takeErr returns the rejection of the last received message, if any.
+func (a *rpcAdmissions) takeErr() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	err := a.err
+	a.err = nil
+	return err
+}
+
+// releaseOldest releases the oldest message which is not released yet.
+func (a *rpcAdmissions) releaseOldest() {
+	a.mu.Lock()
+	if len(a.releases) == 0 {
+		a.mu.Unlock()
+		return
+	}
+	release := a.releases[0]
+	a.releases = a.releases[1:]
+	a.mu.Unlock()
+	release()
+}
+
+func (a *rpcAdmissions) releaseAll() {
+	a.mu.Lock()
+	releases := a.releases
+	a.releases = nil
+	a.mu.Unlock()
+	for _, release := range releases {
+		release()
+	}
+}
+
+// admittedServerStream returns the rejections of the received messages, and releases the messages as the
+// responses are sent.
+type admittedServerStream struct {
+	grpc.ServerStream
+	adm *rpcAdmissions
+}
+
+func (s *admittedServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := s.adm.takeErr(); err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (s *admittedServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	s.adm.releaseOldest()
+	return err
+}
+
+// admissionStatsHandler attaches the admissions of the received messages to their RPC, reports their sizes and
+// releases them once the RPC ends.
+type admissionStatsHandler struct {
+	il *inflightLimiter
+}
+
+var _ stats.Handler = (*admissionStatsHandler)(nil)
+
+func (*admissionStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcAdmissionsKey{}, &rpcAdmissions{})
+}
+
+func (h *admissionStatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	switch rs := rs.(type) {
+	case *stats.InPayload:
+		value, ok := h.il.pending.LoadAndDelete(rs.Payload)
+		if !ok {
+			return
+		}
+		adm := rpcAdmissionsFromContext(ctx)
+		if a := value.(admission); a.err != nil {
+			h.il.telemetryBuilder.InflightLimiterRejectedRequests.Add(ctx, 1)
+			adm.reject(a.err)
+		} else {
+			adm.add(h.il.track(ctx, a.size, int64(rs.Length), int64(rs.CompressedLength)))
+		}
+	case *stats.End:
+		rpcAdmissionsFromContext(ctx).releaseAll()
+	}
+}
+
+func (*admissionStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (*admissionStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
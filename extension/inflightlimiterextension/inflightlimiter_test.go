@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package inflightlimiterextension
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/inflightlimiterextension/internal/metadatatest"
+	"go.opentelemetry.io/collector/internal/otlpstream"
+)
+
+func newTestLimiter(t *testing.T, cfg *Config) (*inflightLimiter, *componenttest.Telemetry) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	il, err := newInflightLimiter(cfg, tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, il.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, il.Shutdown(context.Background())) })
+	return il, tt
+}
+
+func assertInflightBytes(t *testing.T, tt *componenttest.Telemetry, uncompressed, compressed int64) {
+	metadatatest.AssertEqualInflightLimiterInflightBytes(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: uncompressed}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualInflightLimiterInflightCompressedBytes(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: compressed}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestHTTPHandler(t *testing.T) {
+	il, tt := newTestLimiter(t, &Config{MaxInflightBytes: 10})
+	wrap, err := il.GetHTTPHandler(context.Background())
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler, err := wrap(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if string(body) == "blocking" {
+			close(started)
+			<-release
+		}
+		_, _ = w.Write(body)
+	}))
+	require.NoError(t, err)
+
+	blockingDone := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("blocking")))
+		blockingDone <- rec
+	}()
+	<-started
+	assertInflightBytes(t, tt, 8, 8)
+
+	// The request exceeding the available budget is rejected, it can be retried.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("large")))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	st := &spb.Status{}
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), st))
+	assert.Equal(t, int32(codes.ResourceExhausted), st.GetCode())
+	require.Len(t, st.GetDetails(), 1)
+
+	// The request exceeding the limit is rejected with a permanent error.
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("much larger"))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+	assert.Equal(t, "application/x-protobuf", rec.Header().Get("Content-Type"))
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), st))
+	assert.Equal(t, int32(codes.InvalidArgument), st.GetCode())
+
+	// The length of the decompressed bodies is unknown, they are read to know their size.
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("ok"))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-blockingDone).Code)
+	assertInflightBytes(t, tt, 0, 0)
+	metadatatest.AssertEqualInflightLimiterRejectedRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+}
+
+func gzipBody(t *testing.T, body string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestHTTPHandlerGzip(t *testing.T) {
+	il, tt := newTestLimiter(t, &Config{MaxInflightBytes: 10000})
+	wrap, err := il.GetHTTPHandler(context.Background())
+	require.NoError(t, err)
+
+	var served atomic.Int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler, err := wrap(context.Background(), http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		served.Add(1)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Len(t, body, 5000)
+		close(started)
+		<-release
+	}))
+	require.NoError(t, err)
+	// The middlewares of the servers run after the decompression.
+	sc := confighttp.NewDefaultServerConfig()
+	srv, err := sc.ToServer(context.Background(), nil, componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	newRequest := func(body []byte) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Encoding", "gzip")
+		return req
+	}
+
+	compressed := gzipBody(t, strings.Repeat("a", 5000))
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(rec, newRequest(compressed))
+		done <- rec
+	}()
+	<-started
+	// The uncompressed and the compressed sizes are tracked separately.
+	assertInflightBytes(t, tt, 5000, int64(len(compressed)))
+
+	// The decompressed body exceeding the budget is rejected while it is read, though its compressed size fits.
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, newRequest(gzipBody(t, strings.Repeat("b", 20000))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.EqualValues(t, 1, served.Load())
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-done).Code)
+	assertInflightBytes(t, tt, 0, 0)
+	metadatatest.AssertEqualInflightLimiterRejectedRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+}
+
+type blockingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingHealthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.GetService() == "blocking" {
+		close(s.started)
+		<-s.release
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func TestGRPCServerOptions(t *testing.T) {
+	il, tt := newTestLimiter(t, &Config{MaxInflightBytes: 15, MaxWait: 10 * time.Millisecond})
+	opts, err := il.GetGRPCServerOptions(context.Background())
+	require.NoError(t, err)
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	health := &blockingHealthServer{started: make(chan struct{}), release: make(chan struct{})}
+	grpc_health_v1.RegisterHealthServer(srv, health)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	blockingDone := make(chan error)
+	go func() {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "blocking"})
+		blockingDone <- err
+	}()
+	<-health.started
+	// The request is encoded in 10 bytes, the service name and its tag and length.
+	assertInflightBytes(t, tt, 10, 10)
+
+	// The request exceeding the limit waits, then is rejected.
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "large"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "ok"})
+	require.NoError(t, err)
+
+	close(health.release)
+	require.NoError(t, <-blockingDone)
+	assertInflightBytes(t, tt, 0, 0)
+	metadatatest.AssertEqualInflightLimiterRejectedRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualInflightLimiterWaitingRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestGRPCServerOptionsStream(t *testing.T) {
+	il, tt := newTestLimiter(t, &Config{MaxInflightBytes: 30})
+	opts, err := il.GetGRPCServerOptions(context.Background())
+	require.NoError(t, err)
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	received := make(chan string, 10)
+	release := make(chan struct{})
+	otlpstream.RegisterServer(srv, otlpstream.Handlers{
+		Traces: func(_ context.Context, req []byte) ([]byte, error) {
+			received <- string(req)
+			<-release
+			return req, nil
+		},
+	})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+	stream, err := otlpstream.NewStreamServiceClient(conn).ExportTraces(context.Background())
+	require.NoError(t, err)
+	// Every message is encoded in 11 bytes, the id and the request with their tags and the request length.
+	for i := range 2 {
+		require.NoError(t, stream.Send(&otlpstream.StreamRequest{Id: uint64(i + 1), Request: []byte("request")}))
+		assert.Equal(t, "request", <-received)
+	}
+	assertInflightBytes(t, tt, 22, 22)
+
+	// The message exceeding the budget ends the stream.
+	require.NoError(t, stream.Send(&otlpstream.StreamRequest{Id: 3, Request: []byte("request")}))
+	require.Eventually(t, func() bool {
+		_, err := tt.GetMetric("otelcol_inflight_limiter_rejected_requests")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	close(release)
+	for range 2 {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Nil(t, resp.GetStatus())
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	assertInflightBytes(t, tt, 0, 0)
+	metadatatest.AssertEqualInflightLimiterRejectedRequests(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+}
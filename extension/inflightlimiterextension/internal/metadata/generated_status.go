@@ -0,0 +1,18 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+// Package metadata contains the autogenerated telemetry and
+// build information for the extension/inflight_limiter component.
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("inflight_limiter")
+	ScopeName = "go.opentelemetry.io/collector/extension/inflightlimiterextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
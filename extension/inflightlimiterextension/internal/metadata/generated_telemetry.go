@@ -0,0 +1,89 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("go.opentelemetry.io/collector/extension/inflightlimiterextension")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("go.opentelemetry.io/collector/extension/inflightlimiterextension")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                                  metric.Meter
+	mu                                     sync.Mutex
+	registrations                          []metric.Registration
+	InflightLimiterInflightBytes           metric.Int64UpDownCounter
+	InflightLimiterInflightCompressedBytes metric.Int64UpDownCounter
+	InflightLimiterRejectedRequests        metric.Int64Counter
+	InflightLimiterWaitingRequests         metric.Int64UpDownCounter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.InflightLimiterInflightBytes, err = builder.meter.Int64UpDownCounter(
+		"otelcol_inflight_limiter_inflight_bytes",
+		metric.WithDescription("Number of uncompressed bytes of the requests admitted and not yet processed. [Development]"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.InflightLimiterInflightCompressedBytes, err = builder.meter.Int64UpDownCounter(
+		"otelcol_inflight_limiter_inflight_compressed_bytes",
+		metric.WithDescription("Number of compressed bytes of the requests admitted and not yet processed, when the compressed size of the requests is known. [Development]"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.InflightLimiterRejectedRequests, err = builder.meter.Int64Counter(
+		"otelcol_inflight_limiter_rejected_requests",
+		metric.WithDescription("Number of requests rejected because the in-flight bytes limit was exceeded. [Development]"),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.InflightLimiterWaitingRequests, err = builder.meter.Int64UpDownCounter(
+		"otelcol_inflight_limiter_waiting_requests",
+		metric.WithDescription("Number of requests waiting for the in-flight bytes to be released. [Development]"),
+		metric.WithUnit("{request}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
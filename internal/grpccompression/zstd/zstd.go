@@ -2,10 +2,12 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // Package zstd registers a gRPC zstd compressor compatible with the
-// collector's configgrpc package.
+// collector's configgrpc package, and the compressors using zstd dictionaries.
 package zstd // import "go.opentelemetry.io/collector/internal/grpccompression/zstd"
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 	"runtime"
 	"sync"
@@ -54,16 +56,68 @@ func registerCompressor(get func(string) encoding.Compressor, register func(enco
 	return true
 }
 
+// DictionaryName returns the content-coding used for the gRPC payloads
+// compressed with the zstd dictionary identified by id.
+func DictionaryName(id uint32) string {
+	return fmt.Sprintf("%s-dict-%d", Name, id)
+}
+
+// registerDictionaryMu serializes the registrations of the dictionary compressors.
+var registerDictionaryMu sync.Mutex
+
+// RegisterDictionary registers a compressor using the given zstd dictionary,
+// named after the dictionary ID, and returns its name. The compressor is only
+// registered once per dictionary ID, registering a different dictionary with
+// an ID already used returns an error. The gRPC compressors registry is not
+// synchronized, the dictionaries must be registered before the clients and
+// servers using them are created.
+func RegisterDictionary(dict []byte) (string, error) {
+	info, err := zstd.InspectDictionary(dict)
+	if err != nil {
+		return "", fmt.Errorf("invalid zstd dictionary: %w", err)
+	}
+
+	registerDictionaryMu.Lock()
+	defer registerDictionaryMu.Unlock()
+	name := DictionaryName(info.ID())
+	if registered := encoding.GetCompressor(name); registered != nil {
+		if c, ok := registered.(*compressor); !ok || !bytes.Equal(c.dict, dict) {
+			return "", fmt.Errorf("another zstd dictionary with the ID %d is already registered", info.ID())
+		}
+		return name, nil
+	}
+	encoding.RegisterCompressor(&compressor{dictID: info.ID(), dict: dict})
+	return name, nil
+}
+
+// compressor is the zstd compressor, its zero value does not use a dictionary.
 type compressor struct {
+	dictID uint32
+	dict   []byte
+
 	encoderPool sync.Pool
 	decoderPool sync.Pool
 }
 
+func (c *compressor) encoderOptions() []zstd.EOption {
+	if c.dict == nil {
+		return encoderOptions
+	}
+	return append([]zstd.EOption{zstd.WithEncoderDict(c.dict)}, encoderOptions...)
+}
+
+func (c *compressor) decoderOptions() []zstd.DOption {
+	if c.dict == nil {
+		return decoderOptions
+	}
+	return append([]zstd.DOption{zstd.WithDecoderDicts(c.dict)}, decoderOptions...)
+}
+
 func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
 	enc, ok := c.encoderPool.Get().(*zstd.Encoder)
 	if !ok {
 		var err error
-		enc, err = zstdNewWriter(w, encoderOptions...)
+		enc, err = zstdNewWriter(w, c.encoderOptions()...)
 		if err != nil {
 			return nil, err
 		}
@@ -83,7 +137,7 @@ func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
 	dec, ok := c.decoderPool.Get().(*zstd.Decoder)
 	if !ok {
 		var err error
-		dec, err = zstdNewReader(r, decoderOptions...)
+		dec, err = zstdNewReader(r, c.decoderOptions()...)
 		if err != nil {
 			return nil, err
 		}
@@ -101,7 +155,10 @@ func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
 }
 
 func (c *compressor) Name() string {
-	return Name
+	if c.dict == nil {
+		return Name
+	}
+	return DictionaryName(c.dictID)
 }
 
 type encoderWrapper struct {
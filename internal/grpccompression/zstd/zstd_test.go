@@ -6,10 +6,12 @@ package zstd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 	"testing"
 
+	"github.com/klauspost/compress/dict"
 	kzstd "github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,6 +68,39 @@ func TestRegisterCompressor(t *testing.T) {
 	})
 }
 
+func TestRegisterDictionary(t *testing.T) {
+	dict := buildDictionary(t, 1001)
+	name, err := RegisterDictionary(dict)
+	require.NoError(t, err)
+	assert.Equal(t, "zstd-dict-1001", name)
+
+	comp := encoding.GetCompressor(name)
+	require.NotNil(t, comp)
+	assert.Equal(t, name, comp.Name())
+	payload := []byte(`{"resource":{"attributes":{"service.name":"checkout","host.name":"node-7"}}}`)
+	assert.Equal(t, payload, roundTripCompression(t, comp, payload))
+
+	// The payloads compressed with the dictionary can't be decompressed without it.
+	_, err = io.ReadAll(decompressPayload(t, &compressor{}, compressPayload(t, comp, payload)))
+	require.Error(t, err)
+
+	// Registering the same dictionary again is a no-op.
+	name, err = RegisterDictionary(dict)
+	require.NoError(t, err)
+	assert.Equal(t, "zstd-dict-1001", name)
+	assert.Same(t, comp, encoding.GetCompressor(name))
+}
+
+func TestRegisterDictionaryErrors(t *testing.T) {
+	_, err := RegisterDictionary(buildDictionary(t, 1002))
+	require.NoError(t, err)
+	_, err = RegisterDictionary(buildDictionary(t, 1002, []byte("another dictionary content")))
+	require.EqualError(t, err, "another zstd dictionary with the ID 1002 is already registered")
+
+	_, err = RegisterDictionary([]byte("not a dictionary"))
+	require.ErrorContains(t, err, "invalid zstd dictionary")
+}
+
 func TestCompressReusesPooledEncoder(t *testing.T) {
 	c := &compressor{}
 	seed, err := kzstd.NewWriter(io.Discard, encoderOptions...)
@@ -204,15 +239,34 @@ func compressPayload(t *testing.T, comp encoding.Compressor, payload []byte) []b
 	return buf.Bytes()
 }
 
-func roundTripCompression(t *testing.T, comp encoding.Compressor, payload []byte) []byte {
+func decompressPayload(t *testing.T, comp encoding.Compressor, compressed []byte) io.Reader {
 	t.Helper()
 
-	compressed := compressPayload(t, comp, payload)
-
 	r, err := comp.Decompress(bytes.NewReader(compressed))
 	require.NoError(t, err)
+	return r
+}
+
+func roundTripCompression(t *testing.T, comp encoding.Compressor, payload []byte) []byte {
+	t.Helper()
 
-	got, err := io.ReadAll(r)
+	got, err := io.ReadAll(decompressPayload(t, comp, compressPayload(t, comp, payload)))
 	require.NoError(t, err)
 	return got
 }
+
+func buildDictionary(t *testing.T, id uint32, extraContent ...[]byte) []byte {
+	t.Helper()
+
+	var samples [][]byte
+	for i := range 20 {
+		sample := fmt.Appendf(nil, `{"resource":{"attributes":{"service.name":"checkout","host.name":"node-%d"}},"spans":[{"name":"GET /cart/%d"}]}`, i, i*7)
+		for _, content := range extraContent {
+			sample = append(sample, content...)
+		}
+		samples = append(samples, sample)
+	}
+	d, err := dict.BuildZstdDict(samples, dict.Options{MaxDictSize: 4096, HashBytes: 6, ZstdDictID: id})
+	require.NoError(t, err)
+	return d
+}
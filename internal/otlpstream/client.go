@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnimplemented is returned by Client.Export when the server does not support the streams.
+var ErrUnimplemented = errors.New("the server does not support the OTLP streams")
+
+var errClientClosed = status.Error(codes.Unavailable, "otlpstream: client is closed")
+
+var streamDesc = grpc.StreamDesc{
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// Client sends the export requests of a signal over long-lived streams. A stream is opened per key on the first
+// request with the key, and replaced by a new one when it fails or after the maximum stream lifetime. Client is
+// safe for concurrent use, the concurrent requests with the same key are in flight on the same stream.
+type Client struct {
+	conn        grpc.ClientConnInterface
+	method      string
+	maxLifetime time.Duration
+	callOptions []grpc.CallOption
+
+	mu      sync.Mutex
+	streams map[string]*clientStream
+	nextID  uint64
+	closed  bool
+}
+
+// NewClient returns a Client sending the requests to the given stream method. When maxLifetime is not zero,
+// the streams are closed after maxLifetime, once their pending requests are acknowledged.
+func NewClient(conn grpc.ClientConnInterface, method string, maxLifetime time.Duration, callOptions ...grpc.CallOption) *Client {
+	return &Client{
+		conn:        conn,
+		method:      method,
+		maxLifetime: maxLifetime,
+		callOptions: callOptions,
+		streams:     map[string]*clientStream{},
+	}
+}
+
+// Export sends the encoded OTLP export request and waits for its acknowledgement. It returns the encoded
+// OTLP export response, or the error of the request as a gRPC status error. If the server does not support
+// the streams, the returned error wraps ErrUnimplemented.
+//
+// The headers of a stream, including the outgoing metadata and the per-RPC credentials, are derived from the
+// context of the request opening it. The key must identify them: the requests with different keys are sent on
+// different streams, and a request is sent on the stream opened by a previous request with the same key.
+func (c *Client) Export(ctx context.Context, key string, payload []byte) ([]byte, error) {
+	cs, id, resultCh, err := c.register(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	cs.send(&StreamRequest{Id: id, Request: payload})
+	select {
+	case res := <-resultCh:
+		return res.payload, res.err
+	case <-ctx.Done():
+		cs.unregister(id)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// Close closes the streams, failing their pending requests.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for key, cs := range c.streams {
+		cs.cancel()
+		delete(c.streams, key)
+	}
+}
+
+// register returns the stream of the key to use for the next request, opening a new stream if needed, and
+// registers the request in the pending requests of the stream.
+func (c *Client) register(ctx context.Context, key string) (*clientStream, uint64, chan result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, 0, nil, errClientClosed
+	}
+	// The expired streams of all the keys are drained, so the streams of the keys which are no longer used
+	// are not kept open.
+	now := time.Now()
+	for k, cs := range c.streams {
+		switch {
+		case cs.broken():
+			delete(c.streams, k)
+		case c.maxLifetime > 0 && now.After(cs.expiry):
+			cs.drain()
+			delete(c.streams, k)
+		}
+	}
+	cs, ok := c.streams[key]
+	if !ok {
+		var err error
+		if cs, err = c.openStream(ctx); err != nil {
+			return nil, 0, nil, err
+		}
+		c.streams[key] = cs
+	}
+	c.nextID++
+	resultCh := make(chan result, 1)
+	if err := cs.addPending(c.nextID, resultCh); err != nil {
+		// The stream just failed, the request is retried on a new stream.
+		return nil, 0, nil, err
+	}
+	return cs, c.nextID, resultCh, nil
+}
+
+func (c *Client) openStream(ctx context.Context) (*clientStream, error) {
+	// The stream outlives the request opening it, only the opening is canceled with the request. The values of
+	// the request context are kept, so the stream is opened with its outgoing metadata and credentials.
+	streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, cancel)
+	stream, err := c.conn.NewStream(streamCtx, &streamDesc, c.method, c.callOptions...)
+	if !stop() || err != nil {
+		cancel()
+		if err == nil {
+			err = status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, err
+	}
+	cs := &clientStream{
+		stream:  stream,
+		cancel:  cancel,
+		expiry:  time.Now().Add(c.maxLifetime),
+		pending: map[uint64]chan result{},
+	}
+	go cs.receive()
+	return cs, nil
+}
+
+type result struct {
+	payload []byte
+	err     error
+}
+
+type clientStream struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	expiry time.Time
+
+	// sendMu serializes SendMsg and CloseSend, which cannot be called concurrently.
+	sendMu        sync.Mutex
+	closeSendOnce sync.Once
+
+	mu       sync.Mutex
+	pending  map[uint64]chan result
+	draining bool
+	err      error
+}
+
+func (cs *clientStream) send(req *StreamRequest) {
+	cs.sendMu.Lock()
+	err := cs.stream.SendMsg(req)
+	cs.sendMu.Unlock()
+	if err != nil && !errors.Is(err, io.EOF) {
+		cs.fail(err)
+	}
+	// On io.EOF, the stream was terminated and the error is returned by RecvMsg, the pending requests
+	// are failed by the receive loop.
+}
+
+func (cs *clientStream) receive() {
+	for {
+		resp := &StreamResponse{}
+		if err := cs.stream.RecvMsg(resp); err != nil {
+			cs.fail(err)
+			return
+		}
+		cs.complete(resp)
+	}
+}
+
+func (cs *clientStream) broken() bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.err != nil
+}
+
+func (cs *clientStream) addPending(id uint64, resultCh chan result) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.err != nil {
+		return cs.err
+	}
+	cs.pending[id] = resultCh
+	return nil
+}
+
+func (cs *clientStream) unregister(id uint64) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	delete(cs.pending, id)
+	cs.closeSendIfDrained()
+}
+
+func (cs *clientStream) complete(resp *StreamResponse) {
+	res := result{payload: resp.Response}
+	if resp.Status != nil {
+		res.err = status.ErrorProto(resp.Status)
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if resultCh, ok := cs.pending[resp.Id]; ok {
+		delete(cs.pending, resp.Id)
+		resultCh <- res
+	}
+	cs.closeSendIfDrained()
+}
+
+// drain stops sending requests on the stream, it is closed once its pending requests are acknowledged.
+func (cs *clientStream) drain() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.draining = true
+	cs.closeSendIfDrained()
+}
+
+func (cs *clientStream) closeSendIfDrained() {
+	if !cs.draining || len(cs.pending) > 0 || cs.err != nil {
+		return
+	}
+	cs.closeSendOnce.Do(func() {
+		// CloseSend waits for the sends in progress, which may be blocked by the flow control until the
+		// receive loop reads the next responses, so it must not block the caller.
+		go func() {
+			cs.sendMu.Lock()
+			defer cs.sendMu.Unlock()
+			// The server returns once all the requests are acknowledged, the receive loop then ends with io.EOF.
+			_ = cs.stream.CloseSend()
+		}()
+	})
+}
+
+// fail marks the stream as broken and fails all its pending requests with the error.
+func (cs *clientStream) fail(err error) {
+	switch {
+	case errors.Is(err, io.EOF):
+		err = status.Error(codes.Unavailable, "otlpstream: stream closed by the server")
+	case status.Code(err) == codes.Unimplemented:
+		err = fmt.Errorf("%w: %w", ErrUnimplemented, err)
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.err != nil {
+		return
+	}
+	cs.err = err
+	for id, resultCh := range cs.pending {
+		resultCh <- result{err: err}
+		delete(cs.pending, id)
+	}
+	cs.cancel()
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpstream implements the collector streaming transport for the OTLP export requests.
+//
+// The OTLP export requests of a signal are sent over a long-lived bidirectional gRPC stream instead of
+// one unary Export call per request, so the headers, the authentication and the compression setup are paid
+// once per stream. Every request is acknowledged by a response with the same id, carrying either the OTLP
+// export response or the gRPC status of the failure, and multiple requests can be in flight on a stream.
+//
+// The messages and the service are defined in otlpstream.proto.
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+const (
+	serviceName = "opentelemetry.collector.otlpstream.v1.StreamService"
+
+	// TracesMethod is the full name of the traces stream method.
+	TracesMethod = "/" + serviceName + "/ExportTraces"
+	// MetricsMethod is the full name of the metrics stream method.
+	MetricsMethod = "/" + serviceName + "/ExportMetrics"
+	// LogsMethod is the full name of the logs stream method.
+	LogsMethod = "/" + serviceName + "/ExportLogs"
+	// ProfilesMethod is the full name of the profiles stream method.
+	ProfilesMethod = "/" + serviceName + "/ExportProfiles"
+)
@@ -0,0 +1,548 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: internal/otlpstream/otlpstream.proto
+
+package otlpstream
+
+import (
+	context "context"
+	status "google.golang.org/genproto/googleapis/rpc/status"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status1 "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StreamRequest is an OTLP export request sent on a stream.
+type StreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the request on the stream, the response acknowledging the request has the same id.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The encoded OTLP Export*ServiceRequest.
+	Request       []byte `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	mi := &file_internal_otlpstream_otlpstream_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_otlpstream_otlpstream_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_internal_otlpstream_otlpstream_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StreamRequest) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// StreamResponse acknowledges a request of the stream.
+type StreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id of the acknowledged request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The encoded OTLP Export*ServiceResponse, set when the request succeeded.
+	Response []byte `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	// The status of the request, set when the request failed.
+	Status        *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamResponse) Reset() {
+	*x = StreamResponse{}
+	mi := &file_internal_otlpstream_otlpstream_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamResponse) ProtoMessage() {}
+
+func (x *StreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_otlpstream_otlpstream_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamResponse.ProtoReflect.Descriptor instead.
+func (*StreamResponse) Descriptor() ([]byte, []int) {
+	return file_internal_otlpstream_otlpstream_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StreamResponse) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *StreamResponse) GetStatus() *status.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_internal_otlpstream_otlpstream_proto protoreflect.FileDescriptor
+
+const file_internal_otlpstream_otlpstream_proto_rawDesc = "" +
+	"\n" +
+	"$internal/otlpstream/otlpstream.proto\x12%opentelemetry.collector.otlpstream.v1\x1a\x17google/rpc/status.proto\"9\n" +
+	"\rStreamRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\arequest\x18\x02 \x01(\fR\arequest\"h\n" +
+	"\x0eStreamResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\fR\bresponse\x12*\n" +
+	"\x06status\x18\x03 \x01(\v2\x12.google.rpc.StatusR\x06status2\x9f\x04\n" +
+	"\rStreamService\x12\x81\x01\n" +
+	"\fExportTraces\x124.opentelemetry.collector.otlpstream.v1.StreamRequest\x1a5.opentelemetry.collector.otlpstream.v1.StreamResponse\"\x00(\x010\x01\x12\x82\x01\n" +
+	"\rExportMetrics\x124.opentelemetry.collector.otlpstream.v1.StreamRequest\x1a5.opentelemetry.collector.otlpstream.v1.StreamResponse\"\x00(\x010\x01\x12\x7f\n" +
+	"\n" +
+	"ExportLogs\x124.opentelemetry.collector.otlpstream.v1.StreamRequest\x1a5.opentelemetry.collector.otlpstream.v1.StreamResponse\"\x00(\x010\x01\x12\x83\x01\n" +
+	"\x0eExportProfiles\x124.opentelemetry.collector.otlpstream.v1.StreamRequest\x1a5.opentelemetry.collector.otlpstream.v1.StreamResponse\"\x00(\x010\x01B3Z1go.opentelemetry.io/collector/internal/otlpstreamb\x06proto3"
+
+var (
+	file_internal_otlpstream_otlpstream_proto_rawDescOnce sync.Once
+	file_internal_otlpstream_otlpstream_proto_rawDescData []byte
+)
+
+func file_internal_otlpstream_otlpstream_proto_rawDescGZIP() []byte {
+	file_internal_otlpstream_otlpstream_proto_rawDescOnce.Do(func() {
+		file_internal_otlpstream_otlpstream_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_otlpstream_otlpstream_proto_rawDesc), len(file_internal_otlpstream_otlpstream_proto_rawDesc)))
+	})
+	return file_internal_otlpstream_otlpstream_proto_rawDescData
+}
+
+var file_internal_otlpstream_otlpstream_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_otlpstream_otlpstream_proto_goTypes = []any{
+	(*StreamRequest)(nil),  // 0: opentelemetry.collector.otlpstream.v1.StreamRequest
+	(*StreamResponse)(nil), // 1: opentelemetry.collector.otlpstream.v1.StreamResponse
+	(*status.Status)(nil),  // 2: google.rpc.Status
+}
+var file_internal_otlpstream_otlpstream_proto_depIdxs = []int32{
+	2, // 0: opentelemetry.collector.otlpstream.v1.StreamResponse.status:type_name -> google.rpc.Status
+	0, // 1: opentelemetry.collector.otlpstream.v1.StreamService.ExportTraces:input_type -> opentelemetry.collector.otlpstream.v1.StreamRequest
+	0, // 2: opentelemetry.collector.otlpstream.v1.StreamService.ExportMetrics:input_type -> opentelemetry.collector.otlpstream.v1.StreamRequest
+	0, // 3: opentelemetry.collector.otlpstream.v1.StreamService.ExportLogs:input_type -> opentelemetry.collector.otlpstream.v1.StreamRequest
+	0, // 4: opentelemetry.collector.otlpstream.v1.StreamService.ExportProfiles:input_type -> opentelemetry.collector.otlpstream.v1.StreamRequest
+	1, // 5: opentelemetry.collector.otlpstream.v1.StreamService.ExportTraces:output_type -> opentelemetry.collector.otlpstream.v1.StreamResponse
+	1, // 6: opentelemetry.collector.otlpstream.v1.StreamService.ExportMetrics:output_type -> opentelemetry.collector.otlpstream.v1.StreamResponse
+	1, // 7: opentelemetry.collector.otlpstream.v1.StreamService.ExportLogs:output_type -> opentelemetry.collector.otlpstream.v1.StreamResponse
+	1, // 8: opentelemetry.collector.otlpstream.v1.StreamService.ExportProfiles:output_type -> opentelemetry.collector.otlpstream.v1.StreamResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_internal_otlpstream_otlpstream_proto_init() }
+func file_internal_otlpstream_otlpstream_proto_init() {
+	if File_internal_otlpstream_otlpstream_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_otlpstream_otlpstream_proto_rawDesc), len(file_internal_otlpstream_otlpstream_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_otlpstream_otlpstream_proto_goTypes,
+		DependencyIndexes: file_internal_otlpstream_otlpstream_proto_depIdxs,
+		MessageInfos:      file_internal_otlpstream_otlpstream_proto_msgTypes,
+	}.Build()
+	File_internal_otlpstream_otlpstream_proto = out.File
+	file_internal_otlpstream_otlpstream_proto_goTypes = nil
+	file_internal_otlpstream_otlpstream_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// StreamServiceClient is the client API for StreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StreamServiceClient interface {
+	// ExportTraces exports the encoded OTLP ExportTraceServiceRequest messages.
+	ExportTraces(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportTracesClient, error)
+	// ExportMetrics exports the encoded OTLP ExportMetricsServiceRequest messages.
+	ExportMetrics(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportMetricsClient, error)
+	// ExportLogs exports the encoded OTLP ExportLogsServiceRequest messages.
+	ExportLogs(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportLogsClient, error)
+	// ExportProfiles exports the encoded OTLP ExportProfilesServiceRequest messages.
+	ExportProfiles(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportProfilesClient, error)
+}
+
+type streamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamServiceClient(cc grpc.ClientConnInterface) StreamServiceClient {
+	return &streamServiceClient{cc}
+}
+
+func (c *streamServiceClient) ExportTraces(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportTracesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamService_serviceDesc.Streams[0], "/opentelemetry.collector.otlpstream.v1.StreamService/ExportTraces", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamServiceExportTracesClient{stream}
+	return x, nil
+}
+
+type StreamService_ExportTracesClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type streamServiceExportTracesClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamServiceExportTracesClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamServiceExportTracesClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamServiceClient) ExportMetrics(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportMetricsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamService_serviceDesc.Streams[1], "/opentelemetry.collector.otlpstream.v1.StreamService/ExportMetrics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamServiceExportMetricsClient{stream}
+	return x, nil
+}
+
+type StreamService_ExportMetricsClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type streamServiceExportMetricsClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamServiceExportMetricsClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamServiceExportMetricsClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamServiceClient) ExportLogs(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamService_serviceDesc.Streams[2], "/opentelemetry.collector.otlpstream.v1.StreamService/ExportLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamServiceExportLogsClient{stream}
+	return x, nil
+}
+
+type StreamService_ExportLogsClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type streamServiceExportLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamServiceExportLogsClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamServiceExportLogsClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamServiceClient) ExportProfiles(ctx context.Context, opts ...grpc.CallOption) (StreamService_ExportProfilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamService_serviceDesc.Streams[3], "/opentelemetry.collector.otlpstream.v1.StreamService/ExportProfiles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamServiceExportProfilesClient{stream}
+	return x, nil
+}
+
+type StreamService_ExportProfilesClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type streamServiceExportProfilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamServiceExportProfilesClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamServiceExportProfilesClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamServiceServer is the server API for StreamService service.
+type StreamServiceServer interface {
+	// ExportTraces exports the encoded OTLP ExportTraceServiceRequest messages.
+	ExportTraces(StreamService_ExportTracesServer) error
+	// ExportMetrics exports the encoded OTLP ExportMetricsServiceRequest messages.
+	ExportMetrics(StreamService_ExportMetricsServer) error
+	// ExportLogs exports the encoded OTLP ExportLogsServiceRequest messages.
+	ExportLogs(StreamService_ExportLogsServer) error
+	// ExportProfiles exports the encoded OTLP ExportProfilesServiceRequest messages.
+	ExportProfiles(StreamService_ExportProfilesServer) error
+}
+
+// UnimplementedStreamServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedStreamServiceServer struct {
+}
+
+func (*UnimplementedStreamServiceServer) ExportTraces(StreamService_ExportTracesServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ExportTraces not implemented")
+}
+func (*UnimplementedStreamServiceServer) ExportMetrics(StreamService_ExportMetricsServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ExportMetrics not implemented")
+}
+func (*UnimplementedStreamServiceServer) ExportLogs(StreamService_ExportLogsServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ExportLogs not implemented")
+}
+func (*UnimplementedStreamServiceServer) ExportProfiles(StreamService_ExportProfilesServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ExportProfiles not implemented")
+}
+
+func RegisterStreamServiceServer(s *grpc.Server, srv StreamServiceServer) {
+	s.RegisterService(&_StreamService_serviceDesc, srv)
+}
+
+func _StreamService_ExportTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamServiceServer).ExportTraces(&streamServiceExportTracesServer{stream})
+}
+
+type StreamService_ExportTracesServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type streamServiceExportTracesServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamServiceExportTracesServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamServiceExportTracesServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StreamService_ExportMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamServiceServer).ExportMetrics(&streamServiceExportMetricsServer{stream})
+}
+
+type StreamService_ExportMetricsServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type streamServiceExportMetricsServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamServiceExportMetricsServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamServiceExportMetricsServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StreamService_ExportLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamServiceServer).ExportLogs(&streamServiceExportLogsServer{stream})
+}
+
+type StreamService_ExportLogsServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type streamServiceExportLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamServiceExportLogsServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamServiceExportLogsServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StreamService_ExportProfiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamServiceServer).ExportProfiles(&streamServiceExportProfilesServer{stream})
+}
+
+type StreamService_ExportProfilesServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type streamServiceExportProfilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamServiceExportProfilesServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamServiceExportProfilesServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _StreamService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.collector.otlpstream.v1.StreamService",
+	HandlerType: (*StreamServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTraces",
+			Handler:       _StreamService_ExportTraces_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportMetrics",
+			Handler:       _StreamService_ExportMetrics_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportLogs",
+			Handler:       _StreamService_ExportLogs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProfiles",
+			Handler:       _StreamService_ExportProfiles_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "internal/otlpstream/otlpstream.proto",
+}
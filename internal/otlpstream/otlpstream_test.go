@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// streamCounter counts the streams opened on the server.
+type streamCounter struct {
+	count atomic.Int64
+}
+
+func (c *streamCounter) intercept(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	c.count.Add(1)
+	return handler(srv, ss)
+}
+
+func startServer(t *testing.T, handlers Handlers, counter *streamCounter) *grpc.ClientConn {
+	ln := bufconn.Listen(1 << 20)
+	var opts []grpc.ServerOption
+	if counter != nil {
+		opts = append(opts, grpc.StreamInterceptor(counter.intercept))
+	}
+	srv := grpc.NewServer(opts...)
+	RegisterServer(srv, handlers)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	return conn
+}
+
+func TestClient_Export(t *testing.T) {
+	counter := &streamCounter{}
+	conn := startServer(t, Handlers{
+		Logs: func(_ context.Context, req []byte) ([]byte, error) {
+			switch string(req) {
+			case "permanent":
+				return nil, status.Error(codes.InvalidArgument, "bad data")
+			case "throttled":
+				st, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+				require.NoError(t, err)
+				return nil, st.Err()
+			}
+			return append([]byte("ack "), req...), nil
+		},
+	}, counter)
+	client := NewClient(conn, LogsMethod, 0)
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			resp, err := client.Export(context.Background(), "", []byte(strconv.Itoa(i)))
+			assert.NoError(t, err)
+			assert.Equal(t, "ack "+strconv.Itoa(i), string(resp))
+		})
+	}
+	wg.Wait()
+
+	_, err := client.Export(context.Background(), "", []byte("permanent"))
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "bad data", st.Message())
+
+	_, err = client.Export(context.Background(), "", []byte("throttled"))
+	st = status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, time.Second, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	// All the requests, including the failed ones, are sent on the same stream.
+	assert.EqualValues(t, 1, counter.count.Load())
+
+	client.Close()
+	_, err = client.Export(context.Background(), "", []byte("closed"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestClient_ExportKeys(t *testing.T) {
+	counter := &streamCounter{}
+	conn := startServer(t, Handlers{
+		Logs: func(ctx context.Context, req []byte) ([]byte, error) {
+			// The tenant of the stream must be the tenant of the request.
+			md, _ := metadata.FromIncomingContext(ctx)
+			return []byte(strings.Join(md.Get("x-tenant"), ",") + " " + string(req)), nil
+		},
+	}, counter)
+	client := NewClient(conn, LogsMethod, 0)
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for _, tenant := range []string{"acme", "globex", "acme", "globex"} {
+		wg.Go(func() {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", tenant)
+			resp, err := client.Export(ctx, tenant, []byte("logs"))
+			assert.NoError(t, err)
+			assert.Equal(t, tenant+" logs", string(resp))
+		})
+	}
+	wg.Wait()
+
+	// A stream is opened per tenant, with the metadata of the tenant.
+	assert.EqualValues(t, 2, counter.count.Load())
+}
+
+func TestClient_Unimplemented(t *testing.T) {
+	conn := startServer(t, Handlers{
+		Traces: func(context.Context, []byte) ([]byte, error) { return nil, nil },
+	}, nil)
+	client := NewClient(conn, LogsMethod, 0)
+	defer client.Close()
+
+	_, err := client.Export(context.Background(), "", []byte("logs"))
+	require.ErrorIs(t, err, ErrUnimplemented)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestClient_MaxLifetime(t *testing.T) {
+	counter := &streamCounter{}
+	conn := startServer(t, Handlers{
+		Metrics: func(_ context.Context, req []byte) ([]byte, error) { return req, nil },
+	}, counter)
+	client := NewClient(conn, MetricsMethod, time.Millisecond)
+	defer client.Close()
+
+	for i := range 3 {
+		resp, err := client.Export(context.Background(), "", []byte(strconv.Itoa(i)))
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(i), string(resp))
+		time.Sleep(2 * time.Millisecond)
+	}
+	// Every request is sent on a new stream, the previous streams are closed once drained.
+	assert.EqualValues(t, 3, counter.count.Load())
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	conn := startServer(t, Handlers{
+		Profiles: func(_ context.Context, req []byte) ([]byte, error) {
+			if string(req) == "slow" {
+				<-release
+			}
+			return req, nil
+		},
+	}, nil)
+	client := NewClient(conn, ProfilesMethod, 0)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Export(ctx, "", []byte("slow"))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// The late acknowledgement is ignored, the stream is still usable.
+	close(release)
+	resp, err := client.Export(context.Background(), "", []byte("fast"))
+	require.NoError(t, err)
+	assert.Equal(t, "fast", string(resp))
+}
+
+func TestClient_StreamFailure(t *testing.T) {
+	conn := startServer(t, Handlers{
+		Traces: func(context.Context, []byte) ([]byte, error) { return []byte("ok"), nil },
+	}, nil)
+	client := NewClient(conn, TracesMethod, 0)
+	defer client.Close()
+
+	_, err := client.Export(context.Background(), "", []byte("first"))
+	require.NoError(t, err)
+
+	// Break the current stream, the next request opens a new one.
+	client.mu.Lock()
+	cs := client.streams[""]
+	client.mu.Unlock()
+	cs.cancel()
+	assert.Eventually(t, cs.broken, time.Second, time.Millisecond)
+
+	resp, err := client.Export(context.Background(), "", []byte("second"))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(resp))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	streamSrv := RegisterServer(srv, Handlers{
+		Logs: func(_ context.Context, req []byte) ([]byte, error) { return req, nil },
+	})
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	client := NewClient(conn, LogsMethod, 0)
+	defer client.Close()
+
+	_, err = client.Export(context.Background(), "", []byte("before"))
+	require.NoError(t, err)
+
+	// The open stream does not block the graceful stop of the server.
+	streamSrv.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the server was not stopped")
+	}
+
+	_, err = client.Export(context.Background(), "", []byte("after"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
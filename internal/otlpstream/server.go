@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxConcurrentRequests is the maximum number of requests of a stream processed concurrently, the next
+// requests are not read until one of them is acknowledged.
+const maxConcurrentRequests = 64
+
+var (
+	errShuttingDown  = status.Error(codes.Unavailable, "otlpstream: server is shutting down")
+	errUnimplemented = status.Error(codes.Unimplemented, "otlpstream: the signal is not supported by the server")
+)
+
+// Handler processes an encoded OTLP export request and returns the encoded OTLP export response.
+// The returned errors are sent to the client as their gRPC status.
+type Handler func(ctx context.Context, req []byte) ([]byte, error)
+
+// Handlers are the handlers of the signals, the streams of the signals without a handler are rejected
+// with the Unimplemented code, as the unary Export calls of an unregistered OTLP service.
+type Handlers struct {
+	Traces   Handler
+	Metrics  Handler
+	Logs     Handler
+	Profiles Handler
+}
+
+// Server is the stream service registered to a gRPC server.
+type Server struct {
+	handlers     Handlers
+	shutdownOnce sync.Once
+	shutdownCh   chan struct{}
+}
+
+var _ StreamServiceServer = (*Server)(nil)
+
+// RegisterServer registers the stream service to the gRPC server.
+func RegisterServer(s grpc.ServiceRegistrar, h Handlers) *Server {
+	srv := &Server{handlers: h, shutdownCh: make(chan struct{})}
+	s.RegisterService(&_StreamService_serviceDesc, srv)
+	return srv
+}
+
+// ExportTraces implements StreamServiceServer.
+func (s *Server) ExportTraces(stream StreamService_ExportTracesServer) error {
+	return s.serveStream(stream, s.handlers.Traces)
+}
+
+// ExportMetrics implements StreamServiceServer.
+func (s *Server) ExportMetrics(stream StreamService_ExportMetricsServer) error {
+	return s.serveStream(stream, s.handlers.Metrics)
+}
+
+// ExportLogs implements StreamServiceServer.
+func (s *Server) ExportLogs(stream StreamService_ExportLogsServer) error {
+	return s.serveStream(stream, s.handlers.Logs)
+}
+
+// ExportProfiles implements StreamServiceServer.
+func (s *Server) ExportProfiles(stream StreamService_ExportProfilesServer) error {
+	return s.serveStream(stream, s.handlers.Profiles)
+}
+
+// Shutdown closes the streams with the Unavailable code once their pending requests are acknowledged, so
+// the clients send their next requests on new streams. It must be called before stopping the gRPC server
+// gracefully, which waits for the streams to end.
+func (s *Server) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdownCh)
+	})
+}
+
+// serveStream processes the requests of a stream until the client closes it or the server is shut down, the
+// requests are processed concurrently and acknowledged as soon as they are processed.
+func (s *Server) serveStream(stream grpc.ServerStream, handler Handler) error {
+	if handler == nil {
+		return errUnimplemented
+	}
+	ctx := stream.Context()
+	var (
+		wg     sync.WaitGroup
+		sendMu sync.Mutex
+		sem    = make(chan struct{}, maxConcurrentRequests)
+		reqCh  = make(chan *StreamRequest)
+		errCh  = make(chan error, 1)
+	)
+	defer wg.Wait()
+
+	// RecvMsg cannot be interrupted, the requests are received in a separate goroutine which ends with the
+	// stream, once the handler returns.
+	go func() {
+		for {
+			req := &StreamRequest{}
+			if err := stream.RecvMsg(req); err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case reqCh <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var req *StreamRequest
+		select {
+		case req = <-reqCh:
+		case err := <-errCh:
+			if errors.Is(err, io.EOF) {
+				// The client closed the stream, its pending requests are acknowledged before returning.
+				return nil
+			}
+			return err
+		case <-s.shutdownCh:
+			return errShuttingDown
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-s.shutdownCh:
+			return errShuttingDown
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp := &StreamResponse{Id: req.Id}
+			payload, err := handler(ctx, req.Request)
+			if err != nil {
+				resp.Status = status.Convert(err).Proto()
+			} else {
+				resp.Response = payload
+			}
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			// A failed send means the stream is broken, the receiving goroutine gets the error.
+			_ = stream.SendMsg(resp)
+		}()
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue // import "go.opentelemetry.io/collector/internal/persistentqueue"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// zstdDecoder is shared by all the queues, DecodeAll can be called concurrently.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+})
+
+// Decompress decompresses an item persisted with the given compression. The items are decompressed
+// independently of the current settings, so the compression can be changed on an existing queue.
+func Decompress(typ configcompression.Type, buf []byte) ([]byte, error) {
+	var reader io.Reader
+	switch typ {
+	case "":
+		return buf, nil
+	case configcompression.TypeSnappy:
+		return snappy.Decode(nil, buf)
+	case configcompression.TypeZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(buf, nil)
+	case configcompression.TypeGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		reader = gr
+	case configcompression.TypeZlib, configcompression.TypeDeflate:
+		zr, err := zlib.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		reader = zr
+	case configcompression.TypeSnappyFramed:
+		reader = snappy.NewReader(bytes.NewReader(buf))
+	case configcompression.TypeLz4:
+		reader = lz4.NewReader(bytes.NewReader(buf))
+	default:
+		return nil, fmt.Errorf("unsupported compression type %q", typ)
+	}
+	return io.ReadAll(reader)
+}
+
+// DecodeValue returns the request as encoded by the queue encoding, given the stored value and item metadata.
+func DecodeValue(value, itemMetadataBuf []byte) ([]byte, error) {
+	if len(itemMetadataBuf) == 0 {
+		return value, nil
+	}
+	itemMetadata := &PersistentItemMetadata{}
+	if err := proto.Unmarshal(itemMetadataBuf, itemMetadata); err != nil {
+		return nil, err
+	}
+	return Decompress(configcompression.Type(itemMetadata.Compression), value)
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue // import "go.opentelemetry.io/collector/internal/persistentqueue"
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// encryptedValueMagic starts every encrypted value. A protobuf message never starts with a zero byte, so the values
+// stored before the encryption was enabled are told apart.
+var encryptedValueMagic = []byte{0x00, 'o', 't', 'e'}
+
+const encryptedValueVersion = 1
+
+// ErrDecryptionFailed is returned when a persisted value is not encrypted, or cannot be decrypted with the known keys.
+var ErrDecryptionFailed = errors.New("failed to decrypt the persisted value")
+
+// EncryptionKey is a key used to encrypt the values persisted by the queue.
+type EncryptionKey struct {
+	// ID identifies the key, it is stored with every encrypted value.
+	ID string
+	// Key is the AES key, 16, 24 or 32 bytes long.
+	Key []byte
+}
+
+// EncryptionSettings define the keys used to encrypt the values persisted by the queue.
+type EncryptionSettings struct {
+	// Key is used to encrypt the new values.
+	Key EncryptionKey
+	// PreviousKeys are only used to decrypt the values encrypted before the key rotation.
+	PreviousKeys []EncryptionKey
+}
+
+// ParseEncryptionKey decodes a base64 encoded AES key, surrounding whitespaces are ignored.
+func ParseEncryptionKey(id string, encoded []byte) (EncryptionKey, error) {
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return EncryptionKey{}, fmt.Errorf("failed to decode the encryption key %q: %w", id, err)
+	}
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return EncryptionKey{}, fmt.Errorf("the encryption key %q must be 16, 24 or 32 bytes long, found %d", id, len(key))
+	}
+	return EncryptionKey{ID: id, Key: key}, nil
+}
+
+// encryptedClient is a storage.Client that encrypts the values using AES-GCM before passing them to the
+// underlying storage. Every value is stored as:
+//
+//	magic (4 bytes) | version (1 byte) | key ID length (1 byte) | key ID | nonce | ciphertext and tag
+//
+// The storage key is used as additional authenticated data, so a value cannot be moved to another key.
+type encryptedClient struct {
+	storage.Client
+	keyID []byte
+	aead  cipher.AEAD
+	// aeads contains all the known keys by ID, including the current one.
+	aeads map[string]cipher.AEAD
+}
+
+// NewEncryptedClient returns a storage.Client that transparently encrypts the values stored by the given client.
+// The values stored without encryption are rejected, EncryptStoredValues encrypts them when the encryption is
+// enabled on an existing queue.
+func NewEncryptedClient(client storage.Client, set EncryptionSettings) (storage.Client, error) {
+	if len(set.Key.ID) > 255 {
+		return nil, fmt.Errorf("encryption key ID %q is too long", set.Key.ID)
+	}
+	ec := &encryptedClient{
+		Client: client,
+		keyID:  []byte(set.Key.ID),
+		aeads:  make(map[string]cipher.AEAD, len(set.PreviousKeys)+1),
+	}
+	for _, key := range append([]EncryptionKey{set.Key}, set.PreviousKeys...) {
+		block, err := aes.NewCipher(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", key.ID, err)
+		}
+		if _, ok := ec.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate encryption key ID %q", key.ID)
+		}
+		ec.aeads[key.ID] = aead
+	}
+	ec.aead = ec.aeads[set.Key.ID]
+	return ec, nil
+}
+
+func (ec *encryptedClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := ec.Client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return ec.decrypt(key, value)
+}
+
+func (ec *encryptedClient) Set(ctx context.Context, key string, value []byte) error {
+	encrypted, err := ec.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return ec.Client.Set(ctx, key, encrypted)
+}
+
+func (ec *encryptedClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	encOps := make([]*storage.Operation, len(ops))
+	for i, op := range ops {
+		if op.Type != storage.Set {
+			// The get operations are filled in-place, then decrypted.
+			encOps[i] = op
+			continue
+		}
+		encrypted, err := ec.encrypt(op.Key, op.Value)
+		if err != nil {
+			return err
+		}
+		encOps[i] = storage.SetOperation(op.Key, encrypted)
+	}
+	if err := ec.Client.Batch(ctx, encOps...); err != nil {
+		return err
+	}
+	var errs []error
+	for _, op := range ops {
+		if op.Type != storage.Get {
+			continue
+		}
+		value, err := ec.decrypt(op.Key, op.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %q: %w", op.Key, err))
+		}
+		op.Value = value
+	}
+	return errors.Join(errs...)
+}
+
+func (ec *encryptedClient) encrypt(key string, value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	headerLen := len(encryptedValueMagic) + 2 + len(ec.keyID)
+	buf := make([]byte, headerLen+ec.aead.NonceSize(), headerLen+ec.aead.NonceSize()+len(value)+ec.aead.Overhead())
+	n := copy(buf, encryptedValueMagic)
+	buf[n] = encryptedValueVersion
+	buf[n+1] = byte(len(ec.keyID))
+	copy(buf[n+2:], ec.keyID)
+	nonce := buf[headerLen:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return ec.aead.Seal(buf, nonce, value, []byte(key)), nil
+}
+
+func (ec *encryptedClient) decrypt(key string, value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		// Not set.
+		return value, nil
+	}
+	if !bytes.HasPrefix(value, encryptedValueMagic) {
+		return nil, fmt.Errorf("%w: the value is not encrypted", ErrDecryptionFailed)
+	}
+	buf := value[len(encryptedValueMagic):]
+	if len(buf) < 2 || buf[0] != encryptedValueVersion || len(buf) < 2+int(buf[1]) {
+		return nil, fmt.Errorf("%w: invalid header", ErrDecryptionFailed)
+	}
+	keyID := string(buf[2 : 2+int(buf[1])])
+	buf = buf[2+int(buf[1]):]
+	aead, ok := ec.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown encryption key ID %q", ErrDecryptionFailed, keyID)
+	}
+	if len(buf) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid header", ErrDecryptionFailed)
+	}
+	plain, err := aead.Open(nil, buf[:aead.NonceSize()], buf[aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return plain, nil
+}
+
+// EncryptStoredValues encrypts the values of a queue stored before the encryption was enabled, so they can be read
+// by the encrypted client. It does nothing if the queue metadata is not set or already encrypted.
+func EncryptStoredValues(ctx context.Context, client storage.Client, set EncryptionSettings, logger *zap.Logger) error {
+	metadataBytes, err := client.Get(ctx, MetadataKey)
+	if err != nil {
+		return err
+	}
+	if len(metadataBytes) == 0 || bytes.HasPrefix(metadataBytes, encryptedValueMagic) {
+		return nil
+	}
+	var metadata PersistentMetadata
+	if err = proto.Unmarshal(metadataBytes, &metadata); err != nil {
+		return fmt.Errorf("failed to read the queue metadata: %w", err)
+	}
+	ec, err := NewEncryptedClient(client, set)
+	if err != nil {
+		return err
+	}
+
+	indexes := slices.Clone(metadata.CurrentlyDispatchedItems)
+	for index := metadata.ReadIndex; index < metadata.WriteIndex; index++ {
+		indexes = append(indexes, index)
+	}
+	for _, index := range indexes {
+		getOps := []*storage.Operation{
+			storage.GetOperation(ItemKey(index)),
+			storage.GetOperation(EnqueueTimeKey(index)),
+			storage.GetOperation(ItemMetadataKey(index)),
+		}
+		if err = client.Batch(ctx, getOps...); err != nil {
+			return err
+		}
+		var setOps []*storage.Operation
+		for _, op := range getOps {
+			// Skip the values encrypted before an interruption of the migration.
+			if len(op.Value) > 0 && !bytes.HasPrefix(op.Value, encryptedValueMagic) {
+				setOps = append(setOps, storage.SetOperation(op.Key, op.Value))
+			}
+		}
+		if len(setOps) == 0 {
+			continue
+		}
+		if err = ec.Batch(ctx, setOps...); err != nil {
+			return err
+		}
+	}
+	// The metadata is encrypted last, so an interrupted migration is resumed on the next start.
+	if err = ec.Set(ctx, MetadataKey, metadataBytes); err != nil {
+		return err
+	}
+	logger.Info("Encrypted the queue stored before the encryption was enabled", zap.Int("items", len(indexes)))
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+var (
+	testKeyA = EncryptionKey{ID: "a", Key: bytes.Repeat([]byte{1}, 32)}
+	testKeyB = EncryptionKey{ID: "b", Key: bytes.Repeat([]byte{2}, 16)}
+)
+
+func newEncryptedTestClient(t *testing.T, raw storage.Client, set EncryptionSettings) storage.Client {
+	client, err := NewEncryptedClient(raw, set)
+	require.NoError(t, err)
+	return client
+}
+
+func TestEncryptedClient(t *testing.T) {
+	ctx := context.Background()
+	raw := newMemClient()
+	client := newEncryptedTestClient(t, raw, EncryptionSettings{Key: testKeyA})
+
+	require.NoError(t, client.Set(ctx, "k1", []byte("secret value")))
+	got, err := client.Get(ctx, "k1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret value"), got)
+
+	stored, err := raw.Get(ctx, "k1")
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(stored, append(encryptedValueMagic, encryptedValueVersion, 1, 'a')))
+	assert.NotContains(t, string(stored), "secret value")
+
+	setOp := storage.SetOperation("k2", []byte("other"))
+	require.NoError(t, client.Batch(ctx, setOp, storage.DeleteOperation("k1")))
+	// The operation of the caller is not modified.
+	assert.Equal(t, []byte("other"), setOp.Value)
+	getOps := []*storage.Operation{storage.GetOperation("k1"), storage.GetOperation("k2")}
+	require.NoError(t, client.Batch(ctx, getOps...))
+	assert.Nil(t, getOps[0].Value)
+	assert.Equal(t, []byte("other"), getOps[1].Value)
+}
+
+func TestEncryptedClient_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	raw := newMemClient()
+	require.NoError(t, newEncryptedTestClient(t, raw, EncryptionSettings{Key: testKeyA}).Set(ctx, "k1", []byte("old")))
+
+	rotated := newEncryptedTestClient(t, raw, EncryptionSettings{Key: testKeyB, PreviousKeys: []EncryptionKey{testKeyA}})
+	require.NoError(t, rotated.Set(ctx, "k2", []byte("new")))
+	getOps := []*storage.Operation{storage.GetOperation("k1"), storage.GetOperation("k2")}
+	require.NoError(t, rotated.Batch(ctx, getOps...))
+	assert.Equal(t, []byte("old"), getOps[0].Value)
+	assert.Equal(t, []byte("new"), getOps[1].Value)
+
+	// Without the previous key, only the new values can be decrypted.
+	client := newEncryptedTestClient(t, raw, EncryptionSettings{Key: testKeyB})
+	_, err := client.Get(ctx, "k1")
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+	require.ErrorContains(t, err, `unknown encryption key ID "a"`)
+	err = client.Batch(ctx, getOps...)
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+	require.ErrorContains(t, err, `key "k1"`)
+	assert.Nil(t, getOps[0].Value)
+	assert.Equal(t, []byte("new"), getOps[1].Value)
+}
+
+func TestEncryptedClient_InvalidValues(t *testing.T) {
+	ctx := context.Background()
+	raw := newMemClient()
+	client := newEncryptedTestClient(t, raw, EncryptionSettings{Key: testKeyA})
+
+	// The values stored without encryption are rejected.
+	require.NoError(t, raw.Set(ctx, "plain", []byte("plain value")))
+	_, err := client.Get(ctx, "plain")
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+	require.ErrorContains(t, err, "the value is not encrypted")
+
+	// A value moved to another key cannot be decrypted.
+	require.NoError(t, client.Set(ctx, "k1", []byte("value")))
+	stored, err := raw.Get(ctx, "k1")
+	require.NoError(t, err)
+	require.NoError(t, raw.Set(ctx, "k2", stored))
+	_, err = client.Get(ctx, "k2")
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+
+	require.NoError(t, raw.Set(ctx, "k3", stored[:len(encryptedValueMagic)+3]))
+	_, err = client.Get(ctx, "k3")
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+}
+
+func TestEncryptStoredValues(t *testing.T) {
+	ctx := context.Background()
+	raw := newMemClient()
+	metadataBytes, err := proto.Marshal(&PersistentMetadata{ReadIndex: 1, WriteIndex: 3, CurrentlyDispatchedItems: []uint64{0}})
+	require.NoError(t, err)
+	require.NoError(t, raw.Batch(ctx,
+		storage.SetOperation(MetadataKey, metadataBytes),
+		storage.SetOperation(ItemKey(0), []byte("dispatched")),
+		storage.SetOperation(ItemKey(1), []byte("first")),
+		storage.SetOperation(ItemMetadataKey(1), []byte("first metadata")),
+		storage.SetOperation(ItemKey(2), []byte("second")),
+	))
+
+	set := EncryptionSettings{Key: testKeyA}
+	require.NoError(t, EncryptStoredValues(ctx, raw, set, zap.NewNop()))
+	for _, key := range []string{MetadataKey, ItemKey(0), ItemKey(1), ItemMetadataKey(1), ItemKey(2)} {
+		stored, getErr := raw.Get(ctx, key)
+		require.NoError(t, getErr)
+		assert.True(t, bytes.HasPrefix(stored, encryptedValueMagic), key)
+	}
+	client := newEncryptedTestClient(t, raw, set)
+	getOps := []*storage.Operation{
+		storage.GetOperation(MetadataKey),
+		storage.GetOperation(ItemKey(0)),
+		storage.GetOperation(ItemMetadataKey(1)),
+		storage.GetOperation(EnqueueTimeKey(1)),
+	}
+	require.NoError(t, client.Batch(ctx, getOps...))
+	assert.Equal(t, metadataBytes, getOps[0].Value)
+	assert.Equal(t, []byte("dispatched"), getOps[1].Value)
+	assert.Equal(t, []byte("first metadata"), getOps[2].Value)
+	assert.Nil(t, getOps[3].Value)
+
+	// An encrypted queue is not modified.
+	stored, err := raw.Get(ctx, ItemKey(1))
+	require.NoError(t, err)
+	require.NoError(t, EncryptStoredValues(ctx, raw, set, zap.NewNop()))
+	storedAgain, err := raw.Get(ctx, ItemKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, stored, storedAgain)
+}
+
+func TestNewEncryptedClient_InvalidSettings(t *testing.T) {
+	raw := newMemClient()
+	_, err := NewEncryptedClient(raw, EncryptionSettings{Key: EncryptionKey{ID: "short", Key: []byte("short")}})
+	require.ErrorContains(t, err, `invalid encryption key "short"`)
+
+	_, err = NewEncryptedClient(raw, EncryptionSettings{Key: testKeyA, PreviousKeys: []EncryptionKey{testKeyA}})
+	require.ErrorContains(t, err, `duplicate encryption key ID "a"`)
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key, err := ParseEncryptionKey("a", []byte(" AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=\n"))
+	require.NoError(t, err)
+	assert.Equal(t, testKeyA, key)
+
+	_, err = ParseEncryptionKey("a", []byte("not base64"))
+	require.ErrorContains(t, err, `failed to decode the encryption key "a"`)
+	_, err = ParseEncryptionKey("a", []byte("AQID"))
+	require.ErrorContains(t, err, `the encryption key "a" must be 16, 24 or 32 bytes long, found 3`)
+}
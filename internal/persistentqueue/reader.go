@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue // import "go.opentelemetry.io/collector/internal/persistentqueue"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// Item is a request stored by a persistent queue.
+type Item struct {
+	// Index is the position of the item in the queue.
+	Index uint64
+	// Value is the request as encoded by the Encoding configured for the queue, decompressed if needed.
+	Value []byte
+	// EnqueueTime is the time when the item was added to the queue, zero if unknown.
+	EnqueueTime time.Time
+	// Dispatched is true if the item was being processed by a consumer when the queue was stopped.
+	Dispatched bool
+}
+
+// Read calls fn for every item stored by the persistent queue that uses the given storage client.
+// The items that were dispatched when the queue was stopped are returned first, then the remaining items in order.
+// The iteration stops at the first error returned by fn.
+//
+// The storage must not be used by a running queue at the same time.
+func Read(ctx context.Context, client storage.Client, fn func(Item) error) error {
+	metadata, err := loadMetadata(ctx, client)
+	if err != nil {
+		return err
+	}
+	for _, index := range metadata.CurrentlyDispatchedItems {
+		if err = readItem(ctx, client, index, true, fn); err != nil {
+			return err
+		}
+	}
+	for index := metadata.ReadIndex; index != metadata.WriteIndex; index++ {
+		if err = readItem(ctx, client, index, false, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Drain calls fn for every item stored by the persistent queue that uses the given storage client,
+// in the same order as Read, and removes the item from the queue if fn returns no error.
+// The draining stops at the first error returned by fn, the item that failed and all the following ones are kept.
+//
+// The storage must not be used by a running queue at the same time.
+func Drain(ctx context.Context, client storage.Client, fn func(Item) error) error {
+	metadata, err := loadMetadata(ctx, client)
+	if err != nil {
+		return err
+	}
+	for len(metadata.CurrentlyDispatchedItems) > 0 {
+		index := metadata.CurrentlyDispatchedItems[0]
+		if err = readItem(ctx, client, index, true, fn); err != nil {
+			return err
+		}
+		metadata.CurrentlyDispatchedItems = metadata.CurrentlyDispatchedItems[1:]
+		if err = removeItem(ctx, client, metadata, index); err != nil {
+			return err
+		}
+	}
+	for metadata.ReadIndex != metadata.WriteIndex {
+		index := metadata.ReadIndex
+		if err = readItem(ctx, client, index, false, fn); err != nil {
+			return err
+		}
+		metadata.ReadIndex++
+		if err = removeItem(ctx, client, metadata, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadMetadata(ctx context.Context, client storage.Client) (*PersistentMetadata, error) {
+	metadata := &PersistentMetadata{}
+	buf, err := client.Get(ctx, MetadataKey)
+	if err != nil || len(buf) == 0 {
+		return metadata, err
+	}
+	if err = proto.Unmarshal(buf, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// readItem calls fn with the item stored at the given index, missing items are ignored.
+func readItem(ctx context.Context, client storage.Client, index uint64, dispatched bool, fn func(Item) error) error {
+	getOp := storage.GetOperation(ItemKey(index))
+	getTimeOp := storage.GetOperation(EnqueueTimeKey(index))
+	getMetadataOp := storage.GetOperation(ItemMetadataKey(index))
+	if err := client.Batch(ctx, getOp, getTimeOp, getMetadataOp); err != nil {
+		return err
+	}
+	if getOp.Value == nil {
+		return nil
+	}
+	value, err := DecodeValue(getOp.Value, getMetadataOp.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode the item %d: %w", index, err)
+	}
+	item := Item{
+		Index:      index,
+		Value:      value,
+		Dispatched: dispatched,
+	}
+	if enqueueTime, err := BytesToTime(getTimeOp.Value); err == nil {
+		item.EnqueueTime = enqueueTime
+	}
+	return fn(item)
+}
+
+// removeItem deletes the item and persists the already updated metadata.
+func removeItem(ctx context.Context, client storage.Client, metadata *PersistentMetadata, index uint64) error {
+	// The size of the removed item is unknown, the items and bytes sizes are fixed once the queue is fully drained.
+	if metadata.WriteIndex == metadata.ReadIndex && len(metadata.CurrentlyDispatchedItems) == 0 {
+		metadata.ItemsSize = 0
+		metadata.BytesSize = 0
+	}
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return client.Batch(ctx,
+		storage.SetOperation(MetadataKey, metadataBytes),
+		storage.DeleteOperation(ItemKey(index)),
+		storage.DeleteOperation(EnqueueTimeKey(index)),
+		storage.DeleteOperation(ItemMetadataKey(index)))
+}
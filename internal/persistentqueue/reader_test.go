@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestQueue stores 4 items, the item 0 is dispatched, the item 2 is gzip compressed.
+func newTestQueue(t *testing.T) (*memClient, time.Time) {
+	client := newMemClient()
+	ctx := context.Background()
+	enqueueTime := time.Unix(0, 1234)
+	metadata, err := proto.Marshal(&PersistentMetadata{ReadIndex: 1, WriteIndex: 4, CurrentlyDispatchedItems: []uint64{0}, ItemsSize: 4})
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, MetadataKey, metadata))
+	for _, value := range []string{"a", "b", "d"} {
+		index := uint64(value[0] - 'a')
+		require.NoError(t, client.Set(ctx, ItemKey(index), []byte(value)))
+		require.NoError(t, client.Set(ctx, EnqueueTimeKey(index), TimeToBytes(enqueueTime)))
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err = gw.Write([]byte("c"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	itemMetadata, err := proto.Marshal(&PersistentItemMetadata{Compression: "gzip"})
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, ItemKey(2), buf.Bytes()))
+	require.NoError(t, client.Set(ctx, ItemMetadataKey(2), itemMetadata))
+	return client, enqueueTime
+}
+
+func TestRead(t *testing.T) {
+	client, enqueueTime := newTestQueue(t)
+	var items []Item
+	require.NoError(t, Read(context.Background(), client, func(item Item) error {
+		items = append(items, item)
+		return nil
+	}))
+	assert.Equal(t, []Item{
+		{Index: 0, Value: []byte("a"), EnqueueTime: enqueueTime, Dispatched: true},
+		{Index: 1, Value: []byte("b"), EnqueueTime: enqueueTime},
+		{Index: 2, Value: []byte("c")},
+		{Index: 3, Value: []byte("d"), EnqueueTime: enqueueTime},
+	}, items)
+
+	errStop := errors.New("stop")
+	require.ErrorIs(t, Read(context.Background(), client, func(Item) error { return errStop }), errStop)
+}
+
+func TestRead_Empty(t *testing.T) {
+	require.NoError(t, Read(context.Background(), newMemClient(), func(Item) error {
+		return errors.New("unexpected item")
+	}))
+}
+
+func TestDrain(t *testing.T) {
+	client, _ := newTestQueue(t)
+	errStop := errors.New("stop")
+	var drained []uint64
+	require.ErrorIs(t, Drain(context.Background(), client, func(item Item) error {
+		if item.Index == 2 {
+			return errStop
+		}
+		drained = append(drained, item.Index)
+		return nil
+	}), errStop)
+	assert.Equal(t, []uint64{0, 1}, drained)
+
+	// The item that failed and the following ones are kept.
+	drained = nil
+	require.NoError(t, Drain(context.Background(), client, func(item Item) error {
+		drained = append(drained, item.Index)
+		return nil
+	}))
+	assert.Equal(t, []uint64{2, 3}, drained)
+
+	metadata, err := loadMetadata(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), metadata.ReadIndex)
+	assert.Empty(t, metadata.CurrentlyDispatchedItems)
+	assert.Zero(t, metadata.ItemsSize)
+	value, err := client.Get(context.Background(), ItemKey(3))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
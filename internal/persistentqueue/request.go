@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue // import "go.opentelemetry.io/collector/internal/persistentqueue"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	pdatareq "go.opentelemetry.io/collector/pdata/xpdata/request"
+)
+
+// UnmarshalTraces decodes the value of an Item stored by a traces exporter created with exporterhelper.NewTraces.
+func UnmarshalTraces(buf []byte) (context.Context, ptrace.Traces, error) {
+	ctx, td, err := pdatareq.UnmarshalTraces(buf)
+	if errors.Is(err, pdatareq.ErrInvalidFormat) {
+		// Stored without the request context.
+		td, err = (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+		return context.Background(), td, err
+	}
+	return ctx, td, err
+}
+
+// UnmarshalMetrics decodes the value of an Item stored by a metrics exporter created with exporterhelper.NewMetrics.
+func UnmarshalMetrics(buf []byte) (context.Context, pmetric.Metrics, error) {
+	ctx, md, err := pdatareq.UnmarshalMetrics(buf)
+	if errors.Is(err, pdatareq.ErrInvalidFormat) {
+		// Stored without the request context.
+		md, err = (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+		return context.Background(), md, err
+	}
+	return ctx, md, err
+}
+
+// UnmarshalLogs decodes the value of an Item stored by a logs exporter created with exporterhelper.NewLogs.
+func UnmarshalLogs(buf []byte) (context.Context, plog.Logs, error) {
+	ctx, ld, err := pdatareq.UnmarshalLogs(buf)
+	if errors.Is(err, pdatareq.ErrInvalidFormat) {
+		// Stored without the request context.
+		ld, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(buf)
+		return context.Background(), ld, err
+	}
+	return ctx, ld, err
+}
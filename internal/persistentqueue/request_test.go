@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	pdatareq "go.opentelemetry.io/collector/pdata/xpdata/request"
+)
+
+func newMetadataContext() context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"a"}}),
+	})
+}
+
+func TestUnmarshalTraces(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	_, got, err := UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	buf, err = pdatareq.MarshalTraces(newMetadataContext(), td)
+	require.NoError(t, err)
+	ctx, got, err := UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+	assert.Equal(t, []string{"a"}, client.FromContext(ctx).Metadata.Get("tenant"))
+}
+
+func TestUnmarshalMetrics(t *testing.T) {
+	md := testdata.GenerateMetrics(2)
+	buf, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	_, got, err := UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+
+	buf, err = pdatareq.MarshalMetrics(newMetadataContext(), md)
+	require.NoError(t, err)
+	ctx, got, err := UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+	assert.Equal(t, []string{"a"}, client.FromContext(ctx).Metadata.Get("tenant"))
+}
+
+func TestUnmarshalLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(2)
+	buf, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	_, got, err := UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+
+	buf, err = pdatareq.MarshalLogs(newMetadataContext(), ld)
+	require.NoError(t, err)
+	ctx, got, err := UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+	assert.Equal(t, []string{"a"}, client.FromContext(ctx).Metadata.Get("tenant"))
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package persistentqueue defines the storage format of the persistent sending queues of the exporters,
+// shared by the exporter helper writing the queues and the tools inspecting them.
+package persistentqueue // import "go.opentelemetry.io/collector/internal/persistentqueue"
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	// MetadataKey is the key of the PersistentMetadata of a queue.
+	MetadataKey = "qmv0"
+
+	// enqueueTimeKeyPrefix is the prefix of the keys that record when an item was added to the queue.
+	enqueueTimeKeyPrefix = "t"
+
+	// itemMetadataKeyPrefix is the prefix of the keys that hold the PersistentItemMetadata of an item.
+	itemMetadataKeyPrefix = "m"
+
+	// deadLetterStorageSuffix is appended to the signal name to obtain the storage client name used for dead
+	// letters. This guarantees that dead letters never share keys with a persistent sending queue configured
+	// with the same storage extension.
+	deadLetterStorageSuffix = "_dead_letter"
+
+	// laneStorageInfix is used to obtain the storage client name of a persistent lane.
+	laneStorageInfix = "_lane_"
+)
+
+var (
+	// ErrValueNotSet is returned when a stored value is missing.
+	ErrValueNotSet = errors.New("value not set")
+	// ErrInvalidValue is returned when a stored value cannot be decoded.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// StorageName returns the name of the storage client used by the persistent queue for the given signal.
+func StorageName(signal pipeline.Signal) string {
+	return signal.String()
+}
+
+// LaneStorageName returns the name of the storage client used by a persistent lane of a priority queue
+// for the given signal. The default lane uses StorageName.
+func LaneStorageName(signal pipeline.Signal, lane string) string {
+	return signal.String() + laneStorageInfix + lane
+}
+
+// DeadLetterStorageName returns the name of the storage client used to persist the dead letters for the given signal.
+func DeadLetterStorageName(signal pipeline.Signal) string {
+	return signal.String() + deadLetterStorageSuffix
+}
+
+// ItemKey returns the key of the item stored at the given index.
+func ItemKey(index uint64) string {
+	return strconv.FormatUint(index, 10)
+}
+
+// EnqueueTimeKey returns the key of the time when the item stored at the given index was added to the queue.
+func EnqueueTimeKey(index uint64) string {
+	return enqueueTimeKeyPrefix + strconv.FormatUint(index, 10)
+}
+
+// ItemMetadataKey returns the key of the PersistentItemMetadata of the item stored at the given index.
+func ItemMetadataKey(index uint64) string {
+	return itemMetadataKeyPrefix + strconv.FormatUint(index, 10)
+}
+
+// TimeToBytes encodes a time as stored by the queue.
+func TimeToBytes(t time.Time) []byte {
+	return binary.LittleEndian.AppendUint64(nil, uint64(t.UnixNano())) // #nosec G115
+}
+
+// BytesToTime decodes a time encoded by TimeToBytes.
+func BytesToTime(buf []byte) (time.Time, error) {
+	if buf == nil {
+		return time.Time{}, ErrValueNotSet
+	}
+	// The sizeof uint64 in binary is 8.
+	if len(buf) < 8 {
+		return time.Time{}, ErrInvalidValue
+	}
+	return time.Unix(0, int64(binary.LittleEndian.Uint64(buf))), nil // #nosec G115
+}
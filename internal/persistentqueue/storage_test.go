@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+// memClient is an in-memory storage.Client.
+type memClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemClient() *memClient {
+	return &memClient{data: map[string][]byte{}}
+}
+
+func (c *memClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *memClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *memClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *memClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *memClient) Close(context.Context) error {
+	return nil
+}
+
+func TestStorageNames(t *testing.T) {
+	assert.Equal(t, "traces", StorageName(pipeline.SignalTraces))
+	assert.Equal(t, "logs_lane_high", LaneStorageName(pipeline.SignalLogs, "high"))
+	assert.Equal(t, "profiles_dead_letter", DeadLetterStorageName(xpipeline.SignalProfiles))
+}
+
+func TestBytesToTime(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano())
+	got, err := BytesToTime(TimeToBytes(now))
+	require.NoError(t, err)
+	assert.True(t, now.Equal(got))
+
+	_, err = BytesToTime(nil)
+	require.ErrorIs(t, err, ErrValueNotSet)
+	_, err = BytesToTime([]byte{1})
+	require.ErrorIs(t, err, ErrInvalidValue)
+}
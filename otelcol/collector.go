@@ -20,9 +20,11 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
+	"go.opentelemetry.io/collector/otelcol/internal/metadata"
 	"go.opentelemetry.io/collector/service"
 )
 
@@ -83,6 +85,16 @@ type CollectorSettings struct {
 
 	// SkipSettingGRPCLogger avoids setting the grpc logger
 	SkipSettingGRPCLogger bool
+
+	// LastKnownGoodConfigFile is the path of the file where the last config which started successfully is
+	// persisted, if set. The Collector starts with it when its config fails to start. The file contains the
+	// resolved config, including its secrets, and is only readable by its owner.
+	LastKnownGoodConfigFile string
+
+	// ReportConfigStatus is called, if set, with a componentstatus.StatusOK event when a config is applied, and
+	// a componentstatus.StatusRecoverableError event when a config fails and the Collector rolls back to the
+	// last-known-good config.
+	ReportConfigStatus func(*componentstatus.Event)
 }
 
 // (Internal note) Collector Lifecycle:
@@ -93,6 +105,9 @@ type CollectorSettings struct {
 //   Collector can be shutdown if parser gets a shutdown error.
 // - Run runs runAndWaitForShutdownEvent and waits for a shutdown event.
 //   SIGINT and SIGTERM, errors, and (*Collector).Shutdown can trigger the shutdown events.
+// - Upon config change or SIGHUP, reloadConfiguration reloads the components affected by the changes,
+//   or restarts the service when the changes cannot be reloaded.
+//   If the new config fails, the collector rolls back to the last-known-good config.
 // - Upon shutdown, pipelines are notified, then pipelines and extensions are shut down.
 // - Users can call (*Collector).Shutdown anytime to shut down the collector.
 
@@ -107,6 +122,11 @@ type Collector struct {
 	service       *service.Service
 	state         *atomic.Int64
 
+	// lastKnownGood is the last config which started successfully, the Collector rolls back to it when a new
+	// config fails.
+	lastKnownGood    *loadedConfig
+	telemetryBuilder *metadata.TelemetryBuilder
+
 	// shutdownChan is used to terminate the collector.
 	shutdownChan chan struct{}
 	shutdownOnce sync.Once
@@ -176,30 +196,87 @@ func buildModuleInfo(m map[component.Type]string) map[component.Type]service.Mod
 }
 
 // setupConfigurationComponents loads the config, creates the graph, and starts the components. If all the steps succeeds it
-// sets the col.service with the service currently running.
+// sets the col.service with the service currently running. If they fail, the collector starts with the last-known-good
+// config persisted in the CollectorSettings.LastKnownGoodConfigFile, if any.
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	loaded, err := col.loadConfig(ctx)
+	if err == nil {
+		if err = col.startService(ctx, loaded); err == nil {
+			col.configApplied(loaded)
+			return nil
+		}
+	}
+
+	lastKnownGood, lkgErr := col.loadLastKnownGoodConfig()
+	if lkgErr != nil {
+		if !errors.Is(lkgErr, errNoLastKnownGoodConfig) {
+			err = multierr.Append(err, lkgErr)
+		}
+		return err
+	}
+	col.lastKnownGood = lastKnownGood
+	return col.rollback(ctx, err)
+}
+
+// loadedConfig is a config loaded and validated, with the settings to create the service from it.
+type loadedConfig struct {
+	set service.Settings
+	cfg *Config
+	// conf is the resolved configuration map the config is unmarshaled from.
+	conf *confmap.Conf
+}
+
+// loadConfig loads and validates the config.
+func (col *Collector) loadConfig(ctx context.Context) (*loadedConfig, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 
-	cfg, err := col.configProvider.Get(ctx, factories)
+	cfg, conf, err := col.configProvider.get(ctx, factories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	return col.newLoadedConfig(factories, cfg, conf)
+}
+
+// loadLastKnownGoodConfig loads and validates the last-known-good config persisted in the
+// CollectorSettings.LastKnownGoodConfigFile.
+func (col *Collector) loadLastKnownGoodConfig() (*loadedConfig, error) {
+	if col.set.LastKnownGoodConfigFile == "" {
+		return nil, errNoLastKnownGoodConfig
+	}
+
+	factories, err := col.set.Factories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+
+	conf, err := readLastKnownGoodConfig(col.set.LastKnownGoodConfigFile)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return nil, err
 	}
 
-	if err = xconfmap.Validate(cfg); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+	cfg, err := newConfig(conf, factories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last-known-good config: %w", err)
 	}
 
-	col.serviceConfig = &cfg.Service
+	return col.newLoadedConfig(factories, cfg, conf)
+}
+
+func (col *Collector) newLoadedConfig(factories Factories, cfg *Config, resolved *confmap.Conf) (*loadedConfig, error) {
+	if err := xconfmap.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
 
 	conf := confmap.New()
 
-	if err = conf.Marshal(cfg); err != nil {
-		return fmt.Errorf("could not marshal configuration: %w", err)
+	if err := conf.Marshal(cfg); err != nil {
+		return nil, fmt.Errorf("could not marshal configuration: %w", err)
 	}
 
 	// Wrap the buildZapLogger to append LoggingOptions from collector settings,
@@ -213,7 +290,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		}
 	}
 
-	col.service, err = service.New(ctx, service.Settings{
+	return &loadedConfig{set: service.Settings{
 		BuildInfo:     col.set.BuildInfo,
 		CollectorConf: conf,
 
@@ -238,10 +315,21 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		AsyncErrorChannel: col.asyncErrorChannel,
 		BuildZapLogger:    buildZapLogger,
 		TelemetryFactory:  factories.Telemetry,
-	}, cfg.Service)
+	}, cfg: cfg, conf: resolved}, nil
+}
+
+// startService creates the service from the loaded config, and starts it.
+func (col *Collector) startService(ctx context.Context, loaded *loadedConfig) error {
+	col.serviceConfig = &loaded.cfg.Service
+
+	var err error
+	col.service, err = service.New(ctx, loaded.set, loaded.cfg.Service)
 	if err != nil {
 		return err
 	}
+	if col.telemetryBuilder, err = metadata.NewTelemetryBuilder(col.service.TelemetrySettings()); err != nil {
+		return multierr.Combine(err, col.service.Shutdown(ctx))
+	}
 	if col.updateConfigProviderLogger != nil {
 		col.updateConfigProviderLogger(col.service.Logger().Core())
 	}
@@ -267,38 +355,125 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	return nil
 }
 
+// reloadConfiguration applies the updated config to the running service, only restarting the components affected
+// by the changes. The service is restarted when the changes cannot be applied to it, e.g. the changes of the
+// telemetry configuration. If the updated config fails, the collector rolls back to the last-known-good config.
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
-	col.service.Logger().Warn("Config updated, restart service")
-	col.setCollectorState(StateClosing)
+	col.service.Logger().Warn("Config updated, reload service")
 
-	if err := col.service.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
+	loaded, err := col.loadConfig(ctx)
+	if err != nil {
+		// The running service is left unchanged.
+		col.configFailed(fmt.Errorf("failed to setup configuration components: %w", err))
+		return nil
 	}
 
-	if err := col.setupConfigurationComponents(ctx); err != nil {
-		return fmt.Errorf("failed to setup configuration components: %w", err)
+	err = col.service.Reload(ctx, loaded.set, loaded.cfg.Service)
+	switch {
+	case err == nil:
+		col.serviceConfig = &loaded.cfg.Service
+		col.configApplied(loaded)
+		return nil
+	case errors.Is(err, service.ErrRestartRequired):
+		col.service.Logger().Warn("Config changes cannot be reloaded, restart service")
+		err = nil
+	default:
+		err = fmt.Errorf("failed to reload the configuration: %w", err)
 	}
 
+	col.setCollectorState(StateClosing)
+	if shutdownErr := col.service.Shutdown(ctx); shutdownErr != nil {
+		return multierr.Append(err, fmt.Errorf("failed to shutdown the retiring config: %w", shutdownErr))
+	}
+
+	col.setCollectorState(StateStarting)
+	if err == nil {
+		if err = col.startService(ctx, loaded); err == nil {
+			col.configApplied(loaded)
+			return nil
+		}
+		err = fmt.Errorf("failed to setup configuration components: %w", err)
+	}
+	return col.rollback(ctx, err)
+}
+
+// rollback starts the service with the last-known-good config, after the given error applying a new config. The
+// previous service must be shut down.
+func (col *Collector) rollback(ctx context.Context, err error) error {
+	if startErr := col.startService(ctx, col.lastKnownGood); startErr != nil {
+		return multierr.Append(err, fmt.Errorf("failed to roll back to the last-known-good config: %w", startErr))
+	}
+	col.configFailed(err)
 	return nil
 }
 
+// configApplied records the config applied successfully as the last-known-good config.
+func (col *Collector) configApplied(loaded *loadedConfig) {
+	col.lastKnownGood = loaded
+	col.reportConfigStatus(componentstatus.NewEvent(componentstatus.StatusOK))
+	if col.set.LastKnownGoodConfigFile == "" {
+		return
+	}
+	if err := writeLastKnownGoodConfig(col.set.LastKnownGoodConfigFile, loaded.conf); err != nil {
+		col.service.Logger().Warn("Failed to persist the last-known-good config", zap.Error(err))
+	}
+}
+
+// configFailed reports the error applying a new config, the collector running the last-known-good config.
+func (col *Collector) configFailed(err error) {
+	col.service.Logger().Error("Failed to apply the config, running the last-known-good config", zap.Error(err))
+	col.telemetryBuilder.ConfigReloadFailed.Add(context.Background(), 1)
+	col.reportConfigStatus(componentstatus.NewRecoverableErrorEvent(err))
+}
+
+func (col *Collector) reportConfigStatus(event *componentstatus.Event) {
+	if col.set.ReportConfigStatus != nil {
+		col.set.ReportConfigStatus(event)
+	}
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
+	set, cfg, err := col.dryRunSettings(ctx)
+	if err != nil {
+		return err
+	}
+	return service.Validate(ctx, set, cfg)
+}
+
+// dryRunSettings gets and validates the configuration, and returns the settings and the configuration to build
+// the pipelines of the service without running them.
+func (col *Collector) dryRunSettings(ctx context.Context) (service.Settings, service.Config, error) {
+	factories, cfg, err := col.validConfig(ctx)
+	if err != nil {
+		return service.Settings{}, service.Config{}, err
+	}
+	set, srvCfg := dryRunServiceSettings(col.set.BuildInfo, factories, cfg)
+	return set, srvCfg, nil
+}
+
+// validConfig gets and validates the configuration, and returns it with the factories of its components.
+func (col *Collector) validConfig(ctx context.Context) (Factories, *Config, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return Factories{}, nil, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 
 	cfg, err := col.configProvider.Get(ctx, factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return Factories{}, nil, fmt.Errorf("failed to get config: %w", err)
 	}
 
 	if err := xconfmap.Validate(cfg); err != nil {
-		return err
+		return Factories{}, nil, err
 	}
+	return factories, cfg, nil
+}
 
-	return service.Validate(ctx, service.Settings{
-		BuildInfo:           col.set.BuildInfo,
+// dryRunServiceSettings returns the settings and the configuration to build the pipelines of the service without
+// running them.
+func dryRunServiceSettings(buildInfo component.BuildInfo, factories Factories, cfg *Config) (service.Settings, service.Config) {
+	return service.Settings{
+		BuildInfo:           buildInfo,
 		ReceiversConfigs:    cfg.Receivers,
 		ReceiversFactories:  factories.Receivers,
 		ProcessorsConfigs:   cfg.Processors,
@@ -307,10 +482,10 @@ func (col *Collector) DryRun(ctx context.Context) error {
 		ExportersFactories:  factories.Exporters,
 		ConnectorsConfigs:   cfg.Connectors,
 		ConnectorsFactories: factories.Connectors,
+		ExtensionsConfigs:   cfg.Extensions,
+		ExtensionsFactories: factories.Extensions,
 		TelemetryFactory:    factories.Telemetry,
-	}, service.Config{
-		Pipelines: cfg.Service.Pipelines,
-	})
+	}, cfg.Service
 }
 
 func newFallbackLogger(options []zap.Option) (*zap.Logger, error) {
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -81,9 +82,13 @@ func TestCollectorCancelContext(t *testing.T) {
 
 func TestCollectorStateAfterConfigChange(t *testing.T) {
 	var watcher confmap.WatcherFunc
+	generation := 0
 	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
 		watcher = w
 		conf := newConfFromFile(t, uri[5:])
+		// Change the telemetry configuration on every reload, which requires a restart of the service.
+		generation++
+		conf["service"].(map[string]any)["telemetry"] = map[string]any{"generation": generation}
 		return confmap.NewRetrieved(conf)
 	})
 
@@ -127,7 +132,7 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 		return StateRunning == col.GetState()
 	}, 10*time.Second, 10*time.Millisecond)
 
-	// On config change, the collector will internally close
+	// On telemetry config change, the collector will internally close
 	// and recreate the service. The metrics reader will try to
 	// push to the OTLP endpoint. We block the request to check
 	// the state of the collector during the config change event.
@@ -154,6 +159,56 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+func TestCollectorReloadWithoutRestart(t *testing.T) {
+	var watcher confmap.WatcherFunc
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		return confmap.NewRetrieved(newConfFromFile(t, uri[5:]))
+	})
+
+	var loggerShutdowns atomic.Int32
+	core, logs := observer.New(zapcore.InfoLevel)
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Telemetry = telemetry.NewFactory(
+		func() component.Config { return fakeTelemetryConfig{} },
+		telemetrytest.WithLogger(zap.New(core), func(context.Context) error {
+			loggerShutdowns.Add(1)
+			return nil
+		}),
+	)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{filepath.Join("testdata", "otelcol-nop.yaml")},
+				ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+	srv := col.service
+
+	// The telemetry configuration is unchanged, the running service is reloaded.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return logs.FilterMessageSnippet("Configuration reloaded").Len() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, StateRunning, col.GetState())
+	col.Shutdown()
+	wg.Wait()
+	assert.Same(t, srv, col.service)
+	assert.Equal(t, int32(1), loggerShutdowns.Load())
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
 func TestCollectorReportError(t *testing.T) {
 	col, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
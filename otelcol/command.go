@@ -45,6 +45,10 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
 	rootCmd.AddCommand(newConfigPrintSubCommand(set, flagSet))
+	rootCmd.AddCommand(newGraphSubCommand(set, flagSet))
+	rootCmd.AddCommand(newDiffSubCommand(set, flagSet))
+	rootCmd.AddCommand(newQueueSubCommand(set, flagSet))
+	rootCmd.AddCommand(newZstdDictionarySubCommand())
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -61,6 +65,10 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 		return errors.New("at least one config flag must be provided")
 	}
 
+	if lastKnownGoodConfig := getLastKnownGoodConfigFlag(flags); lastKnownGoodConfig != "" {
+		set.LastKnownGoodConfigFile = lastKnownGoodConfig
+	}
+
 	if set.ConfigProviderSettings.ResolverSettings.DefaultScheme == "" {
 		set.ConfigProviderSettings.ResolverSettings.DefaultScheme = "env"
 	}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newDiffSubCommand constructs a new diff sub command using the given CollectorSettings.
+func newDiffSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var newConfigs []string
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Prints the effective differences between the config and a new config",
+		Long: `Prints the effective differences between the config set with --config and the new config set with
+--new-config, and the components of each pipeline which would be restarted to apply the new config.
+
+Both configs are resolved with the same providers and converters, and the --set flags apply to both of them.
+The default configuration of the components is applied, so that only the changes of the effective
+configuration are printed. The values of the sensitive settings are redacted.
+
+The components are restarted as when the config of a running collector changes: the components whose
+configuration or connections change are restarted with the components sending data to them. All the
+components are restarted when an extension is changed or removed, and the collector restarts when the
+telemetry configuration changes.
+
+This command is experimental, the output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(newConfigs) == 0 {
+				return errors.New("at least one new-config flag must be provided")
+			}
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			newSet := set
+			newSet.ConfigProviderSettings.ResolverSettings.URIs = slices.Concat(newConfigs, flagSet.Lookup(configFlag).Value.(*configFlagValue).sets)
+
+			prevSet, prevCfg, err := loadDiffConfig(cmd.Context(), set)
+			if err != nil {
+				return fmt.Errorf("failed to load the config: %w", err)
+			}
+			nextSet, nextCfg, err := loadDiffConfig(cmd.Context(), newSet)
+			if err != nil {
+				return fmt.Errorf("failed to load the new config: %w", err)
+			}
+			plan, err := service.PlanReload(cmd.Context(), prevSet, prevCfg.Service, nextSet, nextCfg.Service)
+			if err != nil {
+				return err
+			}
+			return writeConfigDiff(cmd.OutOrStdout(), prevCfg, nextCfg, plan)
+		},
+	}
+	cmd.Flags().StringArrayVar(&newConfigs, "new-config", nil, "Locations to the new config file(s), note that only a"+
+		" single location can be set per flag entry e.g. `--new-config=file:/path/to/first --new-config=file:path/to/second`.")
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
+
+// loadDiffConfig gets and validates the configuration of the settings, and returns it with the settings to build
+// its pipelines.
+func loadDiffConfig(ctx context.Context, set CollectorSettings) (service.Settings, *Config, error) {
+	col, err := NewCollector(set)
+	if err != nil {
+		return service.Settings{}, nil, err
+	}
+	factories, cfg, err := col.validConfig(ctx)
+	if err != nil {
+		return service.Settings{}, nil, err
+	}
+	srvSet, _ := dryRunServiceSettings(set.BuildInfo, factories, cfg)
+	return srvSet, cfg, nil
+}
+
+// writeConfigDiff prints the differences of the configs, by section, and the components restarted by the plan.
+func writeConfigDiff(w io.Writer, prev, next *Config, plan *service.ReloadPlan) error {
+	var sb strings.Builder
+	writeComponentsDiff(&sb, "Extensions", prev.Extensions, next.Extensions)
+	writeComponentsDiff(&sb, "Receivers", prev.Receivers, next.Receivers)
+	writeComponentsDiff(&sb, "Processors", prev.Processors, next.Processors)
+	writeComponentsDiff(&sb, "Exporters", prev.Exporters, next.Exporters)
+	writeComponentsDiff(&sb, "Connectors", prev.Connectors, next.Connectors)
+	writeServiceDiff(&sb, prev.Service, next.Service)
+	writePipelinesDiff(&sb, prev.Service.Pipelines, next.Service.Pipelines)
+
+	if sb.Len() == 0 {
+		_, err := io.WriteString(w, "No differences.\n")
+		return err
+	}
+
+	switch {
+	case plan.RestartRequired:
+		sb.WriteString("The collector restarts with all its components to apply the telemetry changes.\n")
+	case len(plan.Rebuilt) == 0:
+		sb.WriteString("No pipeline is restarted.\n")
+	default:
+		sb.WriteString("Restarted pipeline components:\n")
+		pipelineIDs := slices.SortedFunc(maps.Keys(plan.Rebuilt), func(a, b pipeline.ID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, pipelineID := range pipelineIDs {
+			labels := make([]string, 0, len(plan.Rebuilt[pipelineID]))
+			for _, node := range plan.Rebuilt[pipelineID] {
+				labels = append(labels, topologyNodeLabel(node))
+			}
+			fmt.Fprintf(&sb, "  %s: %s\n", pipelineID, strings.Join(labels, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeComponentsDiff prints the components added, removed and changed, with the settings changed.
+func writeComponentsDiff(sb *strings.Builder, title string, prev, next map[component.ID]component.Config) {
+	ids := slices.Collect(maps.Keys(prev))
+	for id := range next {
+		if _, ok := prev[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b component.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var lines []string
+	for _, id := range ids {
+		prevCfg, inPrev := prev[id]
+		nextCfg, inNext := next[id]
+		switch {
+		case !inPrev:
+			lines = append(lines, "  + "+id.String())
+		case !inNext:
+			lines = append(lines, "  - "+id.String())
+		case !reflect.DeepEqual(prevCfg, nextCfg):
+			lines = append(lines, "  ~ "+id.String())
+			lines = append(lines, settingsDiff("      ", prevCfg, nextCfg)...)
+		}
+	}
+	writeSection(sb, title, lines)
+}
+
+// writeServiceDiff prints the changes of the service settings, except the pipelines.
+func writeServiceDiff(sb *strings.Builder, prev, next service.Config) {
+	prev.Pipelines, next.Pipelines = nil, nil
+	if reflect.DeepEqual(prev, next) {
+		return
+	}
+	writeSection(sb, "Service", settingsDiff("  ", prev, next))
+}
+
+// writePipelinesDiff prints the pipelines added, removed and changed, with the components lists changed.
+func writePipelinesDiff(sb *strings.Builder, prev, next pipelines.Config) {
+	ids := slices.Collect(maps.Keys(prev))
+	for id := range next {
+		if _, ok := prev[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b pipeline.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var lines []string
+	for _, id := range ids {
+		prevPipe, inPrev := prev[id]
+		nextPipe, inNext := next[id]
+		switch {
+		case !inPrev:
+			lines = append(lines, "  + "+id.String())
+		case !inNext:
+			lines = append(lines, "  - "+id.String())
+		case !reflect.DeepEqual(prevPipe, nextPipe):
+			lines = append(lines, "  ~ "+id.String())
+			for _, list := range []struct {
+				name       string
+				prev, next []component.ID
+			}{
+				{name: "receivers", prev: prevPipe.Receivers, next: nextPipe.Receivers},
+				{name: "processors", prev: prevPipe.Processors, next: nextPipe.Processors},
+				{name: "exporters", prev: prevPipe.Exporters, next: nextPipe.Exporters},
+			} {
+				if !slices.Equal(list.prev, list.next) {
+					lines = append(lines, fmt.Sprintf("      %s: %s -> %s", list.name, formatIDs(list.prev), formatIDs(list.next)))
+				}
+			}
+		}
+	}
+	writeSection(sb, "Pipelines", lines)
+}
+
+func formatIDs(ids []component.ID) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, id.String())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func writeSection(sb *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	sb.WriteString(title + ":\n")
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+}
+
+// settingsDiff returns an indented line per setting changed between the configurations, using the "::" separated
+// path of the setting, or a single line if the changes are not visible once the configurations are marshaled,
+// e.g. the changes of sensitive settings.
+func settingsDiff(indent string, prev, next any) []string {
+	prevSettings, nextSettings := flattenSettings(prev), flattenSettings(next)
+	keys := slices.Collect(maps.Keys(prevSettings))
+	for key := range nextSettings {
+		if _, ok := prevSettings[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var lines []string
+	for _, key := range keys {
+		prevValue, inPrev := prevSettings[key]
+		nextValue, inNext := nextSettings[key]
+		if !inPrev {
+			prevValue = "(none)"
+		}
+		if !inNext {
+			nextValue = "(none)"
+		}
+		if prevValue != nextValue {
+			lines = append(lines, fmt.Sprintf("%s%s: %s -> %s", indent, key, prevValue, nextValue))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, indent+"(changes of redacted settings)")
+	}
+	return lines
+}
+
+// flattenSettings marshals the configuration, and returns its settings by "::" separated path, formatted in JSON.
+func flattenSettings(cfg any) map[string]string {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return nil
+	}
+	settings := make(map[string]string)
+	var flatten func(prefix string, value any)
+	flatten = func(prefix string, value any) {
+		if m, ok := value.(map[string]any); ok && len(m) > 0 {
+			for key, v := range m {
+				if prefix != "" {
+					key = prefix + confmap.KeyDelimiter + key
+				}
+				flatten(key, v)
+			}
+			return
+		}
+		if d, ok := value.(time.Duration); ok {
+			// The durations are kept as is by the marshaling, they are printed as they are configured.
+			value = d.String()
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			b = fmt.Appendf(nil, "%v", value)
+		}
+		settings[prefix] = string(b)
+	}
+	flatten("", conf.ToStringMap())
+	return settings
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service"
+)
+
+// newDiffTestSettings returns the settings of a collector reading the config files from the testdata directory.
+func newDiffTestSettings(t *testing.T) CollectorSettings {
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		return confmap.NewRetrieved(newConfFromFile(t, filepath.Join("testdata", strings.TrimPrefix(uri, "file:"))))
+	})
+	return CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			DefaultScheme:     "file",
+		},
+	}}
+}
+
+func TestDiffSubCommand(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		newConfig string
+		expected  string
+	}{
+		{name: "changed", newConfig: "otelcol-diff.yaml", expected: "diff.txt"},
+		{name: "unchanged", newConfig: "otelcol-nop.yaml", expected: "diff_unchanged.txt"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newDiffSubCommand(newDiffTestSettings(t), flags(featuregate.GlobalRegistry()))
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--config", "file:otelcol-nop.yaml", "--new-config", "file:" + tt.newConfig})
+			require.NoError(t, cmd.Execute())
+
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.expected))
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func TestDiffSubCommandErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "no new config",
+			args:     []string{"--config", "file:otelcol-nop.yaml"},
+			expected: "at least one new-config flag must be provided",
+		},
+		{
+			name:     "no config",
+			args:     []string{"--new-config", "file:otelcol-nop.yaml"},
+			expected: "at least one config flag must be provided",
+		},
+		{
+			name:     "invalid config",
+			args:     []string{"--config", "file:otelcol-invalid-components.yaml", "--new-config", "file:otelcol-nop.yaml"},
+			expected: "failed to load the config",
+		},
+		{
+			name:     "invalid new config",
+			args:     []string{"--config", "file:otelcol-nop.yaml", "--new-config", "file:otelcol-invalid.yaml"},
+			expected: "failed to load the new config",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newDiffSubCommand(newDiffTestSettings(t), flags(featuregate.GlobalRegistry()))
+			cmd.SetArgs(tt.args)
+			require.ErrorContains(t, cmd.Execute(), tt.expected)
+		})
+	}
+}
+
+func TestWriteConfigDiffRestartRequired(t *testing.T) {
+	prev := &Config{Service: service.Config{Telemetry: fakeTelemetryConfig{}}}
+	next := &Config{Service: service.Config{Telemetry: fakeTelemetryConfig{Generation: 1}}}
+	out := &bytes.Buffer{}
+	require.NoError(t, writeConfigDiff(out, prev, next, &service.ReloadPlan{RestartRequired: true}))
+	assert.Equal(t, `Service:
+  telemetry::generation: 0 -> 1
+The collector restarts with all its components to apply the telemetry changes.
+`, out.String())
+}
+
+func TestSettingsDiff(t *testing.T) {
+	type settings struct {
+		Endpoint string              `mapstructure:"endpoint"`
+		Headers  map[string]string   `mapstructure:"headers"`
+		Token    configopaque.String `mapstructure:"token"`
+	}
+
+	assert.Equal(t, []string{
+		`  endpoint: "localhost:4317" -> "0.0.0.0:4317"`,
+		`  headers::a: "b" -> (none)`,
+		`  headers::c: (none) -> "d"`,
+	}, settingsDiff("  ",
+		&settings{Endpoint: "localhost:4317", Headers: map[string]string{"a": "b"}},
+		&settings{Endpoint: "0.0.0.0:4317", Headers: map[string]string{"c": "d"}},
+	))
+
+	// The changes of the sensitive settings are not printed.
+	assert.Equal(t, []string{"  (changes of redacted settings)"}, settingsDiff("  ",
+		&settings{Token: "secret"},
+		&settings{Token: "other secret"},
+	))
+}
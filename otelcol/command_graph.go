@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/service"
+)
+
+// newGraphSubCommand constructs a new graph sub command using the given CollectorSettings.
+func newGraphSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Prints the graph of the pipeline components without running the collector",
+		Long: `Prints the graph of the pipeline components built from the config, without starting them.
+
+The graph contains a node per receiver and exporter signal, per connector pair of signals and per pipeline
+processor, and the capabilities and fanout nodes of each pipeline. Each edge is labeled with its signal.
+
+The output is printed in DOT by default. To print Mermaid use --format=mermaid, and JSON use --format=json.
+
+This command is experimental, the output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			write, ok := topologyWriters[strings.ToLower(format)]
+			if !ok {
+				return fmt.Errorf("invalid format %q: formats are: dot, mermaid, json", format)
+			}
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			srvSet, srvCfg, err := col.dryRunSettings(cmd.Context())
+			if err != nil {
+				return err
+			}
+			topology, err := service.BuildTopology(cmd.Context(), srvSet, srvCfg)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), topology)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "dot", "Output format: dot (default), mermaid, json")
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
+
+var topologyWriters = map[string]func(io.Writer, *service.Topology) error{
+	"dot":     writeTopologyDOT,
+	"mermaid": writeTopologyMermaid,
+	"json":    writeTopologyJSON,
+}
+
+// topologyNodeLabel returns the label of the node in the rendered graphs.
+func topologyNodeLabel(node service.TopologyNode) string {
+	switch node.Kind {
+	case "receiver", "exporter":
+		return fmt.Sprintf("%s %s (%s)", node.Kind, node.ComponentID, node.Signal)
+	case "connector":
+		return fmt.Sprintf("%s %s (%s to %s)", node.Kind, node.ComponentID, node.Signal, node.OutputSignal)
+	case "processor":
+		return fmt.Sprintf("%s %s", node.Kind, node.ComponentID)
+	}
+	return node.Kind
+}
+
+// topologyClusters groups the nodes which belong to a single pipeline, i.e. the processors and the internal nodes
+// of the pipelines, by pipeline. It returns the sorted pipelines and the indexes of their nodes.
+func topologyClusters(topology *service.Topology) ([]string, map[string][]int) {
+	clusters := make(map[string][]int)
+	for i, node := range topology.Nodes {
+		if node.Kind == "receiver" || node.Kind == "exporter" || node.Kind == "connector" || len(node.Pipelines) != 1 {
+			continue
+		}
+		pipelineID := node.Pipelines[0].String()
+		clusters[pipelineID] = append(clusters[pipelineID], i)
+	}
+	pipelineIDs := make([]string, 0, len(clusters))
+	for pipelineID := range clusters {
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+	slices.Sort(pipelineIDs)
+	return pipelineIDs, clusters
+}
+
+func writeTopologyDOT(w io.Writer, topology *service.Topology) error {
+	var sb strings.Builder
+	writeNode := func(indent string, node service.TopologyNode) {
+		shape := "box"
+		if node.Kind == "capabilities" || node.Kind == "fanout" {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&sb, "%s%s [label=%s, shape=%s];\n", indent, strconv.Quote(node.Name), strconv.Quote(topologyNodeLabel(node)), shape)
+	}
+
+	sb.WriteString("digraph pipelines {\n\trankdir=LR;\n")
+	pipelineIDs, clusters := topologyClusters(topology)
+	clustered := make(map[int]bool)
+	for _, pipelineID := range pipelineIDs {
+		fmt.Fprintf(&sb, "\tsubgraph %s {\n\t\tlabel=%s;\n", strconv.Quote("cluster_"+pipelineID), strconv.Quote("pipeline "+pipelineID))
+		for _, i := range clusters[pipelineID] {
+			writeNode("\t\t", topology.Nodes[i])
+			clustered[i] = true
+		}
+		sb.WriteString("\t}\n")
+	}
+	for i, node := range topology.Nodes {
+		if !clustered[i] {
+			writeNode("\t", node)
+		}
+	}
+	for _, edge := range topology.Edges {
+		fmt.Fprintf(&sb, "\t%s -> %s [label=%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(edge.Signal.String()))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeTopologyMermaid(w io.Writer, topology *service.Topology) error {
+	// The node names contain characters which are not allowed in Mermaid IDs, the nodes are identified by index.
+	ids := make(map[string]string, len(topology.Nodes))
+	for i, node := range topology.Nodes {
+		ids[node.Name] = "n" + strconv.Itoa(i)
+	}
+	var sb strings.Builder
+	writeNode := func(indent string, i int) {
+		fmt.Fprintf(&sb, "%s%s[\"%s\"]\n", indent, ids[topology.Nodes[i].Name], topologyNodeLabel(topology.Nodes[i]))
+	}
+
+	sb.WriteString("flowchart LR\n")
+	pipelineIDs, clusters := topologyClusters(topology)
+	clustered := make(map[int]bool)
+	for p, pipelineID := range pipelineIDs {
+		fmt.Fprintf(&sb, "\tsubgraph p%d[\"pipeline %s\"]\n", p, pipelineID)
+		for _, i := range clusters[pipelineID] {
+			writeNode("\t\t", i)
+			clustered[i] = true
+		}
+		sb.WriteString("\tend\n")
+	}
+	for i := range topology.Nodes {
+		if !clustered[i] {
+			writeNode("\t", i)
+		}
+	}
+	for _, edge := range topology.Edges {
+		fmt.Fprintf(&sb, "\t%s -->|%s| %s\n", ids[edge.From], edge.Signal, ids[edge.To])
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type topologyNodeJSON struct {
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"`
+	ComponentID  string   `json:"component_id,omitempty"`
+	Signal       string   `json:"signal"`
+	OutputSignal string   `json:"output_signal,omitempty"`
+	Pipelines    []string `json:"pipelines"`
+}
+
+type topologyEdgeJSON struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Signal string `json:"signal"`
+}
+
+func writeTopologyJSON(w io.Writer, topology *service.Topology) error {
+	out := struct {
+		Nodes []topologyNodeJSON `json:"nodes"`
+		Edges []topologyEdgeJSON `json:"edges"`
+	}{
+		Nodes: make([]topologyNodeJSON, 0, len(topology.Nodes)),
+		Edges: make([]topologyEdgeJSON, 0, len(topology.Edges)),
+	}
+	for _, node := range topology.Nodes {
+		n := topologyNodeJSON{
+			Name:      node.Name,
+			Kind:      node.Kind,
+			Signal:    node.Signal.String(),
+			Pipelines: make([]string, 0, len(node.Pipelines)),
+		}
+		if node.Kind != "capabilities" && node.Kind != "fanout" {
+			n.ComponentID = node.ComponentID.String()
+		}
+		if node.Kind == "connector" {
+			n.OutputSignal = node.OutputSignal.String()
+		}
+		for _, pipelineID := range node.Pipelines {
+			n.Pipelines = append(n.Pipelines, pipelineID.String())
+		}
+		out.Nodes = append(out.Nodes, n)
+	}
+	for _, edge := range topology.Edges {
+		out.Edges = append(out.Edges, topologyEdgeJSON{
+			From:   edge.From,
+			To:     edge.To,
+			Signal: edge.Signal.String(),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
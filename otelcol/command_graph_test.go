@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newGraphTestSettings(t *testing.T, fileName string) CollectorSettings {
+	filePath := filepath.Join("testdata", fileName)
+	fileProvider := newFakeProvider("file", func(_ context.Context, _ string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		return confmap.NewRetrieved(newConfFromFile(t, filePath))
+	})
+	return CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:              []string{filePath},
+			ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			DefaultScheme:     "file",
+		},
+	}}
+}
+
+func TestGraphSubCommand(t *testing.T) {
+	for _, tt := range []struct {
+		format   string
+		expected string
+	}{
+		{format: "dot", expected: "graph.dot"},
+		{format: "mermaid", expected: "graph.mmd"},
+		{format: "json", expected: "graph.json"},
+	} {
+		t.Run(tt.format, func(t *testing.T) {
+			cmd := newGraphSubCommand(newGraphTestSettings(t, "otelcol-nop.yaml"), flags(featuregate.GlobalRegistry()))
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--format", tt.format})
+			require.NoError(t, cmd.Execute())
+
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.expected))
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func TestGraphSubCommandInvalidFormat(t *testing.T) {
+	cmd := newGraphSubCommand(newGraphTestSettings(t, "otelcol-nop.yaml"), flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--format", "svg"})
+	require.ErrorContains(t, cmd.Execute(), `invalid format "svg": formats are: dot, mermaid, json`)
+}
+
+func TestGraphSubCommandNoConfig(t *testing.T) {
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	require.ErrorContains(t, cmd.Execute(), "at least one config flag must be provided")
+}
+
+func TestGraphSubCommandInvalidComponents(t *testing.T) {
+	cmd := newGraphSubCommand(newGraphTestSettings(t, "otelcol-invalid-components.yaml"), flags(featuregate.GlobalRegistry()))
+	require.ErrorContains(t, cmd.Execute(), "unknown type: \"nosuchprocessor\"")
+}
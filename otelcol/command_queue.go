@@ -0,0 +1,553 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/internal/persistentqueue"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+var errQueueProfilesNotSupported = errors.New("profiles are not supported by this command")
+
+// queueCommandFlags holds the flags shared by all the queue sub commands.
+type queueCommandFlags struct {
+	exporters  []string
+	lanes      []string
+	deadLetter bool
+}
+
+// newQueueSubCommand constructs a new queue command using the given CollectorSettings.
+func newQueueSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	qf := &queueCommandFlags{}
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspects and replays the data stored by the exporters persistent queues",
+		Long: `Inspects and replays the data stored by the exporters persistent sending queues and dead letter queues.
+
+The storage extensions configured in the "sending_queue::storage" and "sending_queue::dead_letter::storage"
+settings of the exporters are opened directly, the collector using the same storage must not be running.
+Every lane configured in "sending_queue::priority::lanes" is read as a separate persistent queue.
+The queues configured with "sending_queue::encryption" are decrypted, the keys must be configured with "key_file".
+The profiles can be listed, but not dumped or replayed.
+
+This command is experimental, the output format is not stable and can change between releases.`,
+	}
+	cmd.PersistentFlags().StringSliceVar(&qf.exporters, "exporter", nil, "Only use the queues of the given exporters, e.g. otlp_grpc/backend")
+	cmd.PersistentFlags().StringSliceVar(&qf.lanes, "lane", nil, "Only use the given priority lanes of the sending queues")
+	cmd.PersistentFlags().BoolVar(&qf.deadLetter, "dead-letter", false, "Use the dead letter queues instead of the sending queues")
+	cmd.PersistentFlags().AddGoFlagSet(flagSet)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Lists the items stored in the queues with their sizes and ages",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			qctx, err := newQueueContext(cmd, set, flagSet, qf)
+			if err != nil {
+				return err
+			}
+			return qctx.list()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "dump",
+		Short: "Prints the items stored in the queues as OTLP JSON, one item per line",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			qctx, err := newQueueContext(cmd, set, flagSet, qf)
+			if err != nil {
+				return err
+			}
+			return qctx.dump()
+		},
+	})
+
+	var endpoint string
+	var timeout time.Duration
+	var drain bool
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Sends the items stored in the queues to an OTLP/HTTP endpoint",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if endpoint == "" {
+				return errors.New("the --endpoint flag is required")
+			}
+			qctx, err := newQueueContext(cmd, set, flagSet, qf)
+			if err != nil {
+				return err
+			}
+			return qctx.replay(endpoint, timeout, drain)
+		},
+	}
+	replayCmd.Flags().StringVar(&endpoint, "endpoint", "", "OTLP/HTTP endpoint the items are sent to, e.g. http://localhost:4318")
+	replayCmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout of every request sent to the endpoint")
+	replayCmd.Flags().BoolVar(&drain, "drain", false, "Remove the items from the queue once successfully sent")
+	cmd.AddCommand(replayCmd)
+
+	return cmd
+}
+
+// persistentQueueRef identifies a persistent queue of an exporter for a signal.
+type persistentQueueRef struct {
+	exporterID component.ID
+	signal     pipeline.Signal
+	// lane is the name of the priority lane, empty if the sending queue has no lanes.
+	lane string
+	// defaultLane is true for the default priority lane, which uses the storage of a queue without lanes.
+	defaultLane bool
+	storageID   component.ID
+	// encryption is nil if the queue is not encrypted.
+	encryption *persistentqueue.EncryptionSettings
+}
+
+func (ref persistentQueueRef) storageName(deadLetter bool) string {
+	switch {
+	case deadLetter:
+		return persistentqueue.DeadLetterStorageName(ref.signal)
+	case ref.lane != "" && !ref.defaultLane:
+		return persistentqueue.LaneStorageName(ref.signal, ref.lane)
+	}
+	return persistentqueue.StorageName(ref.signal)
+}
+
+// laneName returns the name of the priority lane displayed by the commands.
+func (ref persistentQueueRef) laneName() string {
+	if ref.lane == "" {
+		return "-"
+	}
+	return ref.lane
+}
+
+type queueContext struct {
+	ctx        context.Context
+	stdout     io.Writer
+	set        CollectorSettings
+	factories  Factories
+	cfg        *Config
+	deadLetter bool
+	queues     []persistentQueueRef
+}
+
+func newQueueContext(cmd *cobra.Command, set CollectorSettings, flagSet *flag.FlagSet, qf *queueCommandFlags) (*queueContext, error) {
+	if len(qf.lanes) > 0 && qf.deadLetter {
+		return nil, errors.New("the --lane flag cannot be used with --dead-letter, the dead letter queues have no lanes")
+	}
+	if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+		return nil, err
+	}
+	factories, err := set.Factories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	configProvider, err := NewConfigProvider(set.ConfigProviderSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config provider: %w", err)
+	}
+	cfg, err := configProvider.Get(cmd.Context(), factories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	qctx := &queueContext{
+		ctx:        cmd.Context(),
+		stdout:     cmd.OutOrStdout(),
+		set:        set,
+		factories:  factories,
+		cfg:        cfg,
+		deadLetter: qf.deadLetter,
+	}
+	qctx.queues, err = findPersistentQueues(cfg, qf.exporters, qf.lanes, qf.deadLetter)
+	if err != nil {
+		return nil, err
+	}
+	return qctx, nil
+}
+
+// findPersistentQueues returns the persistent queues, or dead letter queues, configured for the exporters used in
+// the pipelines, sorted by exporter, signal and lane. Every priority lane of a sending queue is a separate queue.
+func findPersistentQueues(cfg *Config, exporters, lanes []string, deadLetter bool) ([]persistentQueueRef, error) {
+	storageKey := "sending_queue::storage"
+	if deadLetter {
+		storageKey = "sending_queue::dead_letter::storage"
+	}
+
+	var queues []persistentQueueRef
+	for expID, expCfg := range cfg.Exporters {
+		if len(exporters) > 0 && !slices.Contains(exporters, expID.String()) {
+			continue
+		}
+		conf := confmap.New()
+		if err := conf.Marshal(expCfg); err != nil {
+			return nil, fmt.Errorf("failed to marshal exporter %q config: %w", expID, err)
+		}
+		storageStr, ok := conf.Get(storageKey).(string)
+		if !ok || storageStr == "" {
+			continue
+		}
+		var storageID component.ID
+		if err := storageID.UnmarshalText([]byte(storageStr)); err != nil {
+			return nil, fmt.Errorf("exporter %q: invalid storage %q: %w", expID, storageStr, err)
+		}
+		encryption, err := unmarshalQueueEncryption(conf)
+		if err != nil {
+			return nil, fmt.Errorf("exporter %q: %w", expID, err)
+		}
+		refs := []persistentQueueRef{{exporterID: expID, storageID: storageID, encryption: encryption}}
+		if !deadLetter {
+			if refs, err = unmarshalQueueLanes(conf, refs[0]); err != nil {
+				return nil, fmt.Errorf("exporter %q: %w", expID, err)
+			}
+		}
+		for pipeID, pipeCfg := range cfg.Service.Pipelines {
+			if !slices.Contains(pipeCfg.Exporters, expID) {
+				continue
+			}
+			for _, ref := range refs {
+				if len(lanes) > 0 && !slices.Contains(lanes, ref.lane) {
+					continue
+				}
+				ref.signal = pipeID.Signal()
+				if !slices.Contains(queues, ref) {
+					queues = append(queues, ref)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(queues, func(a, b persistentQueueRef) int {
+		if c := strings.Compare(a.exporterID.String(), b.exporterID.String()); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.signal.String(), b.signal.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.lane, b.lane)
+	})
+	return queues, nil
+}
+
+// unmarshalQueueLanes returns a copy of the given queue for every priority lane of the sending queue, or the queue
+// itself if no lanes are configured.
+func unmarshalQueueLanes(conf *confmap.Conf, ref persistentQueueRef) ([]persistentQueueRef, error) {
+	if conf.Get("sending_queue::priority") == nil {
+		return []persistentQueueRef{ref}, nil
+	}
+	priority := &exporterhelper.PriorityConfig{}
+	sub, err := conf.Sub("sending_queue::priority")
+	if err != nil {
+		return nil, err
+	}
+	if err = sub.Unmarshal(priority); err != nil {
+		return nil, fmt.Errorf("invalid priority config: %w", err)
+	}
+	if len(priority.Lanes) == 0 {
+		return []persistentQueueRef{ref}, nil
+	}
+	defaultLane := priority.DefaultLane
+	if defaultLane == "" {
+		defaultLane = priority.Lanes[len(priority.Lanes)-1].Name
+	}
+	refs := make([]persistentQueueRef, 0, len(priority.Lanes))
+	for _, lane := range priority.Lanes {
+		laneRef := ref
+		laneRef.lane = lane.Name
+		laneRef.defaultLane = lane.Name == defaultLane
+		refs = append(refs, laneRef)
+	}
+	return refs, nil
+}
+
+// unmarshalQueueEncryption loads the encryption keys of the sending queue, or returns nil if not configured.
+// The marshaled configuration has the opaque values redacted, so only the keys read from files can be used.
+func unmarshalQueueEncryption(conf *confmap.Conf) (*persistentqueue.EncryptionSettings, error) {
+	if conf.Get("sending_queue::encryption") == nil {
+		return nil, nil
+	}
+	encryption := &exporterhelper.EncryptionConfig{}
+	sub, err := conf.Sub("sending_queue::encryption")
+	if err != nil {
+		return nil, err
+	}
+	if err = sub.Unmarshal(encryption); err != nil {
+		return nil, fmt.Errorf("invalid encryption config: %w", err)
+	}
+	set := &persistentqueue.EncryptionSettings{}
+	if set.Key, err = loadQueueEncryptionKey(encryption.EncryptionKeyConfig); err != nil {
+		return nil, err
+	}
+	for _, keyCfg := range encryption.PreviousKeys {
+		key, err := loadQueueEncryptionKey(keyCfg)
+		if err != nil {
+			return nil, err
+		}
+		set.PreviousKeys = append(set.PreviousKeys, key)
+	}
+	return set, nil
+}
+
+func loadQueueEncryptionKey(cfg exporterhelper.EncryptionKeyConfig) (persistentqueue.EncryptionKey, error) {
+	if cfg.KeyFile == "" {
+		return persistentqueue.EncryptionKey{}, fmt.Errorf("encryption key %q must be configured using `key_file` to be read by this command", cfg.KeyID)
+	}
+	encoded, err := os.ReadFile(filepath.Clean(cfg.KeyFile))
+	if err != nil {
+		return persistentqueue.EncryptionKey{}, fmt.Errorf("failed to read the encryption key %q: %w", cfg.KeyID, err)
+	}
+	return persistentqueue.ParseEncryptionKey(cfg.KeyID, encoded)
+}
+
+// forEachItem calls fn for every item of every queue. If drain is true, the items are removed from the storage
+// when fn returns no error.
+func (qctx *queueContext) forEachItem(drain bool, fn func(persistentQueueRef, persistentqueue.Item) error) error {
+	for _, ref := range qctx.queues {
+		err := qctx.withStorageClient(ref, func(client storage.Client) error {
+			itemFn := func(item persistentqueue.Item) error {
+				return fn(ref, item)
+			}
+			if drain {
+				return persistentqueue.Drain(qctx.ctx, client, itemFn)
+			}
+			return persistentqueue.Read(qctx.ctx, client, itemFn)
+		})
+		if err != nil {
+			if ref.lane != "" {
+				return fmt.Errorf("exporter %q, signal %q, lane %q: %w", ref.exporterID, ref.signal, ref.lane, err)
+			}
+			return fmt.Errorf("exporter %q, signal %q: %w", ref.exporterID, ref.signal, err)
+		}
+	}
+	return nil
+}
+
+// withStorageClient creates and starts the storage extension used by the queue, and calls fn with the storage
+// client of the queue.
+func (qctx *queueContext) withStorageClient(ref persistentQueueRef, fn func(storage.Client) error) (err error) {
+	factory, ok := qctx.factories.Extensions[ref.storageID.Type()]
+	if !ok {
+		return fmt.Errorf("storage extension %q is not available in this collector distribution", ref.storageID.Type())
+	}
+	extCfg, ok := qctx.cfg.Extensions[ref.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension %q is not configured", ref.storageID)
+	}
+	ext, err := factory.Create(qctx.ctx, extension.Settings{
+		ID:                ref.storageID,
+		TelemetrySettings: queueTelemetrySettings(),
+		BuildInfo:         qctx.set.BuildInfo,
+	}, extCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create storage extension %q: %w", ref.storageID, err)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", ref.storageID)
+	}
+	if err = ext.Start(qctx.ctx, &queueHost{extensions: map[component.ID]component.Component{ref.storageID: ext}}); err != nil {
+		return errors.Join(fmt.Errorf("failed to start storage extension %q: %w", ref.storageID, err), ext.Shutdown(qctx.ctx))
+	}
+	defer func() {
+		err = errors.Join(err, ext.Shutdown(qctx.ctx))
+	}()
+
+	client, err := storageExt.GetClient(qctx.ctx, component.KindExporter, ref.exporterID, ref.storageName(qctx.deadLetter))
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, client.Close(qctx.ctx))
+	}()
+	if ref.encryption == nil {
+		return fn(client)
+	}
+	encryptedClient, err := persistentqueue.NewEncryptedClient(client, *ref.encryption)
+	if err != nil {
+		return err
+	}
+	return fn(encryptedClient)
+}
+
+func (qctx *queueContext) list() error {
+	w := tabwriter.NewWriter(qctx.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "EXPORTER\tSIGNAL\tLANE\tINDEX\tBYTES\tAGE\tDISPATCHED\n")
+	now := time.Now()
+	err := qctx.forEachItem(false, func(ref persistentQueueRef, item persistentqueue.Item) error {
+		age := "unknown"
+		if !item.EnqueueTime.IsZero() {
+			age = now.Sub(item.EnqueueTime).Round(time.Second).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%v\n", ref.exporterID, ref.signal, ref.laneName(), item.Index, len(item.Value), age, item.Dispatched)
+		return nil
+	})
+	return errors.Join(err, w.Flush())
+}
+
+func (qctx *queueContext) dump() error {
+	return qctx.forEachItem(false, func(ref persistentQueueRef, item persistentqueue.Item) error {
+		buf, err := marshalQueueItemJSON(ref.signal, item.Value)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", item.Index, err)
+		}
+		buf = append(buf, '\n')
+		_, err = qctx.stdout.Write(buf)
+		return err
+	})
+}
+
+func (qctx *queueContext) replay(endpoint string, timeout time.Duration, drain bool) error {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	clientCfg := confighttp.NewDefaultClientConfig()
+	clientCfg.Endpoint = endpoint
+	clientCfg.Timeout = timeout
+	client, err := clientCfg.ToClient(qctx.ctx, nil, queueTelemetrySettings())
+	if err != nil {
+		return fmt.Errorf("failed to create the HTTP client: %w", err)
+	}
+	defer client.CloseIdleConnections()
+
+	sent := 0
+	err = qctx.forEachItem(drain, func(ref persistentQueueRef, item persistentqueue.Item) error {
+		path, body, err := marshalQueueItemOTLP(ref.signal, item.Value)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", item.Index, err)
+		}
+		if err = postOTLP(qctx.ctx, client, endpoint+path, body); err != nil {
+			return fmt.Errorf("item %d: %w", item.Index, err)
+		}
+		sent++
+		return nil
+	})
+	fmt.Fprintf(qctx.stdout, "Replayed %d items to %s\n", sent, endpoint)
+	return err
+}
+
+// marshalQueueItemJSON decodes a queue item and marshals it as OTLP JSON.
+func marshalQueueItemJSON(signal pipeline.Signal, value []byte) ([]byte, error) {
+	switch signal {
+	case pipeline.SignalTraces:
+		_, td, err := persistentqueue.UnmarshalTraces(value)
+		if err != nil {
+			return nil, err
+		}
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	case pipeline.SignalMetrics:
+		_, md, err := persistentqueue.UnmarshalMetrics(value)
+		if err != nil {
+			return nil, err
+		}
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	case pipeline.SignalLogs:
+		_, ld, err := persistentqueue.UnmarshalLogs(value)
+		if err != nil {
+			return nil, err
+		}
+		return (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	case xpipeline.SignalProfiles:
+		return nil, errQueueProfilesNotSupported
+	}
+	return nil, fmt.Errorf("unsupported signal %q", signal)
+}
+
+// marshalQueueItemOTLP decodes a queue item and marshals it as an OTLP/HTTP protobuf request,
+// returns the URL path the request must be sent to.
+func marshalQueueItemOTLP(signal pipeline.Signal, value []byte) (string, []byte, error) {
+	switch signal {
+	case pipeline.SignalTraces:
+		_, td, err := persistentqueue.UnmarshalTraces(value)
+		if err != nil {
+			return "", nil, err
+		}
+		body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+		return "/v1/traces", body, err
+	case pipeline.SignalMetrics:
+		_, md, err := persistentqueue.UnmarshalMetrics(value)
+		if err != nil {
+			return "", nil, err
+		}
+		body, err := pmetricotlp.NewExportRequestFromMetrics(md).MarshalProto()
+		return "/v1/metrics", body, err
+	case pipeline.SignalLogs:
+		_, ld, err := persistentqueue.UnmarshalLogs(value)
+		if err != nil {
+			return "", nil, err
+		}
+		body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+		return "/v1/logs", body, err
+	case xpipeline.SignalProfiles:
+		return "", nil, errQueueProfilesNotSupported
+	}
+	return "", nil, fmt.Errorf("unsupported signal %q", signal)
+}
+
+func postOTLP(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send data to %s, response status: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// queueTelemetrySettings returns the telemetry settings of the components created by the queue command,
+// their telemetry is not reported.
+func queueTelemetrySettings() component.TelemetrySettings {
+	return component.TelemetrySettings{
+		Logger:         zap.NewNop(),
+		TracerProvider: nooptrace.NewTracerProvider(),
+		MeterProvider:  noopmetric.NewMeterProvider(),
+		Resource:       pcommon.NewResource(),
+	}
+}
+
+// queueHost is the minimal component.Host used to start the storage extensions.
+type queueHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h *queueHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
@@ -0,0 +1,380 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+var (
+	queueExporterType = component.MustNewType("e")
+	memStorageType    = component.MustNewType("mem_storage")
+)
+
+type queueExporterConfig struct {
+	QueueConfig configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+}
+
+func newQueueExporterFactory() exporter.Factory {
+	return exporter.NewFactory(
+		queueExporterType,
+		func() component.Config {
+			return &queueExporterConfig{QueueConfig: configoptional.Default(exporterhelper.NewDefaultQueueConfig())}
+		},
+		exporter.WithLogs(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+			return exporterhelper.NewLogs(ctx, set, cfg, func(context.Context, plog.Logs) error {
+				return consumererror.NewPermanent(errors.New("rejected"))
+			}, exporterhelper.WithQueue(cfg.(*queueExporterConfig).QueueConfig))
+		}, component.StabilityLevelStable),
+	)
+}
+
+// memStorage is a storage extension that keeps the data in memory across the extension instances.
+type memStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	clients *sync.Map
+}
+
+func (m *memStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	c, _ := m.clients.LoadOrStore(kind.String()+"/"+id.String()+"/"+name, &memStorageClient{data: map[string][]byte{}})
+	return c.(*memStorageClient), nil
+}
+
+func newMemStorageFactory() extension.Factory {
+	clients := &sync.Map{}
+	return extension.NewFactory(
+		memStorageType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &memStorage{clients: clients}, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+}
+
+type memStorageClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (c *memStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *memStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *memStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *memStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *memStorageClient) Close(context.Context) error {
+	return nil
+}
+
+func newQueueTestSettings(t *testing.T) CollectorSettings {
+	return newQueueTestSettingsWithQueue(t, func(*exporterhelper.QueueBatchConfig) {})
+}
+
+// newQueueTestSettingsWithQueue returns the settings of a collector that has dead letters stored by an exporter
+// using the sending queue configuration updated by the given function.
+func newQueueTestSettingsWithQueue(t *testing.T, updateQueue func(*exporterhelper.QueueBatchConfig)) CollectorSettings {
+	storageFactory := newMemStorageFactory()
+	factories := Factories{
+		Receivers: map[component.Type]receiver.Factory{
+			receivertest.NopType: receivertest.NewNopFactory(),
+		},
+		Exporters: map[component.Type]exporter.Factory{
+			queueExporterType: newQueueExporterFactory(),
+		},
+		Extensions: map[component.Type]extension.Factory{
+			memStorageType: storageFactory,
+		},
+		Telemetry: telemetry.NewFactory(func() component.Config {
+			return fakeTelemetryConfig{}
+		}),
+	}
+
+	// Export the logs that are rejected, so they are stored in the dead letter queue.
+	ctx := context.Background()
+	ext, err := storageFactory.Create(ctx, extension.Settings{ID: component.NewID(memStorageType)}, &struct{}{})
+	require.NoError(t, err)
+	expFactory := factories.Exporters[queueExporterType]
+	expCfg := expFactory.CreateDefaultConfig().(*queueExporterConfig)
+	expCfg.QueueConfig.GetOrInsertDefault().Batch = configoptional.None[exporterhelper.BatchConfig]()
+	expCfg.QueueConfig.Get().WaitForResult = true
+	expCfg.QueueConfig.Get().DeadLetter = configoptional.Some(exporterhelper.DeadLetterConfig{StorageID: component.NewID(memStorageType)})
+	updateQueue(expCfg.QueueConfig.Get())
+	expSet := exportertest.NewNopSettings(queueExporterType)
+	expSet.ID = component.NewID(queueExporterType)
+	exp, err := expFactory.CreateLogs(ctx, expSet, expCfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(ctx, &queueHost{extensions: map[component.ID]component.Component{component.NewID(memStorageType): ext}}))
+	// The dead letters are still reported as failed.
+	require.EqualError(t, exp.ConsumeLogs(ctx, testdata.GenerateLogs(2)), "Permanent error: rejected")
+	require.EqualError(t, exp.ConsumeLogs(ctx, testdata.GenerateLogs(3)), "Permanent error: rejected")
+	require.NoError(t, exp.Shutdown(ctx))
+
+	return CollectorSettings{
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+				DefaultScheme:     "file",
+			},
+		},
+	}
+}
+
+func executeQueueCommand(t *testing.T, set CollectorSettings, args ...string) (string, error) {
+	return executeQueueCommandWithConfig(t, set, "queue.yaml", args...)
+}
+
+func executeQueueCommandWithConfig(_ *testing.T, set CollectorSettings, configFile string, args ...string) (string, error) {
+	cmd := newQueueSubCommand(set, flags(featuregate.GlobalRegistry()))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs(append(args, "--config", "file:"+filepath.Join("testdata", configFile)))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestQueueCommandList(t *testing.T) {
+	set := newQueueTestSettings(t)
+
+	out, err := executeQueueCommand(t, set, "list", "--dead-letter")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"EXPORTER", "SIGNAL", "LANE", "INDEX", "BYTES", "AGE", "DISPATCHED"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"e", "logs", "-", "0"}, strings.Fields(lines[1])[:4])
+	assert.Equal(t, []string{"e", "logs", "-", "1"}, strings.Fields(lines[2])[:4])
+
+	// The sending queue is not persistent.
+	out, err = executeQueueCommand(t, set, "list")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 1)
+
+	// No queues for other exporters.
+	out, err = executeQueueCommand(t, set, "list", "--dead-letter", "--exporter", "e/other")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 1)
+}
+
+func TestQueueCommandDump(t *testing.T) {
+	set := newQueueTestSettings(t)
+
+	out, err := executeQueueCommand(t, set, "dump", "--dead-letter")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	for i, expected := range []int{2, 3} {
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(lines[i]))
+		require.NoError(t, err)
+		assert.Equal(t, expected, ld.LogRecordCount())
+	}
+}
+
+func TestQueueCommandReplay(t *testing.T) {
+	set := newQueueTestSettings(t)
+
+	var received atomic.Int64
+	var fail, hang atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			// The context is canceled when the client closes the connection, once the body is read.
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			return
+		}
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received.Add(int64(req.Logs().LogRecordCount()))
+	}))
+	defer srv.Close()
+
+	_, err := executeQueueCommand(t, set, "replay", "--dead-letter")
+	require.EqualError(t, err, "the --endpoint flag is required")
+
+	fail.Store(true)
+	_, err = executeQueueCommand(t, set, "replay", "--dead-letter", "--drain", "--endpoint", srv.URL)
+	require.ErrorContains(t, err, "503 Service Unavailable")
+	fail.Store(false)
+
+	hang.Store(true)
+	_, err = executeQueueCommand(t, set, "replay", "--dead-letter", "--drain", "--endpoint", srv.URL, "--timeout", "10ms")
+	require.ErrorContains(t, err, "Client.Timeout exceeded")
+	hang.Store(false)
+
+	// Without drain the items are kept.
+	out, err := executeQueueCommand(t, set, "replay", "--dead-letter", "--endpoint", srv.URL)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Replayed 2 items")
+	assert.Equal(t, int64(5), received.Load())
+
+	out, err = executeQueueCommand(t, set, "replay", "--dead-letter", "--drain", "--endpoint", srv.URL+"/")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Replayed 2 items")
+	assert.Equal(t, int64(10), received.Load())
+
+	out, err = executeQueueCommand(t, set, "list", "--dead-letter")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 1)
+}
+
+func TestMarshalQueueItemProfiles(t *testing.T) {
+	_, err := marshalQueueItemJSON(xpipeline.SignalProfiles, nil)
+	require.ErrorIs(t, err, errQueueProfilesNotSupported)
+	_, _, err = marshalQueueItemOTLP(xpipeline.SignalProfiles, nil)
+	require.ErrorIs(t, err, errQueueProfilesNotSupported)
+}
+
+// newQueueLanesTestSettings returns the settings of a collector that has data stored in the persistent lanes
+// of the sending queue configured in testdata/queue_lanes.yaml.
+func newQueueLanesTestSettings(t *testing.T) CollectorSettings {
+	set := newQueueTestSettings(t)
+	factories, err := set.Factories()
+	require.NoError(t, err)
+
+	// The exports are retried until the shutdown, so the data is kept in the persistent lanes.
+	ctx := context.Background()
+	ext, err := factories.Extensions[memStorageType].Create(ctx, extension.Settings{ID: component.NewID(memStorageType)}, &struct{}{})
+	require.NoError(t, err)
+	queueCfg := exporterhelper.NewDefaultQueueConfig()
+	queueCfg.Batch = configoptional.None[exporterhelper.BatchConfig]()
+	storageID := component.NewID(memStorageType)
+	queueCfg.StorageID = &storageID
+	queueCfg.Priority = configoptional.Some(exporterhelper.PriorityConfig{
+		ResourceAttribute: "resource-attr",
+		Lanes: []exporterhelper.PriorityLaneConfig{
+			{Name: "high", Values: []string{"resource-attr-val-1"}, Weight: 2},
+			{Name: "low", Weight: 1},
+		},
+	})
+	retryCfg := configretry.NewDefaultBackOffConfig()
+	retryCfg.InitialInterval = time.Hour
+	expSet := exportertest.NewNopSettings(queueExporterType)
+	expSet.ID = component.NewID(queueExporterType)
+	exp, err := exporterhelper.NewLogs(ctx, expSet, &queueExporterConfig{}, func(context.Context, plog.Logs) error {
+		return errors.New("unavailable")
+	}, exporterhelper.WithQueue(configoptional.Some(queueCfg)), exporterhelper.WithRetry(retryCfg))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(ctx, &queueHost{extensions: map[component.ID]component.Component{component.NewID(memStorageType): ext}}))
+	require.NoError(t, exp.ConsumeLogs(ctx, testdata.GenerateLogs(2)))
+	low := plog.NewLogs()
+	low.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, exp.ConsumeLogs(ctx, low))
+	require.NoError(t, exp.Shutdown(ctx))
+	return set
+}
+
+func TestQueueCommandLanes(t *testing.T) {
+	set := newQueueLanesTestSettings(t)
+
+	out, err := executeQueueCommandWithConfig(t, set, "queue_lanes.yaml", "list")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"e", "logs", "high", "0"}, strings.Fields(lines[1])[:4])
+	assert.Equal(t, []string{"e", "logs", "low", "0"}, strings.Fields(lines[2])[:4])
+
+	out, err = executeQueueCommandWithConfig(t, set, "queue_lanes.yaml", "dump", "--lane", "low")
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1)
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(lines[0]))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+
+	_, err = executeQueueCommandWithConfig(t, set, "queue_lanes.yaml", "list", "--lane", "low", "--dead-letter")
+	require.ErrorContains(t, err, "the --lane flag cannot be used with --dead-letter")
+}
+
+func TestQueueCommandEncrypted(t *testing.T) {
+	set := newQueueTestSettingsWithQueue(t, func(cfg *exporterhelper.QueueBatchConfig) {
+		cfg.Encryption = configoptional.Some(exporterhelper.EncryptionConfig{
+			EncryptionKeyConfig: exporterhelper.EncryptionKeyConfig{KeyID: "test", KeyFile: filepath.Join("testdata", "queue.key")},
+		})
+	})
+
+	out, err := executeQueueCommandWithConfig(t, set, "queue_encrypted.yaml", "dump", "--dead-letter")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	for i, expected := range []int{2, 3} {
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(lines[i]))
+		require.NoError(t, err)
+		assert.Equal(t, expected, ld.LogRecordCount())
+	}
+
+	// Without the key, the items cannot be decoded.
+	_, err = executeQueueCommand(t, set, "dump", "--dead-letter")
+	require.Error(t, err)
+
+	// The inline keys are redacted in the configuration.
+	_, err = executeQueueCommandWithConfig(t, set, "queue_encrypted_inline.yaml", "dump", "--dead-letter")
+	require.ErrorContains(t, err, "encryption key \"test\" must be configured using `key_file` to be read by this command")
+}
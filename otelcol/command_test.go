@@ -68,12 +68,13 @@ func TestAddFlagToSettings(t *testing.T) {
 		},
 	}
 	flgs := flags(featuregate.NewRegistry())
-	err := flgs.Parse([]string{"--config=otelcol-nop.yaml"})
+	err := flgs.Parse([]string{"--config=otelcol-nop.yaml", "--last-known-good-config=last-known-good.yaml"})
 	require.NoError(t, err)
 
 	err = updateSettingsUsingFlags(&set, flgs)
 	require.NoError(t, err)
 	require.Len(t, set.ConfigProviderSettings.ResolverSettings.URIs, 1)
+	assert.Equal(t, "last-known-good.yaml", set.LastKnownGoodConfigFile)
 }
 
 func TestInvalidCollectorSettings(t *testing.T) {
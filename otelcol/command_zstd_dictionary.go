@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	zstdDictionaryEncodingProto = "proto"
+	zstdDictionaryEncodingJSON  = "json"
+
+	defaultZstdDictionaryMaxSize = 64 * 1024
+)
+
+// zstdDictionaryFlags holds the flags of the zstd-dictionary command.
+type zstdDictionaryFlags struct {
+	output   string
+	id       uint32
+	maxSize  int
+	encoding string
+}
+
+// newZstdDictionarySubCommand constructs a new zstd-dictionary command.
+func newZstdDictionarySubCommand() *cobra.Command {
+	zf := &zstdDictionaryFlags{}
+	cmd := &cobra.Command{
+		Use:   "zstd-dictionary --output <file> <sample>...",
+		Short: "Trains a zstd dictionary from captured OTLP payloads",
+		Long: `Trains a zstd dictionary from captured OTLP payloads, to be used with the "compression_params::dictionary_file"
+setting of the OTLP exporters and the "compression_dictionary_files" setting of the OTLP receivers.
+
+Every sample file either contains OTLP JSON requests, one per line as printed by the "queue dump" command,
+or a single OTLP protobuf request. The JSON requests are encoded with the given encoding before training,
+the protobuf requests are used as is.
+
+This command is experimental, its flags can change between releases.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return trainZstdDictionary(cmd, zf, args)
+		},
+	}
+	cmd.Flags().StringVar(&zf.output, "output", "", "Path of the file the dictionary is written to")
+	cmd.Flags().Uint32Var(&zf.id, "id", 0, "ID of the dictionary, a random ID is used if 0")
+	cmd.Flags().IntVar(&zf.maxSize, "max-size", defaultZstdDictionaryMaxSize, "Maximum size of the dictionary in bytes")
+	cmd.Flags().StringVar(&zf.encoding, "encoding", zstdDictionaryEncodingProto, `Encoding of the payloads the dictionary is used for, "proto" or "json"`)
+	_ = cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func trainZstdDictionary(cmd *cobra.Command, zf *zstdDictionaryFlags, files []string) error {
+	if zf.encoding != zstdDictionaryEncodingProto && zf.encoding != zstdDictionaryEncodingJSON {
+		return fmt.Errorf("unsupported encoding %q", zf.encoding)
+	}
+
+	var samples [][]byte
+	for _, file := range files {
+		fileSamples, err := readZstdDictionarySamples(file, zf.encoding)
+		if err != nil {
+			return fmt.Errorf("failed to read the samples of %q: %w", file, err)
+		}
+		samples = append(samples, fileSamples...)
+	}
+
+	d, err := dict.BuildZstdDict(samples, dict.Options{MaxDictSize: zf.maxSize, HashBytes: 6, ZstdDictID: zf.id})
+	if err != nil {
+		return fmt.Errorf("failed to train the dictionary: %w", err)
+	}
+	info, err := zstd.InspectDictionary(d)
+	if err != nil {
+		return fmt.Errorf("failed to train the dictionary: %w", err)
+	}
+	if err = os.WriteFile(zf.output, d, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Trained the dictionary %d (%d bytes) from %d samples to %s\n", info.ID(), len(d), len(samples), zf.output)
+	return nil
+}
+
+// readZstdDictionarySamples reads the samples of a file, either OTLP JSON requests, one per line, or a single
+// OTLP protobuf request.
+func readZstdDictionarySamples(file, encoding string) ([][]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	if trimmed[0] != '{' {
+		if encoding != zstdDictionaryEncodingProto {
+			return nil, errors.New("the protobuf samples can only be used for the proto encoding")
+		}
+		return [][]byte{content}, nil
+	}
+
+	var samples [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(nil, len(trimmed))
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		sample, err := encodeZstdDictionarySample(scanner.Bytes(), encoding)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
+
+// encodeZstdDictionarySample decodes an OTLP JSON request and encodes it with the given encoding.
+func encodeZstdDictionarySample(buf []byte, encoding string) ([]byte, error) {
+	var signal struct {
+		ResourceSpans   json.RawMessage `json:"resourceSpans"`
+		ResourceMetrics json.RawMessage `json:"resourceMetrics"`
+		ResourceLogs    json.RawMessage `json:"resourceLogs"`
+	}
+	if err := json.Unmarshal(buf, &signal); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case signal.ResourceSpans != nil:
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(buf)
+		if err != nil {
+			return nil, err
+		}
+		req := ptraceotlp.NewExportRequestFromTraces(td)
+		if encoding == zstdDictionaryEncodingJSON {
+			return req.MarshalJSON()
+		}
+		return req.MarshalProto()
+	case signal.ResourceMetrics != nil:
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(buf)
+		if err != nil {
+			return nil, err
+		}
+		req := pmetricotlp.NewExportRequestFromMetrics(md)
+		if encoding == zstdDictionaryEncodingJSON {
+			return req.MarshalJSON()
+		}
+		return req.MarshalProto()
+	case signal.ResourceLogs != nil:
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(buf)
+		if err != nil {
+			return nil, err
+		}
+		req := plogotlp.NewExportRequestFromLogs(ld)
+		if encoding == zstdDictionaryEncodingJSON {
+			return req.MarshalJSON()
+		}
+		return req.MarshalProto()
+	}
+	return nil, errors.New("not an OTLP traces, metrics or logs request")
+}
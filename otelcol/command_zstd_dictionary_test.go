@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func newZstdDictionarySampleTraces(i int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.Resource().Attributes().PutStr("host.name", fmt.Sprintf("node-%d", i))
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(fmt.Sprintf("GET /cart/%d", i))
+	return td
+}
+
+// writeZstdDictionarySamples writes OTLP JSON traces and logs requests, one per line.
+func writeZstdDictionarySamples(t *testing.T) string {
+	var buf bytes.Buffer
+	for i := range 20 {
+		traces, err := (&ptrace.JSONMarshaler{}).MarshalTraces(newZstdDictionarySampleTraces(i))
+		require.NoError(t, err)
+		buf.Write(traces)
+		buf.WriteByte('\n')
+
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", "checkout")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(fmt.Sprintf("cart %d updated", i))
+		logs, err := (&plog.JSONMarshaler{}).MarshalLogs(ld)
+		require.NoError(t, err)
+		buf.Write(logs)
+		buf.WriteByte('\n')
+	}
+	file := filepath.Join(t.TempDir(), "samples.jsonl")
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0o600))
+	return file
+}
+
+func executeZstdDictionaryCommand(args ...string) (string, error) {
+	cmd := newZstdDictionarySubCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestZstdDictionarySubCommand(t *testing.T) {
+	samples := writeZstdDictionarySamples(t)
+	output := filepath.Join(t.TempDir(), "otlp.dict")
+
+	out, err := executeZstdDictionaryCommand("--output", output, "--id", "4001", samples)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Trained the dictionary 4001")
+	assert.Contains(t, out, "from 40 samples")
+
+	d, err := os.ReadFile(output)
+	require.NoError(t, err)
+	info, err := zstd.InspectDictionary(d)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4001), info.ID())
+
+	// The dictionary compresses the protobuf payloads better than zstd alone.
+	payload, err := ptraceotlp.NewExportRequestFromTraces(newZstdDictionarySampleTraces(100)).MarshalProto()
+	require.NoError(t, err)
+	plain, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	withDict, err := zstd.NewWriter(nil, zstd.WithEncoderDict(d))
+	require.NoError(t, err)
+	compressed := withDict.EncodeAll(payload, nil)
+	assert.Less(t, len(compressed), len(plain.EncodeAll(payload, nil)))
+
+	dec, err := zstd.NewReader(nil, zstd.WithDecoderDicts(d))
+	require.NoError(t, err)
+	defer dec.Close()
+	decompressed, err := dec.DecodeAll(compressed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decompressed)
+}
+
+func TestZstdDictionarySubCommandJSONEncoding(t *testing.T) {
+	samples := writeZstdDictionarySamples(t)
+	output := filepath.Join(t.TempDir(), "otlp.dict")
+
+	out, err := executeZstdDictionaryCommand("--output", output, "--encoding", "json", samples)
+	require.NoError(t, err)
+	assert.Contains(t, out, "from 40 samples")
+}
+
+func TestZstdDictionarySubCommandErrors(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "otlp.dict")
+	notOTLP := filepath.Join(dir, "not_otlp.jsonl")
+	require.NoError(t, os.WriteFile(notOTLP, []byte(`{"resourceSpans":[]}`+"\n"+`{"foo":"bar"}`+"\n"), 0o600))
+	proto := filepath.Join(dir, "request.pb")
+	payload, err := ptraceotlp.NewExportRequestFromTraces(newZstdDictionarySampleTraces(0)).MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(proto, payload, 0o600))
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "no output",
+			args:        []string{proto},
+			expectedErr: `required flag(s) "output" not set`,
+		},
+		{
+			name:        "unsupported encoding",
+			args:        []string{"--output", output, "--encoding", "yaml", proto},
+			expectedErr: `unsupported encoding "yaml"`,
+		},
+		{
+			name:        "missing file",
+			args:        []string{"--output", output, filepath.Join(dir, "missing")},
+			expectedErr: "failed to read the samples",
+		},
+		{
+			name:        "not an OTLP request",
+			args:        []string{"--output", output, notOTLP},
+			expectedErr: "line 2: not an OTLP traces, metrics or logs request",
+		},
+		{
+			name:        "protobuf samples for json",
+			args:        []string{"--output", output, "--encoding", "json", proto},
+			expectedErr: "the protobuf samples can only be used for the proto encoding",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeZstdDictionaryCommand(tt.args...)
+			require.ErrorContains(t, err, tt.expectedErr)
+			assert.NoFileExists(t, output)
+		})
+	}
+}
@@ -308,6 +308,8 @@ func generateConfig() *Config {
 
 type fakeTelemetryConfig struct {
 	Invalid bool `mapstructure:"invalid"`
+	// Generation is changed to require a restart of the service on reload.
+	Generation int `mapstructure:"generation"`
 }
 
 func (cfg fakeTelemetryConfig) Validate() error {
@@ -53,13 +53,28 @@ func NewConfigProvider(set ConfigProviderSettings) (*ConfigProvider, error) {
 //
 // Should never be called concurrently with itself, Watch or Shutdown.
 func (cm *ConfigProvider) Get(ctx context.Context, factories Factories) (*Config, error) {
+	cfg, _, err := cm.get(ctx, factories)
+	return cfg, err
+}
+
+// get returns the service configuration and the resolved configuration map it is unmarshaled from.
+func (cm *ConfigProvider) get(ctx context.Context, factories Factories) (*Config, *confmap.Conf, error) {
 	conf, err := cm.mapResolver.Resolve(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+		return nil, nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
 
-	var cfg *configSettings
-	if cfg, err = unmarshal(conf, factories); err != nil {
+	cfg, err := newConfig(conf, factories)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, conf, nil
+}
+
+// newConfig unmarshals the resolved configuration map into the service configuration.
+func newConfig(conf *confmap.Conf, factories Factories) (*Config, error) {
+	cfg, err := unmarshal(conf, factories)
+	if err != nil {
 		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
 	}
 
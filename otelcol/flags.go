@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	configFlag = "config"
+	configFlag              = "config"
+	lastKnownGoodConfigFlag = "last-known-good-config"
 )
 
 type configFlagValue struct {
@@ -49,10 +50,17 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 			return nil
 		})
 
+	flagSet.String(lastKnownGoodConfigFlag, "", "Path to the file where the last config which started successfully is"+
+		" persisted. The collector starts with it when its config fails to start. The file contains the resolved config, including its secrets.")
+
 	reg.RegisterFlags(flagSet)
 	return flagSet
 }
 
+func getLastKnownGoodConfigFlag(flagSet *flag.FlagSet) string {
+	return flagSet.Lookup(lastKnownGoodConfigFlag).Value.String()
+}
+
 func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+var errNoLastKnownGoodConfig = errors.New("no last-known-good config")
+
+// writeLastKnownGoodConfig persists the resolved configuration map in the given file. The file is replaced
+// atomically, so that it is never left partially written.
+func writeLastKnownGoodConfig(path string, conf *confmap.Conf) error {
+	content, err := yaml.Marshal(conf.ToStringMap())
+	if err != nil {
+		return fmt.Errorf("failed to marshal the last-known-good config: %w", err)
+	}
+
+	// The temporary file is created only readable by its owner, the config may contain secrets.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write the last-known-good config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write the last-known-good config: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the last-known-good config: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write the last-known-good config: %w", err)
+	}
+	return nil
+}
+
+// readLastKnownGoodConfig reads the resolved configuration map persisted in the given file. It returns
+// errNoLastKnownGoodConfig if the file does not exist.
+func readLastKnownGoodConfig(path string) (*confmap.Conf, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNoLastKnownGoodConfig
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the last-known-good config: %w", err)
+	}
+
+	var data map[string]any
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to read the last-known-good config: %w", err)
+	}
+	return confmap.NewFromStringMap(data), nil
+}
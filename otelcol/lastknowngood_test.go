@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol/internal/metadatatest"
+	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/telemetry/telemetrytest"
+)
+
+var failingType = component.MustNewType("failing")
+
+// newFailingExtensionFactory returns the factory of an extension which fails to start.
+func newFailingExtensionFactory() extension.Factory {
+	return extension.NewFactory(
+		failingType,
+		func() component.Config {
+			return &struct{}{}
+		},
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &failingExtension{}, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+}
+
+type failingExtension struct {
+	component.ShutdownFunc
+}
+
+func (*failingExtension) Start(context.Context, component.Host) error {
+	return errors.New("failed to start")
+}
+
+// newFailingConf returns the nop config with the failing extension.
+func newFailingConf(t *testing.T) map[string]any {
+	conf := newConfFromFile(t, filepath.Join("testdata", "otelcol-nop.yaml"))
+	conf["extensions"] = map[string]any{"nop": nil, "failing": nil}
+	conf["service"].(map[string]any)["extensions"] = []any{"nop", "failing"}
+	return conf
+}
+
+// newRollbackCollector returns a collector retrieving the given configs in order, one per reload.
+func newRollbackCollector(t *testing.T, tel *componenttest.Telemetry, lastKnownGoodFile string, confs ...map[string]any) (*Collector, *[]*componentstatus.Event, func()) {
+	var (
+		mu      sync.Mutex
+		watcher confmap.WatcherFunc
+		events  []*componentstatus.Event
+	)
+	fileProvider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		conf := confs[0]
+		if len(confs) > 1 {
+			confs = confs[1:]
+		}
+		return confmap.NewRetrieved(conf)
+	})
+
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	factories.Extensions[failingType] = newFailingExtensionFactory()
+	factories.Telemetry = telemetry.NewFactory(
+		func() component.Config { return fakeTelemetryConfig{} },
+		// The meter provider is shared by the services, it must not be shut down with them.
+		telemetrytest.WithMeterProvider(telemetrytest.ShutdownMeterProvider{MeterProvider: tel.NewTelemetrySettings().MeterProvider}),
+	)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:config.yaml"},
+				ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			},
+		},
+		LastKnownGoodConfigFile: lastKnownGoodFile,
+		ReportConfigStatus: func(event *componentstatus.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		},
+	})
+	require.NoError(t, err)
+	return col, &events, func() {
+		watcher(&confmap.ChangeEvent{})
+	}
+}
+
+func TestCollectorRollbackOnFailedReload(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	invalid := newConfFromFile(t, filepath.Join("testdata", "otelcol-invalid.yaml"))
+	// The second config fails validation, the third one fails to start.
+	col, events, reload := newRollbackCollector(t, tel, "",
+		newConfFromFile(t, filepath.Join("testdata", "otelcol-nop.yaml")), invalid, newFailingConf(t))
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for i := 1; i <= 2; i++ {
+		reload()
+		assert.Eventually(t, func() bool {
+			metric, err := tel.GetMetric("otelcol.config.reload.failed")
+			return err == nil && metric.Data.(metricdata.Sum[int64]).DataPoints[0].Value == int64(i)
+		}, 10*time.Second, 10*time.Millisecond)
+		assert.Eventually(t, func() bool {
+			return StateRunning == col.GetState()
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+
+	metadatatest.AssertEqualConfigReloadFailed(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	require.Len(t, *events, 3)
+	assert.Equal(t, componentstatus.StatusOK, (*events)[0].Status())
+	assert.Equal(t, componentstatus.StatusRecoverableError, (*events)[1].Status())
+	require.ErrorContains(t, (*events)[1].Err(), "invalid configuration")
+	assert.Equal(t, componentstatus.StatusRecoverableError, (*events)[2].Status())
+	require.ErrorContains(t, (*events)[2].Err(), "failed to start")
+	// The last-known-good config is still the first one.
+	assert.NotContains(t, col.lastKnownGood.cfg.Extensions, component.NewID(failingType))
+}
+
+func TestCollectorStartWithLastKnownGoodConfig(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	lastKnownGoodFile := filepath.Join(t.TempDir(), "last-known-good.yaml")
+
+	// The config starting successfully is persisted.
+	col, _, _ := newRollbackCollector(t, tel, lastKnownGoodFile, newConfFromFile(t, filepath.Join("testdata", "otelcol-nop.yaml")))
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+	col.Shutdown()
+	wg.Wait()
+
+	info, err := os.Stat(lastKnownGoodFile)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	// The collector starts with the persisted config when its config fails to start.
+	col, events, _ := newRollbackCollector(t, tel, lastKnownGoodFile, newFailingConf(t))
+	wg = startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 10*time.Second, 10*time.Millisecond)
+	col.Shutdown()
+	wg.Wait()
+
+	require.Len(t, *events, 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, (*events)[0].Status())
+	require.ErrorContains(t, (*events)[0].Err(), "failed to start")
+}
+
+func TestCollectorStartWithoutLastKnownGoodConfig(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	col, _, _ := newRollbackCollector(t, tel, filepath.Join(t.TempDir(), "last-known-good.yaml"), newFailingConf(t))
+	require.ErrorContains(t, col.Run(context.Background()), "failed to start")
+}
+
+func TestLastKnownGoodConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "last-known-good.yaml")
+	_, err := readLastKnownGoodConfig(path)
+	require.ErrorIs(t, err, errNoLastKnownGoodConfig)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"nop": map[string]any{"endpoint": "localhost:4317"}},
+	})
+	require.NoError(t, writeLastKnownGoodConfig(path, conf))
+	read, err := readLastKnownGoodConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, conf.ToStringMap(), read.ToStringMap())
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = readLastKnownGoodConfig(path)
+	require.ErrorContains(t, err, "failed to read the last-known-good config")
+
+	require.ErrorContains(t, writeLastKnownGoodConfig(filepath.Join(path, "invalid"), conf), "failed to write the last-known-good config")
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -27,6 +29,12 @@ import (
 	"go.opentelemetry.io/collector/processor"
 )
 
+var (
+	_ xconsumer.Drainable = (*tracesBatchProcessor)(nil)
+	_ xconsumer.Drainable = (*metricsBatchProcessor)(nil)
+	_ xconsumer.Drainable = (*logsBatchProcessor)(nil)
+)
+
 // errTooManyBatchers is returned when the MetadataCardinalityLimit has been reached.
 var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher metadata-value combinations"))
 
@@ -55,6 +63,9 @@ type batchProcessor[T any] struct {
 
 	// batcher will be either *singletonBatcher or *multiBatcher
 	batcher batcher[T]
+
+	// pending is the number of items held in the batches of all the shards.
+	pending atomic.Int64
 }
 
 // batcher is describes a *singletonBatcher or *multiBatcher.
@@ -172,6 +183,11 @@ func (bp *batchProcessor[T]) Start(ctx context.Context, _ component.Host) error
 	return bp.batcher.start(ctx)
 }
 
+// Pending implements xconsumer.Drainable, it returns the number of items held in the batches.
+func (bp *batchProcessor[T]) Pending() int64 {
+	return bp.pending.Load()
+}
+
 // Shutdown is invoked during service shutdown.
 func (bp *batchProcessor[T]) Shutdown(context.Context) error {
 	close(bp.shutdownC)
@@ -225,7 +241,9 @@ func (b *shard[T]) startLoop() {
 }
 
 func (b *shard[T]) processItem(item T) {
+	count := b.batch.itemCount()
 	b.batch.add(item)
+	b.processor.pending.Add(int64(b.batch.itemCount() - count))
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
 		sent = true
@@ -256,6 +274,7 @@ func (b *shard[T]) resetTimer() {
 
 func (b *shard[T]) sendItems(trigger trigger) {
 	sent, req := b.batch.split(b.processor.sendBatchMaxSize)
+	b.processor.pending.Add(-int64(sent))
 
 	bpt := b.processor.telemetry
 	var bytes int
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -425,6 +426,34 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Len(t, sink.AllTraces(), 1)
 }
 
+func TestBatchProcessorPending(t *testing.T) {
+	cfg := &Config{
+		Timeout:          time.Hour,
+		SendBatchSize:    25,
+		SendBatchMaxSize: 25,
+	}
+	sink := new(consumertest.TracesSink)
+
+	traces, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+	drainable, ok := traces.(xconsumer.Drainable)
+	require.True(t, ok)
+	assert.Zero(t, drainable.Pending())
+
+	// The items are pending until their batch is sent.
+	for range 3 {
+		require.NoError(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	}
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 25 && drainable.Pending() == 5
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, traces.Shutdown(context.Background()))
+	assert.Equal(t, 30, sink.SpanCount())
+	assert.Zero(t, drainable.Pending())
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
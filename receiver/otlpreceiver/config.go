@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -46,10 +47,63 @@ type HTTPConfig struct {
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath SanitizedURLPath `mapstructure:"logs_url_path,omitempty"`
 
+	// ErrorResponses configures how the errors returned by the pipelines are reported to the clients.
+	ErrorResponses ErrorResponsesConfig `mapstructure:"error_responses,omitempty"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
+// ErrorResponsesConfig defines the status codes and the Retry-After headers of the responses to the requests
+// failing in the pipelines. The zero values keep the status codes of the OTLP specification.
+type ErrorResponsesConfig struct {
+	// RetryableStatusCode is the status code of the retryable errors. Default is 503.
+	RetryableStatusCode int `mapstructure:"retryable_status_code,omitempty"`
+
+	// ThrottledStatusCode is the status code of the errors asking the clients to slow down, e.g. when the
+	// admission limits are exceeded. Default is 429.
+	ThrottledStatusCode int `mapstructure:"throttled_status_code,omitempty"`
+
+	// PermanentStatusCode is the status code of the permanent errors. By default, it is derived from the error.
+	PermanentStatusCode int `mapstructure:"permanent_status_code,omitempty"`
+
+	// MinRetryAfter is the Retry-After hint of the retryable and throttled errors when the downstream queue is
+	// empty or its fill level is unknown. Zero means no hint.
+	MinRetryAfter time.Duration `mapstructure:"min_retry_after,omitempty"`
+
+	// MaxRetryAfter is the Retry-After hint of the retryable and throttled errors when the downstream queue is
+	// full. The hint grows linearly from MinRetryAfter with the fill level of the queue. Zero means the hint is
+	// always MinRetryAfter.
+	MaxRetryAfter time.Duration `mapstructure:"max_retry_after,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks the error responses configuration is valid.
+func (cfg *ErrorResponsesConfig) Validate() error {
+	var errs []error
+	for _, code := range []struct {
+		name  string
+		value int
+	}{
+		{"retryable_status_code", cfg.RetryableStatusCode},
+		{"throttled_status_code", cfg.ThrottledStatusCode},
+		{"permanent_status_code", cfg.PermanentStatusCode},
+	} {
+		if code.value != 0 && (code.value < 400 || code.value > 599) {
+			errs = append(errs, fmt.Errorf("%q must be an HTTP error status code between 400 and 599, got %d", code.name, code.value))
+		}
+	}
+	if cfg.MinRetryAfter < 0 {
+		errs = append(errs, errors.New(`"min_retry_after" must be non-negative`))
+	}
+	if cfg.MaxRetryAfter != 0 && cfg.MaxRetryAfter < cfg.MinRetryAfter {
+		errs = append(errs, errors.New(`"max_retry_after" must be greater than or equal to "min_retry_after"`))
+	}
+	return errors.Join(errs...)
+}
+
 // Protocols is the configuration for the supported protocols.
 type Protocols struct {
 	GRPC configoptional.Optional[configgrpc.ServerConfig] `mapstructure:"grpc"`
@@ -58,10 +112,100 @@ type Protocols struct {
 	_ struct{}
 }
 
+// AdmissionKey defines how the clients are identified by the admission control.
+type AdmissionKey string
+
+const (
+	// AdmissionKeyClientAddress identifies the clients by their IP address.
+	AdmissionKeyClientAddress AdmissionKey = "client_address"
+	// AdmissionKeyAuth identifies the clients by an attribute of their authentication data.
+	AdmissionKeyAuth AdmissionKey = "auth"
+	// AdmissionKeyMetadata identifies the clients by a request metadata key, e.g. an HTTP header.
+	AdmissionKeyMetadata AdmissionKey = "metadata"
+)
+
+// AdmissionConfig defines the limits applied to the requests of every client.
+type AdmissionConfig struct {
+	// Key defines how the clients are identified, one of "client_address", "auth" or "metadata".
+	Key AdmissionKey `mapstructure:"key"`
+
+	// AuthAttribute is the authentication attribute identifying the clients when Key is "auth".
+	AuthAttribute string `mapstructure:"auth_attribute,omitempty"`
+
+	// MetadataKey is the metadata key identifying the clients when Key is "metadata". The metadata is read from
+	// the request headers, whether include_metadata is enabled in the protocol configuration or not.
+	MetadataKey string `mapstructure:"metadata_key,omitempty"`
+
+	// RequestsPerSecond is the maximum number of requests per second of a client. Zero means no limit.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second,omitempty"`
+
+	// BytesPerSecond is the maximum number of bytes per second of a client, measured on the uncompressed
+	// request bodies. Zero means no limit.
+	BytesPerSecond int64 `mapstructure:"bytes_per_second,omitempty"`
+
+	// MaxConcurrentRequests is the maximum number of requests of a client processed concurrently. Zero means
+	// no limit.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests,omitempty"`
+
+	// MaxClients is the maximum number of clients tracked, the limits of the least recently seen clients are
+	// reset when this number is exceeded.
+	MaxClients int `mapstructure:"max_clients"`
+
+	// IncludeClientAttribute adds the key of the client to the `client` attribute of the rejected requests
+	// metric. Every client rejected creates a new metric series.
+	IncludeClientAttribute bool `mapstructure:"include_client_attribute,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks the admission configuration is valid.
+func (cfg *AdmissionConfig) Validate() error {
+	var errs []error
+	switch cfg.Key {
+	case AdmissionKeyClientAddress:
+	case AdmissionKeyAuth:
+		if cfg.AuthAttribute == "" {
+			errs = append(errs, errors.New(`"auth_attribute" must be set when the admission key is "auth"`))
+		}
+	case AdmissionKeyMetadata:
+		if cfg.MetadataKey == "" {
+			errs = append(errs, errors.New(`"metadata_key" must be set when the admission key is "metadata"`))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid admission key %q, must be one of %q, %q or %q",
+			cfg.Key, AdmissionKeyClientAddress, AdmissionKeyAuth, AdmissionKeyMetadata))
+	}
+	if cfg.RequestsPerSecond < 0 {
+		errs = append(errs, errors.New(`"requests_per_second" must be non-negative`))
+	}
+	if cfg.BytesPerSecond < 0 {
+		errs = append(errs, errors.New(`"bytes_per_second" must be non-negative`))
+	}
+	if cfg.MaxConcurrentRequests < 0 {
+		errs = append(errs, errors.New(`"max_concurrent_requests" must be non-negative`))
+	}
+	if cfg.RequestsPerSecond == 0 && cfg.BytesPerSecond == 0 && cfg.MaxConcurrentRequests == 0 {
+		errs = append(errs, errors.New(`at least one of "requests_per_second", "bytes_per_second" or "max_concurrent_requests" must be set`))
+	}
+	if cfg.MaxClients <= 0 {
+		errs = append(errs, errors.New(`"max_clients" must be positive`))
+	}
+	return errors.Join(errs...)
+}
+
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// GRPCStreaming enables the streams used by the streaming export mode of the OTLP exporter on the gRPC
+	// server, in addition to the OTLP Export calls. The streams are a Collector protocol, not part of OTLP.
+	GRPCStreaming bool `mapstructure:"grpc_streaming,omitempty"`
+
+	// Admission configures the admission control limiting the requests of every client, on all the protocols.
+	Admission configoptional.Optional[AdmissionConfig] `mapstructure:"admission"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -73,5 +217,8 @@ func (cfg *Config) Validate() error {
 	if !cfg.GRPC.HasValue() && !cfg.HTTP.HasValue() {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.GRPCStreaming && !cfg.GRPC.HasValue() {
+		return errors.New(`"grpc_streaming" requires the gRPC protocol`)
+	}
 	return nil
 }
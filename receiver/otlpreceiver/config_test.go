@@ -4,6 +4,7 @@
 package otlpreceiver
 
 import (
+	"net/http"
 	"path/filepath"
 	"testing"
 	"time"
@@ -147,8 +148,21 @@ func TestUnmarshalConfig(t *testing.T) {
 					TracesURLPath:  "/traces",
 					MetricsURLPath: "/v2/metrics",
 					LogsURLPath:    "/log/ingest",
+					ErrorResponses: ErrorResponsesConfig{
+						RetryableStatusCode: http.StatusTooManyRequests,
+						MinRetryAfter:       time.Second,
+						MaxRetryAfter:       30 * time.Second,
+					},
 				}),
 			},
+			Admission: configoptional.Some(AdmissionConfig{
+				Key:                   AdmissionKeyMetadata,
+				MetadataKey:           "X-Tenant",
+				RequestsPerSecond:     100,
+				BytesPerSecond:        1048576,
+				MaxConcurrentRequests: 10,
+				MaxClients:            defaultAdmissionMaxClients,
+			}),
 		}, cfg)
 }
 
@@ -182,6 +196,10 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 					LogsURLPath:    defaultLogsURLPath,
 				}),
 			},
+			Admission: configoptional.Default(AdmissionConfig{
+				Key:        AdmissionKeyClientAddress,
+				MaxClients: defaultAdmissionMaxClients,
+			}),
 		}, cfg)
 }
 
@@ -247,3 +265,121 @@ func TestUnmarshalConfigEmpty(t *testing.T) {
 	require.NoError(t, confmap.New().Unmarshal(&cfg))
 	assert.EqualError(t, xconfmap.Validate(cfg), "must specify at least one protocol when using the OTLP receiver")
 }
+
+func TestValidateGRPCStreaming(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.GetOrInsertDefault()
+	cfg.GRPCStreaming = true
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.GRPC = configoptional.None[configgrpc.ServerConfig]()
+	cfg.HTTP.GetOrInsertDefault()
+	assert.EqualError(t, xconfmap.Validate(cfg), `"grpc_streaming" requires the gRPC protocol`)
+}
+
+func TestValidateAdmissionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         AdmissionConfig
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  AdmissionConfig{Key: AdmissionKeyClientAddress, RequestsPerSecond: 10, MaxClients: 10},
+		},
+		{
+			name:        "invalid key",
+			cfg:         AdmissionConfig{Key: "ip", RequestsPerSecond: 10, MaxClients: 10},
+			expectedErr: `invalid admission key "ip", must be one of "client_address", "auth" or "metadata"`,
+		},
+		{
+			name:        "missing auth attribute",
+			cfg:         AdmissionConfig{Key: AdmissionKeyAuth, RequestsPerSecond: 10, MaxClients: 10},
+			expectedErr: `"auth_attribute" must be set when the admission key is "auth"`,
+		},
+		{
+			name:        "missing metadata key",
+			cfg:         AdmissionConfig{Key: AdmissionKeyMetadata, MaxConcurrentRequests: 1, MaxClients: 10},
+			expectedErr: `"metadata_key" must be set when the admission key is "metadata"`,
+		},
+		{
+			name:        "negative limits",
+			cfg:         AdmissionConfig{Key: AdmissionKeyClientAddress, RequestsPerSecond: -1, BytesPerSecond: -1, MaxConcurrentRequests: -1, MaxClients: 10},
+			expectedErr: "\"requests_per_second\" must be non-negative\n\"bytes_per_second\" must be non-negative\n\"max_concurrent_requests\" must be non-negative",
+		},
+		{
+			name:        "no limit",
+			cfg:         AdmissionConfig{Key: AdmissionKeyClientAddress, MaxClients: 10},
+			expectedErr: `at least one of "requests_per_second", "bytes_per_second" or "max_concurrent_requests" must be set`,
+		},
+		{
+			name:        "no client",
+			cfg:         AdmissionConfig{Key: AdmissionKeyClientAddress, BytesPerSecond: 10},
+			expectedErr: `"max_clients" must be positive`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidateErrorResponsesConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ErrorResponsesConfig
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  ErrorResponsesConfig{},
+		},
+		{
+			name: "valid",
+			cfg: ErrorResponsesConfig{
+				RetryableStatusCode: http.StatusTooManyRequests,
+				ThrottledStatusCode: http.StatusServiceUnavailable,
+				PermanentStatusCode: http.StatusBadRequest,
+				MinRetryAfter:       time.Second,
+				MaxRetryAfter:       time.Minute,
+			},
+		},
+		{
+			name: "invalid status codes",
+			cfg: ErrorResponsesConfig{
+				RetryableStatusCode: http.StatusOK,
+				ThrottledStatusCode: 600,
+				PermanentStatusCode: http.StatusFound,
+			},
+			expectedErr: "\"retryable_status_code\" must be an HTTP error status code between 400 and 599, got 200\n" +
+				"\"throttled_status_code\" must be an HTTP error status code between 400 and 599, got 600\n" +
+				"\"permanent_status_code\" must be an HTTP error status code between 400 and 599, got 302",
+		},
+		{
+			name:        "negative retry after",
+			cfg:         ErrorResponsesConfig{MinRetryAfter: -time.Second},
+			expectedErr: `"min_retry_after" must be non-negative`,
+		},
+		{
+			name:        "max retry after lower than min",
+			cfg:         ErrorResponsesConfig{MinRetryAfter: time.Minute, MaxRetryAfter: time.Second},
+			expectedErr: `"max_retry_after" must be greater than or equal to "min_retry_after"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -15,13 +15,15 @@ import (
 )
 
 const (
-	pbContentType   = "application/x-protobuf"
-	jsonContentType = "application/json"
+	pbContentType     = "application/x-protobuf"
+	jsonContentType   = "application/json"
+	ndjsonContentType = "application/x-ndjson"
 )
 
 var (
-	pbEncoder = &protoEncoder{}
-	jsEncoder = &jsonEncoder{}
+	pbEncoder     = &protoEncoder{}
+	jsEncoder     = &jsonEncoder{}
+	ndjsonEncoder = &newlineDelimitedJSONEncoder{}
 )
 
 type encoder interface {
@@ -139,3 +141,9 @@ func (jsonEncoder) marshalStatus(resp *spb.Status) ([]byte, error) {
 func (jsonEncoder) contentType() string {
 	return jsonContentType
 }
+
+// newlineDelimitedJSONEncoder decodes the lines of the newline-delimited JSON requests, each of them being an OTLP
+// JSON request. The responses are encoded in JSON.
+type newlineDelimitedJSONEncoder struct {
+	jsonEncoder
+}
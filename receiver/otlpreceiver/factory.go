@@ -24,6 +24,8 @@ const (
 	defaultMetricsURLPath  = "/v1/metrics"
 	defaultLogsURLPath     = "/v1/logs"
 	defaultProfilesURLPath = "/v1development/profiles"
+
+	defaultAdmissionMaxClients = 10000
 )
 
 // NewFactory creates a new OTLP receiver factory.
@@ -63,6 +65,10 @@ func createDefaultConfig() component.Config {
 				LogsURLPath:    defaultLogsURLPath,
 			}),
 		},
+		Admission: configoptional.Default(AdmissionConfig{
+			Key:        AdmissionKeyClientAddress,
+			MaxClients: defaultAdmissionMaxClients,
+		}),
 	}
 }
 
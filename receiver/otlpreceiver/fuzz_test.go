@@ -43,14 +43,14 @@ func FuzzReceiverHandlers(f *testing.F) {
 		resp := httptest.NewRecorder()
 		switch handler % 3 {
 		case 0:
-			httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
-			handleTraces(resp, req, httpTracesReceiver)
+			httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP, nil)
+			handleTraces(resp, req, httpTracesReceiver, &ErrorResponsesConfig{})
 		case 1:
-			httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
-			handleMetrics(resp, req, httpMetricsReceiver)
+			httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP, nil)
+			handleMetrics(resp, req, httpMetricsReceiver, &ErrorResponsesConfig{})
 		case 2:
-			httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
-			handleLogs(resp, req, httpLogsReceiver)
+			httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP, nil)
+			handleLogs(resp, req, httpLogsReceiver, &ErrorResponsesConfig{})
 		}
 	})
 }
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
+)
+
+// KeyType defines how the clients are identified.
+type KeyType string
+
+const (
+	// KeyClientAddress identifies the clients by their IP address.
+	KeyClientAddress KeyType = "client_address"
+	// KeyAuth identifies the clients by an attribute of the authentication data.
+	KeyAuth KeyType = "auth"
+	// KeyMetadata identifies the clients by a metadata key, e.g. an HTTP header.
+	KeyMetadata KeyType = "metadata"
+)
+
+const (
+	reasonRequestRate = "request_rate"
+	reasonByteRate    = "byte_rate"
+	reasonConcurrency = "concurrency"
+
+	// concurrencyRetryDelay is the delay returned to the clients rejected by the concurrency limit, there is
+	// no way to know when one of their requests completes.
+	concurrencyRetryDelay = time.Second
+)
+
+// Settings define the limits applied to every client.
+type Settings struct {
+	Key KeyType
+	// Name is the authentication attribute or the metadata key identifying the clients, depending on Key.
+	Name                  string
+	RequestsPerSecond     float64
+	BytesPerSecond        int64
+	MaxConcurrentRequests int
+	MaxClients            int
+	// ClientAttribute adds the key of the client to the rejected requests metric.
+	ClientAttribute bool
+}
+
+// Controller admits the requests of every client according to its request rate, byte rate and number of
+// concurrent requests.
+type Controller struct {
+	set Settings
+	tb  *metadata.TelemetryBuilder
+
+	// mu guards clients.
+	mu      sync.Mutex
+	clients *simplelru.LRU[string, *clientLimiter]
+}
+
+// NewController returns a new Controller.
+func NewController(set Settings, tb *metadata.TelemetryBuilder) (*Controller, error) {
+	// The least recently seen clients are forgotten, their limits are reset when they come back.
+	clients, err := simplelru.NewLRU[string, *clientLimiter](set.MaxClients, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Controller{set: set, tb: tb, clients: clients}, nil
+}
+
+// Admit checks that the client identified by info can send a request of the given size, before the request is
+// read. If admitted, the returned release function must be called once the request is processed with the size
+// actually read, the difference with the admitted size is charged to the client. Otherwise, it returns a
+// ResourceExhausted status error with the delay after which the client can retry. A size of 0 admits a request
+// of unknown size as long as the client did not exceed its byte rate. A nil Controller admits all the requests.
+func (c *Controller) Admit(ctx context.Context, info client.Info, size int) (func(int), error) {
+	if c == nil {
+		return func(int) {}, nil
+	}
+	key := c.key(info)
+	c.mu.Lock()
+	limiter, ok := c.clients.Get(key)
+	if !ok {
+		limiter = c.newClientLimiter()
+		c.clients.Add(key, limiter)
+	}
+	c.mu.Unlock()
+
+	reason, delay := limiter.admit(float64(size))
+	if reason == "" {
+		var once sync.Once
+		return func(read int) {
+			once.Do(func() { limiter.release(float64(read - size)) })
+		}, nil
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("reason", reason)}
+	if c.set.ClientAttribute {
+		attrs = append(attrs, attribute.String("client", key))
+	}
+	c.tb.ReceiverOtlpAdmissionRejectedRequests.Add(ctx, 1, metric.WithAttributeSet(attribute.NewSet(attrs...)))
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("too many requests from the client %q: %s limit exceeded", key, reason)).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil, st.Err()
+}
+
+// key returns the key identifying the client. The requests without the key are all identified by the empty key.
+func (c *Controller) key(info client.Info) string {
+	switch c.set.Key {
+	case KeyClientAddress:
+		if info.Addr == nil {
+			return ""
+		}
+		if tcpAddr, ok := info.Addr.(*net.TCPAddr); ok {
+			return tcpAddr.IP.String()
+		}
+		if host, _, err := net.SplitHostPort(info.Addr.String()); err == nil {
+			return host
+		}
+		return info.Addr.String()
+	case KeyAuth:
+		if info.Auth == nil {
+			return ""
+		}
+		if value := info.Auth.GetAttribute(c.set.Name); value != nil {
+			return fmt.Sprint(value)
+		}
+		return ""
+	case KeyMetadata:
+		return strings.Join(info.Metadata.Get(c.set.Name), ",")
+	}
+	return ""
+}
+
+func (c *Controller) newClientLimiter() *clientLimiter {
+	now := time.Now()
+	return &clientLimiter{
+		requestRate:    c.set.RequestsPerSecond,
+		requestBurst:   max(c.set.RequestsPerSecond, 1),
+		byteRate:       float64(c.set.BytesPerSecond),
+		maxConcurrency: c.set.MaxConcurrentRequests,
+		requestTokens:  max(c.set.RequestsPerSecond, 1),
+		byteTokens:     float64(c.set.BytesPerSecond),
+		lastRefill:     now,
+	}
+}
+
+// clientLimiter limits the requests of a client. The rates are enforced with token buckets refilled continuously,
+// holding up to one second of tokens, or a single request for the request rates lower than one. A request larger
+// than the byte rate is admitted when the bucket is full, and the bytes of the requests whose size is only known
+// once read are charged when they are released, the next requests are rejected until the debt is paid off.
+type clientLimiter struct {
+	requestRate    float64
+	requestBurst   float64
+	byteRate       float64
+	maxConcurrency int
+
+	// mu guards everything declared below.
+	mu            sync.Mutex
+	requestTokens float64
+	byteTokens    float64
+	lastRefill    time.Time
+	inFlight      int
+}
+
+func (l *clientLimiter) admit(bytes float64) (string, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(l.lastRefill).Seconds()
+	l.lastRefill = now
+	l.requestTokens = min(l.requestBurst, l.requestTokens+elapsed*l.requestRate)
+	l.byteTokens = min(l.byteRate, l.byteTokens+elapsed*l.byteRate)
+
+	if l.maxConcurrency > 0 && l.inFlight >= l.maxConcurrency {
+		return reasonConcurrency, concurrencyRetryDelay
+	}
+	if l.requestRate > 0 && l.requestTokens < 1 {
+		return reasonRequestRate, retryDelay((1 - l.requestTokens) / l.requestRate)
+	}
+	if needed := min(bytes, l.byteRate); l.byteRate > 0 && l.byteTokens < needed {
+		return reasonByteRate, retryDelay((needed - l.byteTokens) / l.byteRate)
+	}
+
+	if l.requestRate > 0 {
+		l.requestTokens--
+	}
+	if l.byteRate > 0 {
+		l.byteTokens -= bytes
+	}
+	l.inFlight++
+	return "", 0
+}
+
+// release ends a request, charging the bytes read in addition to the admitted ones.
+func (l *clientLimiter) release(extraBytes float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	if l.byteRate > 0 {
+		l.byteTokens -= extraBytes
+	}
+}
+
+// retryDelay rounds up the delay to the second, as the HTTP Retry-After header is expressed in seconds.
+func retryDelay(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds)) * time.Second
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadatatest"
+)
+
+func newController(t *testing.T, set Settings) (*Controller, *componenttest.Telemetry) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(tb.Shutdown)
+	if set.Key == "" {
+		set.Key = KeyClientAddress
+	}
+	if set.MaxClients == 0 {
+		set.MaxClients = 10
+	}
+	c, err := NewController(set, tb)
+	require.NoError(t, err)
+	return c, tt
+}
+
+func assertRejected(t *testing.T, err error, expectedDelay time.Duration) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, expectedDelay, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+}
+
+func TestNilController(t *testing.T) {
+	var c *Controller
+	release, err := c.Admit(context.Background(), client.Info{}, 10)
+	require.NoError(t, err)
+	release(10)
+}
+
+func TestController_RequestRate(t *testing.T) {
+	c, tt := newController(t, Settings{RequestsPerSecond: 0.1, ClientAttribute: true})
+	info := client.Info{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}}
+
+	release, err := c.Admit(context.Background(), info, 10)
+	require.NoError(t, err)
+	release(10)
+
+	_, err = c.Admit(context.Background(), info, 10)
+	assertRejected(t, err, 10*time.Second)
+
+	metadatatest.AssertEqualReceiverOtlpAdmissionRejectedRequests(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value: 1,
+			Attributes: attribute.NewSet(
+				attribute.String("client", "10.0.0.1"),
+				attribute.String("reason", "request_rate")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestController_ByteRate(t *testing.T) {
+	c, tt := newController(t, Settings{BytesPerSecond: 100})
+
+	// A request larger than the byte rate is admitted when the bucket is full.
+	release, err := c.Admit(context.Background(), client.Info{}, 150)
+	require.NoError(t, err)
+	release(150)
+
+	_, err = c.Admit(context.Background(), client.Info{}, 1)
+	assertRejected(t, err, time.Second)
+
+	// The client is not identified by the metric by default.
+	metadatatest.AssertEqualReceiverOtlpAdmissionRejectedRequests(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("reason", "byte_rate")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestController_ByteRateUnknownSize(t *testing.T) {
+	c, _ := newController(t, Settings{BytesPerSecond: 100})
+
+	// The requests of unknown size are admitted until the bytes read are charged to the client.
+	release, err := c.Admit(context.Background(), client.Info{}, 0)
+	require.NoError(t, err)
+	otherRelease, err := c.Admit(context.Background(), client.Info{}, 0)
+	require.NoError(t, err)
+	release(250)
+	otherRelease(0)
+
+	_, err = c.Admit(context.Background(), client.Info{}, 0)
+	assertRejected(t, err, 2*time.Second)
+}
+
+func TestController_Concurrency(t *testing.T) {
+	c, tt := newController(t, Settings{MaxConcurrentRequests: 1})
+
+	release, err := c.Admit(context.Background(), client.Info{}, 1)
+	require.NoError(t, err)
+
+	_, err = c.Admit(context.Background(), client.Info{}, 1)
+	assertRejected(t, err, concurrencyRetryDelay)
+
+	// The request is released only once.
+	release(1)
+	release(1)
+	release, err = c.Admit(context.Background(), client.Info{}, 1)
+	require.NoError(t, err)
+	_, err = c.Admit(context.Background(), client.Info{}, 1)
+	assertRejected(t, err, concurrencyRetryDelay)
+	release(1)
+
+	metadatatest.AssertEqualReceiverOtlpAdmissionRejectedRequests(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value:      2,
+			Attributes: attribute.NewSet(attribute.String("reason", "concurrency")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+type authData map[string]any
+
+func (a authData) GetAttribute(name string) any {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestController_Key(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         Settings
+		info        client.Info
+		expectedKey string
+	}{
+		{
+			name:        "client address",
+			set:         Settings{Key: KeyClientAddress},
+			info:        client.Info{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}},
+			expectedKey: "10.0.0.1",
+		},
+		{
+			name:        "client address with port",
+			set:         Settings{Key: KeyClientAddress},
+			info:        client.Info{Addr: &net.UDPAddr{IP: net.IPv6loopback, Port: 1234}},
+			expectedKey: "::1",
+		},
+		{
+			name:        "unix socket",
+			set:         Settings{Key: KeyClientAddress},
+			info:        client.Info{Addr: &net.UnixAddr{Name: "/tmp/otlp.sock", Net: "unix"}},
+			expectedKey: "/tmp/otlp.sock",
+		},
+		{
+			name: "no client address",
+			set:  Settings{Key: KeyClientAddress},
+		},
+		{
+			name:        "auth",
+			set:         Settings{Key: KeyAuth, Name: "subject"},
+			info:        client.Info{Auth: authData{"subject": "tenant-a"}},
+			expectedKey: "tenant-a",
+		},
+		{
+			name: "missing auth attribute",
+			set:  Settings{Key: KeyAuth, Name: "subject"},
+			info: client.Info{Auth: authData{"other": "tenant-a"}},
+		},
+		{
+			name: "no auth",
+			set:  Settings{Key: KeyAuth, Name: "subject"},
+		},
+		{
+			name:        "metadata",
+			set:         Settings{Key: KeyMetadata, Name: "X-Tenant"},
+			info:        client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"tenant-a", "tenant-b"}})},
+			expectedKey: "tenant-a,tenant-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newController(t, tt.set)
+			assert.Equal(t, tt.expectedKey, c.key(tt.info))
+		})
+	}
+}
+
+func TestController_Clients(t *testing.T) {
+	c, _ := newController(t, Settings{Key: KeyMetadata, Name: "tenant", MaxConcurrentRequests: 1, MaxClients: 2})
+	infoFor := func(tenant string) client.Info {
+		return client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}})}
+	}
+
+	// Every client has its own limits.
+	_, err := c.Admit(context.Background(), infoFor("a"), 1)
+	require.NoError(t, err)
+	_, err = c.Admit(context.Background(), infoFor("b"), 1)
+	require.NoError(t, err)
+	_, err = c.Admit(context.Background(), infoFor("a"), 1)
+	assertRejected(t, err, concurrencyRetryDelay)
+
+	// The least recently seen client is forgotten when the maximum number of clients is exceeded.
+	_, err = c.Admit(context.Background(), infoFor("c"), 1)
+	require.NoError(t, err)
+	_, err = c.Admit(context.Background(), infoFor("b"), 1)
+	require.NoError(t, err)
+}
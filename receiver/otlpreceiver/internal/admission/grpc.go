@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/tap"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// GRPCClientInfo returns the client information identifying the client of a gRPC request. The metadata and the
+// address are read from the request, so they are available before the client information is set by the server
+// interceptors, and whether the metadata is included in the client information or not.
+func GRPCClientInfo(ctx context.Context) client.Info {
+	info := client.FromContext(ctx)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		info.Metadata = client.NewMetadata(md)
+	}
+	if p, ok := peer.FromContext(ctx); ok && info.Addr == nil {
+		info.Addr = p.Addr
+	}
+	return info
+}
+
+// GRPCServerOptions returns the gRPC server options admitting the unary requests of the given methods. The requests
+// are admitted when their headers are received, before their message is read, and the size of the message is
+// charged to the client once read. The authentication runs once the message is read, so the requests are admitted
+// after the authentication when the clients are identified by their authentication data.
+func (c *Controller) GRPCServerOptions(methods ...string) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.InTapHandle(func(ctx context.Context, info *tap.Info) (context.Context, error) {
+			if !slices.Contains(methods, info.FullMethodName) {
+				return ctx, nil
+			}
+			rpc := &rpcAdmission{}
+			if c.set.Key != KeyAuth {
+				release, err := c.Admit(ctx, GRPCClientInfo(ctx), 0)
+				if err != nil {
+					return nil, err
+				}
+				// The context of the stream is canceled once the request is processed, or if it is aborted.
+				context.AfterFunc(ctx, func() { release(int(rpc.size.Load())) })
+			}
+			return context.WithValue(ctx, rpcAdmissionKey{}, rpc), nil
+		}),
+		grpc.StatsHandler(payloadStatsHandler{}),
+	}
+	if c.set.Key == KeyAuth {
+		opts = append(opts, grpc.ChainUnaryInterceptor(
+			func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				rpc, ok := ctx.Value(rpcAdmissionKey{}).(*rpcAdmission)
+				if !ok {
+					return handler(ctx, req)
+				}
+				size := int(rpc.size.Load())
+				release, err := c.Admit(ctx, GRPCClientInfo(ctx), size)
+				if err != nil {
+					return nil, err
+				}
+				defer release(size)
+				return handler(ctx, req)
+			}))
+	}
+	return opts
+}
+
+type rpcAdmissionKey struct{}
+
+// rpcAdmission records the size of the message of an admitted request.
+type rpcAdmission struct {
+	size atomic.Int64
+}
+
+// payloadStatsHandler records the size of the messages received by the admitted requests.
+type payloadStatsHandler struct{}
+
+var _ stats.Handler = payloadStatsHandler{}
+
+func (payloadStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (payloadStatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	in, ok := rs.(*stats.InPayload)
+	if !ok {
+		return
+	}
+	if rpc, ok := ctx.Value(rpcAdmissionKey{}).(*rpcAdmission); ok {
+		rpc.size.Add(int64(in.Length))
+	}
+}
+
+func (payloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (payloadStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package admission
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+const healthCheckMethod = "/grpc.health.v1.Health/Check"
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	checked int
+}
+
+func (s *healthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.checked++
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func newHealthClient(t *testing.T, opts []grpc.ServerOption) (grpc_health_v1.HealthClient, *healthServer) {
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	health := &healthServer{}
+	grpc_health_v1.RegisterHealthServer(srv, health)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	return grpc_health_v1.NewHealthClient(conn), health
+}
+
+func contextWithTenant(tenant string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-tenant", tenant)
+}
+
+func TestGRPCServerOptions(t *testing.T) {
+	c, _ := newController(t, Settings{Key: KeyMetadata, Name: "X-Tenant", BytesPerSecond: 100})
+	healthClient, health := newHealthClient(t, c.GRPCServerOptions(healthCheckMethod))
+
+	// The size of the request is only known once it is read, it is charged to the client afterwards.
+	large := &grpc_health_v1.HealthCheckRequest{Service: strings.Repeat("a", 250)}
+	_, err := healthClient.Check(contextWithTenant("a"), large)
+	require.NoError(t, err)
+	// The request is released once the stream is closed, which can happen after the response is received.
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		limiter, _ := c.clients.Get("a")
+		c.mu.Unlock()
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		return limiter.inFlight == 0
+	}, time.Second, time.Millisecond)
+
+	// The request is rejected before it is read.
+	_, err = healthClient.Check(contextWithTenant("a"), large)
+	assertRejected(t, err, 2*time.Second)
+	assert.Equal(t, 1, health.checked)
+
+	// The other clients are not affected, the metadata is read from the request headers.
+	_, err = healthClient.Check(contextWithTenant("b"), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, health.checked)
+}
+
+func TestGRPCServerOptions_NotAdmittedMethod(t *testing.T) {
+	c, _ := newController(t, Settings{MaxConcurrentRequests: 1, RequestsPerSecond: 0.1})
+	healthClient, health := newHealthClient(t, c.GRPCServerOptions("/grpc.health.v1.Health/List"))
+
+	for range 3 {
+		_, err := healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, health.checked)
+}
+
+func TestGRPCServerOptions_Auth(t *testing.T) {
+	c, _ := newController(t, Settings{Key: KeyAuth, Name: "subject", RequestsPerSecond: 0.1})
+	// The authentication data is set by an interceptor, like the server authentication.
+	authenticate := grpc.ChainUnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			info := client.FromContext(ctx)
+			info.Auth = authData{"subject": strings.Join(md.Get("x-tenant"), ",")}
+			return handler(client.NewContext(ctx, info), req)
+		})
+	healthClient, health := newHealthClient(t, append([]grpc.ServerOption{authenticate}, c.GRPCServerOptions(healthCheckMethod)...))
+
+	_, err := healthClient.Check(contextWithTenant("a"), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = healthClient.Check(contextWithTenant("a"), &grpc_health_v1.HealthCheckRequest{})
+	assertRejected(t, err, 10*time.Second)
+	_, err = healthClient.Check(contextWithTenant("b"), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, health.checked)
+}
+
+func TestGRPCClientInfo(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "a"))
+	info := GRPCClientInfo(ctx)
+	assert.Equal(t, []string{"a"}, info.Metadata.Get("X-Tenant"))
+	assert.Nil(t, info.Addr)
+
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}
+	info = GRPCClientInfo(client.NewContext(ctx, client.Info{Addr: addr}))
+	assert.Equal(t, addr, info.Addr)
+	assert.Equal(t, []string{"a"}, info.Metadata.Get("X-Tenant"))
+}
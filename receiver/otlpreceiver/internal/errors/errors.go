@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 )
 
 func GetStatusFromError(err error) error {
@@ -24,9 +25,42 @@ func GetStatusFromError(err error) error {
 		}
 		s = status.New(code, err.Error())
 	}
+	if _, ok := xconsumererror.BackpressureFill(err); ok {
+		// Keep the fill level of the downstream queue for the computation of the Retry-After hint.
+		return &backpressureStatusError{status: s, err: err}
+	}
 	return s.Err()
 }
 
+// backpressureStatusError is a gRPC status error that wraps the error it was created from, so the backpressure
+// it carries can still be retrieved.
+type backpressureStatusError struct {
+	status *status.Status
+	err    error
+}
+
+func (e *backpressureStatusError) Error() string {
+	return e.status.Err().Error()
+}
+
+func (e *backpressureStatusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func (e *backpressureStatusError) Unwrap() error {
+	return e.err
+}
+
+// GetPartialSuccess returns the number of rejected items and the error message if the error is a partial error,
+// which is reported to the client as a partial success.
+func GetPartialSuccess(err error) (int64, string, bool) {
+	partial, ok := consumererror.AsPartial(err)
+	if !ok {
+		return 0, "", false
+	}
+	return partial.Rejected(), err.Error(), true
+}
+
 func GetHTTPStatusCodeFromStatus(s *status.Status) int {
 	// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md#failures
 	// to see if a code is retryable.
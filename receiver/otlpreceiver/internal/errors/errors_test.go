@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 )
 
 func Test_GetStatusFromError(t *testing.T) {
@@ -45,6 +46,18 @@ func Test_GetStatusFromError(t *testing.T) {
 	}
 }
 
+func Test_GetStatusFromErrorBackpressure(t *testing.T) {
+	err := xconsumererror.NewBackpressure(errors.New("sending queue is full"), 0.5)
+	result := GetStatusFromError(err)
+	assert.Equal(t, status.New(codes.Unavailable, "sending queue is full").Err().Error(), result.Error())
+	s, ok := status.FromError(result)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, s.Code())
+	fill, ok := xconsumererror.BackpressureFill(result)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, fill, 1e-9)
+}
+
 func Test_GetHTTPStatusCodeFromStatus(t *testing.T) {
 	tests := []struct {
 		name     string
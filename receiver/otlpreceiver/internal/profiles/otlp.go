@@ -6,8 +6,12 @@ package profiles // import "go.opentelemetry.io/collector/receiver/otlpreceiver/
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
@@ -19,13 +23,15 @@ type Receiver struct {
 	pprofileotlp.UnimplementedGRPCServer
 	nextConsumer xconsumer.Profiles
 	obsreport    *receiverhelper.ObsReport
+	admission    *admission.Controller
 }
 
 // New creates a new Receiver reference.
-func New(nextConsumer xconsumer.Profiles, obsreport *receiverhelper.ObsReport) *Receiver {
+func New(nextConsumer xconsumer.Profiles, obsreport *receiverhelper.ObsReport, admission *admission.Controller) *Receiver {
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsreport:    obsreport,
+		admission:    admission,
 	}
 }
 
@@ -48,9 +54,35 @@ func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (
 	// So, convert the error to appropriate grpc status and return the error
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
+	// Partial errors are returned as a partial success, the rest of the data was accepted.
 	if err != nil {
+		if rejected, msg, ok := errors.GetPartialSuccess(err); ok {
+			resp := pprofileotlp.NewExportResponse()
+			resp.PartialSuccess().SetRejectedProfiles(rejected)
+			resp.PartialSuccess().SetErrorMessage(msg)
+			return resp, nil
+		}
 		return pprofileotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
 
 	return pprofileotlp.NewExportResponse(), nil
 }
+
+// ExportStream implements the otlpstream.Handler of the profiles streams, the requests are admitted and
+// processed as the unary Export calls.
+func (r *Receiver) ExportStream(ctx context.Context, payload []byte) ([]byte, error) {
+	release, err := r.admission.Admit(ctx, admission.GRPCClientInfo(ctx), len(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer release(len(payload))
+	req := pprofileotlp.NewExportRequest()
+	if err = req.UnmarshalProto(payload); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := r.Export(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.MarshalProto()
+}
@@ -71,6 +71,16 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, pprofileotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := pprofileotlp.NewExportRequestFromProfiles(testdata.GenerateProfiles(2))
+
+	profileClient := makeProfileServiceClient(t, consumertest.NewErr(consumererror.NewPartial(errors.New("1 item dropped"), 1)))
+	resp, err := profileClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedProfiles())
+	assert.Equal(t, "1 item dropped", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeProfileServiceClient(t *testing.T, tc xconsumer.Profiles) pprofileotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -99,7 +109,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc xconsumer.Profiles) net.Addr {
 	})
 	require.NoError(t, err)
 
-	r := New(tc, obsreport)
+	r := New(tc, obsreport, nil)
 	// Now run it as a gRPC server
 	srv := grpc.NewServer()
 	pprofileotlp.RegisterGRPCServer(srv, r)
@@ -15,27 +15,41 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
+// The methods of the unary gRPC export requests.
+const (
+	grpcTracesMethod   = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	grpcMetricsMethod  = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	grpcLogsMethod     = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+	grpcProfilesMethod = "/opentelemetry.proto.collector.profiles.v1development.ProfilesService/Export"
+)
+
 // otlpReceiver is the type that exposes Trace and Metrics reception.
 type otlpReceiver struct {
-	cfg        *Config
-	serverGRPC *grpc.Server
-	serverHTTP *http.Server
+	cfg          *Config
+	serverGRPC   *grpc.Server
+	serverStream *otlpstream.Server
+	serverHTTP   *http.Server
+	serverHTTP3  *confighttp.HTTP3Server
 
 	nextTraces   consumer.Traces
 	nextMetrics  consumer.Metrics
@@ -46,6 +60,9 @@ type otlpReceiver struct {
 	obsrepGRPC *receiverhelper.ObsReport
 	obsrepHTTP *receiverhelper.ObsReport
 
+	telemetryBuilder *metadata.TelemetryBuilder
+	admission        *admission.Controller
+
 	settings *receiver.Settings
 }
 
@@ -82,6 +99,31 @@ func newOtlpReceiver(cfg *Config, set *receiver.Settings) (*otlpReceiver, error)
 		return nil, err
 	}
 
+	if cfg.Admission.HasValue() {
+		admissionCfg := cfg.Admission.Get()
+		r.telemetryBuilder, err = metadata.NewTelemetryBuilder(set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+		name := admissionCfg.AuthAttribute
+		if admissionCfg.Key == AdmissionKeyMetadata {
+			name = admissionCfg.MetadataKey
+		}
+		r.admission, err = admission.NewController(admission.Settings{
+			Key:                   admission.KeyType(admissionCfg.Key),
+			Name:                  name,
+			RequestsPerSecond:     admissionCfg.RequestsPerSecond,
+			BytesPerSecond:        admissionCfg.BytesPerSecond,
+			MaxConcurrentRequests: admissionCfg.MaxConcurrentRequests,
+			MaxClients:            admissionCfg.MaxClients,
+			ClientAttribute:       admissionCfg.IncludeClientAttribute,
+		}, r.telemetryBuilder)
+		if err != nil {
+			r.telemetryBuilder.Shutdown()
+			return nil, err
+		}
+	}
+
 	return r, nil
 }
 
@@ -93,24 +135,60 @@ func (r *otlpReceiver) startGRPCServer(ctx context.Context, host component.Host)
 
 	grpcCfg := r.cfg.GRPC.Get()
 	var err error
-	if r.serverGRPC, err = grpcCfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings); err != nil {
+	var opts []configgrpc.ToServerOption
+	if r.admission != nil {
+		// Only the methods registered below are admitted, the requests of the streams are admitted one by one.
+		var methods []string
+		if r.nextTraces != nil {
+			methods = append(methods, grpcTracesMethod)
+		}
+		if r.nextMetrics != nil {
+			methods = append(methods, grpcMetricsMethod)
+		}
+		if r.nextLogs != nil {
+			methods = append(methods, grpcLogsMethod)
+		}
+		if r.nextProfiles != nil {
+			methods = append(methods, grpcProfilesMethod)
+		}
+		for _, opt := range r.admission.GRPCServerOptions(methods...) {
+			opts = append(opts, configgrpc.WithGrpcServerOption(opt))
+		}
+	}
+	if r.serverGRPC, err = grpcCfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, opts...); err != nil {
 		return err
 	}
 
+	// When enabled, the signals are also served over the streams used by the streaming export mode of the OTLP
+	// exporter.
+	var streamHandlers otlpstream.Handlers
+
 	if r.nextTraces != nil {
-		ptraceotlp.RegisterGRPCServer(r.serverGRPC, trace.New(r.nextTraces, r.obsrepGRPC))
+		traceReceiver := trace.New(r.nextTraces, r.obsrepGRPC, r.admission)
+		ptraceotlp.RegisterGRPCServer(r.serverGRPC, traceReceiver)
+		streamHandlers.Traces = traceReceiver.ExportStream
 	}
 
 	if r.nextMetrics != nil {
-		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metrics.New(r.nextMetrics, r.obsrepGRPC))
+		metricsReceiver := metrics.New(r.nextMetrics, r.obsrepGRPC, r.admission)
+		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metricsReceiver)
+		streamHandlers.Metrics = metricsReceiver.ExportStream
 	}
 
 	if r.nextLogs != nil {
-		plogotlp.RegisterGRPCServer(r.serverGRPC, logs.New(r.nextLogs, r.obsrepGRPC))
+		logsReceiver := logs.New(r.nextLogs, r.obsrepGRPC, r.admission)
+		plogotlp.RegisterGRPCServer(r.serverGRPC, logsReceiver)
+		streamHandlers.Logs = logsReceiver.ExportStream
 	}
 
 	if r.nextProfiles != nil {
-		pprofileotlp.RegisterGRPCServer(r.serverGRPC, profiles.New(r.nextProfiles, r.obsrepGRPC))
+		profilesReceiver := profiles.New(r.nextProfiles, r.obsrepGRPC, r.admission)
+		pprofileotlp.RegisterGRPCServer(r.serverGRPC, profilesReceiver)
+		streamHandlers.Profiles = profilesReceiver.ExportStream
+	}
+
+	if r.cfg.GRPCStreaming {
+		r.serverStream = otlpstream.RegisterServer(r.serverGRPC, streamHandlers)
 	}
 
 	var gln net.Listener
@@ -136,35 +214,36 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	httpCfg := r.cfg.HTTP.Get()
 	httpMux := http.NewServeMux()
 	if r.nextTraces != nil {
-		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
+		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP, r.admission)
 		httpMux.HandleFunc(string(httpCfg.TracesURLPath), func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, httpTracesReceiver)
+			handleTraces(resp, req, httpTracesReceiver, &httpCfg.ErrorResponses)
 		})
 	}
 
 	if r.nextMetrics != nil {
-		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
+		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP, r.admission)
 		httpMux.HandleFunc(string(httpCfg.MetricsURLPath), func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, httpMetricsReceiver)
+			handleMetrics(resp, req, httpMetricsReceiver, &httpCfg.ErrorResponses)
 		})
 	}
 
 	if r.nextLogs != nil {
-		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
+		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP, r.admission)
 		httpMux.HandleFunc(string(httpCfg.LogsURLPath), func(resp http.ResponseWriter, req *http.Request) {
-			handleLogs(resp, req, httpLogsReceiver)
+			handleLogs(resp, req, httpLogsReceiver, &httpCfg.ErrorResponses)
 		})
 	}
 
 	if r.nextProfiles != nil {
-		httpProfilesReceiver := profiles.New(r.nextProfiles, r.obsrepHTTP)
+		httpProfilesReceiver := profiles.New(r.nextProfiles, r.obsrepHTTP, r.admission)
 		httpMux.HandleFunc(defaultProfilesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleProfiles(resp, req, httpProfilesReceiver)
+			handleProfiles(resp, req, httpProfilesReceiver, &httpCfg.ErrorResponses)
 		})
 	}
 
+	handler := admitHTTP(httpMux, r.admission, &httpCfg.ErrorResponses)
 	var err error
-	if r.serverHTTP, err = httpCfg.ServerConfig.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+	if r.serverHTTP, err = httpCfg.ServerConfig.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, handler, confighttp.WithErrorHandler(errorHandler)); err != nil {
 		return err
 	}
 
@@ -172,6 +251,10 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if hln, err = httpCfg.ServerConfig.ToListener(ctx); err != nil {
 		return err
 	}
+	// The HTTP/3 server wraps the handler of the HTTP server, so it is created before serving HTTP.
+	if r.serverHTTP3, err = httpCfg.ServerConfig.ToHTTP3Server(ctx, r.serverHTTP); err != nil {
+		return errors.Join(err, hln.Close())
+	}
 	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", hln.Addr().String()))
 
 	r.shutdownWG.Go(func() {
@@ -179,6 +262,15 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
 		}
 	})
+
+	if r.serverHTTP3 != nil {
+		r.settings.Logger.Info("Starting HTTP/3 server", zap.String("endpoint", r.serverHTTP3.Addr().String()))
+		r.shutdownWG.Go(func() {
+			if errHTTP3 := r.serverHTTP3.Serve(); errHTTP3 != nil && !errors.Is(errHTTP3, http.ErrServerClosed) {
+				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP3))
+			}
+		})
+	}
 	return nil
 }
 
@@ -205,12 +297,22 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 	if r.serverHTTP != nil {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
+	if r.serverHTTP3 != nil {
+		err = errors.Join(err, r.serverHTTP3.Shutdown(ctx))
+	}
 
 	if r.serverGRPC != nil {
+		if r.serverStream != nil {
+			// The streams would block the graceful stop until the clients close them.
+			r.serverStream.Shutdown()
+		}
 		r.serverGRPC.GracefulStop()
 	}
 
 	r.shutdownWG.Wait()
+	if r.telemetryBuilder != nil {
+		r.telemetryBuilder.Shutdown()
+	}
 	return err
 }
 
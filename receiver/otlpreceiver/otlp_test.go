@@ -13,6 +13,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -41,6 +43,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -51,6 +54,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadatatest"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
@@ -184,7 +188,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -193,7 +197,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -226,7 +230,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -235,7 +239,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -268,7 +272,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid content type",
@@ -277,7 +281,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf, application/x-ndjson]",
 		},
 		{
 			name:        "invalid request",
@@ -417,6 +421,43 @@ func TestProtoHTTP(t *testing.T) {
 	}
 }
 
+func TestProtoHTTPPartialSuccess(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	sink.SetConsumeError(consumererror.NewPartial(errors.New("2 items dropped"), 2))
+	for _, dr := range generateDataRequests(t) {
+		respBytes := doHTTPRequest(t, "http://"+addr+dr.path, "", "application/x-protobuf", dr.protoBytes, http.StatusOK)
+		// The partial success has the same encoding in the responses of all the signals.
+		tr := ptraceotlp.NewExportResponse()
+		require.NoError(t, tr.UnmarshalProto(respBytes))
+		assert.Equal(t, int64(2), tr.PartialSuccess().RejectedSpans(), dr.path)
+		assert.Equal(t, "2 items dropped", tr.PartialSuccess().ErrorMessage(), dr.path)
+	}
+}
+
+func TestProtoHTTPPartialSuccessFanout(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	// One pipeline of the fanout partially accepted the data, the other one failed: the client must retry.
+	sink.SetConsumeError(errors.Join(
+		consumererror.NewPartial(errors.New("2 items dropped"), 2),
+		errors.New("exporter unavailable")))
+	for _, dr := range generateDataRequests(t) {
+		respBytes := doHTTPRequest(t, "http://"+addr+dr.path, "", "application/x-protobuf", dr.protoBytes, http.StatusServiceUnavailable)
+		errStatus := &spb.Status{}
+		require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+		assert.Equal(t, int32(codes.Unavailable), errStatus.Code, dr.path)
+	}
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -747,6 +788,147 @@ func TestOTLPReceiverGRPCTracesIngestTest(t *testing.T) {
 	assertReceiverTraces(t, tt, otlpReceiverID, "grpc", int64(expectedReceivedBatches), int64(expectedIngestionBlockedRPCs))
 }
 
+// TestOTLPReceiverGRPCStreamsIngestTest checks that the requests sent over the streams are processed, and
+// acknowledged with the same status codes, as the unary Export calls.
+func TestOTLPReceiverGRPCStreamsIngestTest(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	td := testdata.GenerateTraces(1)
+	payload, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.GetOrInsertDefault().NetAddr.Endpoint = addr
+	cfg.GRPCStreaming = true
+	recv := newReceiver(t, tt.NewTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NotNil(t, recv)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	client := otlpstream.NewClient(cc, otlpstream.TracesMethod, 0)
+	defer client.Close()
+
+	for _, consumeErr := range []error{nil, errors.New("consumer error"), consumererror.NewPermanent(errors.New("consumer error")), nil} {
+		sink.SetConsumeError(consumeErr)
+		var expectedCode codes.Code
+		switch {
+		case consumeErr == nil:
+			expectedCode = codes.OK
+		case consumererror.IsPermanent(consumeErr):
+			expectedCode = codes.Internal
+		default:
+			expectedCode = codes.Unavailable
+		}
+
+		respPayload, err := client.Export(context.Background(), "", payload)
+		assert.Equal(t, expectedCode, status.Code(err))
+		if err == nil {
+			require.NoError(t, ptraceotlp.NewExportResponse().UnmarshalProto(respPayload))
+		}
+	}
+
+	// The requests that cannot be decoded are rejected.
+	_, err = otlpstream.NewClient(cc, otlpstream.LogsMethod, 0).Export(context.Background(), "", payload)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The unary Export calls are still served by the same server.
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllTraces(), 3)
+	assertReceiverTraces(t, tt, otlpReceiverID, "grpc", 3, 2)
+
+	// The open stream does not block the shutdown.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, recv.Shutdown(ctx))
+}
+
+// TestOTLPReceiverGRPCStreamsDisabled checks that the streams are rejected unless they are enabled.
+func TestOTLPReceiverGRPCStreamsDisabled(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	payload, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1)).MarshalProto()
+	require.NoError(t, err)
+
+	recv := newGRPCReceiver(t, componenttest.NewNopTelemetrySettings(), addr, consumertest.NewNop())
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	client := otlpstream.NewClient(cc, otlpstream.TracesMethod, 0)
+	defer client.Close()
+
+	_, err = client.Export(context.Background(), "", payload)
+	require.ErrorIs(t, err, otlpstream.ErrUnimplemented)
+}
+
+func TestOTLPReceiverAdmission(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	td := testdata.GenerateTraces(1)
+
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.GetOrInsertDefault().NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.GetOrInsertDefault().ServerConfig.NetAddr.Endpoint = httpAddr
+	// The client can send a request every 10 seconds, the limits are shared by all the protocols.
+	cfg.Admission.GetOrInsertDefault().RequestsPerSecond = 0.1
+	cfg.Admission.Get().IncludeClientAttribute = true
+
+	sink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	recv := newReceiver(t, tt.NewTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	require.NoError(t, exportTraces(cc, td))
+
+	err = exportTraces(cc, td)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, 10*time.Second, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	pbBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+httpAddr+defaultTracesURLPath, bytes.NewReader(pbBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", pbContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "10", resp.Header.Get("Retry-After"))
+
+	require.Len(t, sink.AllTraces(), 1)
+	metadatatest.AssertEqualReceiverOtlpAdmissionRejectedRequests(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value: 2,
+			Attributes: attribute.NewSet(
+				attribute.String("client", "127.0.0.1"),
+				attribute.String("reason", "request_rate")),
+		},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
 // TestOTLPReceiverHTTPTracesIngestTest checks that the HTTP trace receiver
 // is returning the proper response (return and metrics) when the next consumer
 // in the pipeline reports error. The test changes the responses returned by the
@@ -933,6 +1115,60 @@ func TestHTTPInvalidTLSCredentials(t *testing.T) {
 		`failed to load TLS config: failed to load TLS cert and key: for auth via TLS, provide both certificate and key, or neither`)
 }
 
+func TestHTTP3(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := &Config{
+		Protocols: Protocols{
+			HTTP: configoptional.Some(HTTPConfig{
+				ServerConfig: confighttp.ServerConfig{
+					NetAddr: confignet.AddrConfig{
+						Endpoint:  addr,
+						Transport: confignet.TransportTypeTCP,
+					},
+					TLS: configoptional.Some(configtls.ServerConfig{
+						Config: configtls.Config{
+							CertFile: filepath.Join("testdata", "server.crt"),
+							KeyFile:  filepath.Join("testdata", "server.key"),
+						},
+					}),
+					HTTP3: configoptional.Some(confighttp.HTTP3Config{}),
+				},
+				TracesURLPath:  defaultTracesURLPath,
+				MetricsURLPath: defaultMetricsURLPath,
+				LogsURLPath:    defaultLogsURLPath,
+			}),
+		},
+	}
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	// The HTTP/3 server listens on the UDP port of the endpoint.
+	cc := confighttp.NewDefaultClientConfig()
+	cc.Endpoint = "https://" + addr
+	cc.TLS = configtls.ClientConfig{
+		Config:     configtls.Config{CAFile: filepath.Join("testdata", "ca.crt")},
+		ServerName: "localhost",
+	}
+	cc.HTTP3 = configoptional.Some(confighttp.HTTP3Config{})
+	client, err := cc.ToClient(context.Background(), nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer client.CloseIdleConnections()
+
+	td := testdata.GenerateTraces(2)
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	resp, err := client.Post(cc.Endpoint+defaultTracesURLPath, pbContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/3.0", resp.Proto)
+	sink.checkData(t, td, 1)
+}
+
 func testHTTPMaxRequestBodySize(t *testing.T, path, contentType string, payload []byte, size, expectedStatusCode int) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	url := "http://" + addr + path
@@ -1274,6 +1510,8 @@ type errOrSinkConsumer struct {
 	*consumertest.ProfilesSink
 	mu           sync.Mutex
 	consumeError error // to be returned by ConsumeTraces, if set
+	// consumeErrorAfter is the number of calls succeeding before consumeError is returned.
+	consumeErrorAfter int
 }
 
 func newErrOrSinkConsumer() *errOrSinkConsumer {
@@ -1292,6 +1530,23 @@ func (esc *errOrSinkConsumer) SetConsumeError(err error) {
 	esc.consumeError = err
 }
 
+// SetConsumeErrorAfter sets an error that will be returned by the Consume function once it succeeded n times.
+func (esc *errOrSinkConsumer) SetConsumeErrorAfter(err error, n int) {
+	esc.mu.Lock()
+	defer esc.mu.Unlock()
+	esc.consumeError = err
+	esc.consumeErrorAfter = n
+}
+
+// nextConsumeError returns the error of the next Consume call, must be called with the lock held.
+func (esc *errOrSinkConsumer) nextConsumeError() error {
+	if esc.consumeErrorAfter > 0 {
+		esc.consumeErrorAfter--
+		return nil
+	}
+	return esc.consumeError
+}
+
 func (esc *errOrSinkConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
@@ -1301,8 +1556,8 @@ func (esc *errOrSinkConsumer) ConsumeTraces(ctx context.Context, td ptrace.Trace
 	esc.mu.Lock()
 	defer esc.mu.Unlock()
 
-	if esc.consumeError != nil {
-		return esc.consumeError
+	if err := esc.nextConsumeError(); err != nil {
+		return err
 	}
 
 	return esc.TracesSink.ConsumeTraces(ctx, td)
@@ -1313,8 +1568,8 @@ func (esc *errOrSinkConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Met
 	esc.mu.Lock()
 	defer esc.mu.Unlock()
 
-	if esc.consumeError != nil {
-		return esc.consumeError
+	if err := esc.nextConsumeError(); err != nil {
+		return err
 	}
 
 	return esc.MetricsSink.ConsumeMetrics(ctx, md)
@@ -1325,8 +1580,8 @@ func (esc *errOrSinkConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) err
 	esc.mu.Lock()
 	defer esc.mu.Unlock()
 
-	if esc.consumeError != nil {
-		return esc.consumeError
+	if err := esc.nextConsumeError(); err != nil {
+		return err
 	}
 
 	return esc.LogsSink.ConsumeLogs(ctx, ld)
@@ -1337,8 +1592,8 @@ func (esc *errOrSinkConsumer) ConsumeProfiles(ctx context.Context, md pprofile.P
 	esc.mu.Lock()
 	defer esc.mu.Unlock()
 
-	if esc.consumeError != nil {
-		return esc.consumeError
+	if err := esc.nextConsumeError(); err != nil {
+		return err
 	}
 
 	return esc.ProfilesSink.ConsumeProfiles(ctx, md)
@@ -1350,6 +1605,7 @@ func (esc *errOrSinkConsumer) Reset() {
 	defer esc.mu.Unlock()
 
 	esc.consumeError = nil
+	esc.consumeErrorAfter = 0
 	esc.TracesSink.Reset()
 	esc.MetricsSink.Reset()
 	esc.LogsSink.Reset()
@@ -6,6 +6,7 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 import (
 	"fmt"
 	"io"
+	"math"
 	"mime"
 	"net/http"
 	"strconv"
@@ -13,7 +14,10 @@ import (
 
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/internal/statusutil"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
@@ -26,11 +30,15 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, errCfg *ErrorResponsesConfig) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
+	if enc == ndjsonEncoder {
+		handleTracesNDJSON(resp, req, tracesReceiver, errCfg)
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -45,7 +53,7 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 
 	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, enc, err, http.StatusInternalServerError)
+		writeExportError(resp, enc, err, errCfg)
 		return
 	}
 
@@ -57,11 +65,15 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, errCfg *ErrorResponsesConfig) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
+	if enc == ndjsonEncoder {
+		handleMetricsNDJSON(resp, req, metricsReceiver, errCfg)
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -76,7 +88,7 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 
 	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, enc, err, http.StatusInternalServerError)
+		writeExportError(resp, enc, err, errCfg)
 		return
 	}
 
@@ -88,11 +100,15 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, errCfg *ErrorResponsesConfig) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
+	if enc == ndjsonEncoder {
+		handleLogsNDJSON(resp, req, logsReceiver, errCfg)
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -107,7 +123,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 
 	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, enc, err, http.StatusInternalServerError)
+		writeExportError(resp, enc, err, errCfg)
 		return
 	}
 
@@ -119,11 +135,15 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver) {
+func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver, errCfg *ErrorResponsesConfig) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
+	if enc == ndjsonEncoder {
+		handleProfilesNDJSON(resp, req, profilesReceiver, errCfg)
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -138,7 +158,7 @@ func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceive
 
 	otlpResp, err := profilesReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, enc, err, http.StatusInternalServerError)
+		writeExportError(resp, enc, err, errCfg)
 		return
 	}
 
@@ -150,6 +170,45 @@ func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceive
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
+// admitHTTP wraps the handler with the admission control, the requests are admitted before their body is read.
+// The requests are admitted with their content length when known, and the size of the body read is charged to
+// the client once processed.
+func admitHTTP(next http.Handler, ctrl *admission.Controller, errCfg *ErrorResponsesConfig) http.Handler {
+	if ctrl == nil {
+		return next
+	}
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		info := client.FromContext(req.Context())
+		// The headers are read directly, whether they are included in the client information or not.
+		info.Metadata = client.NewMetadata(req.Header)
+		release, err := ctrl.Admit(req.Context(), info, int(max(req.ContentLength, 0)))
+		if err != nil {
+			var enc encoder = jsEncoder
+			if getMimeTypeFromContentType(req.Header.Get("Content-Type")) == pbContentType {
+				enc = pbEncoder
+			}
+			writeExportError(resp, enc, err, errCfg)
+			return
+		}
+		body := &countingReadCloser{ReadCloser: req.Body}
+		req.Body = body
+		defer func() { release(int(body.read)) }()
+		next.ServeHTTP(resp, req)
+	})
+}
+
+// countingReadCloser counts the bytes read from a request body.
+type countingReadCloser struct {
+	io.ReadCloser
+	read int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.read += int64(n)
+	return n, err
+}
+
 func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool) {
 	if req.Method != http.MethodPost {
 		handleUnmatchedMethod(resp)
@@ -161,6 +220,8 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 		return pbEncoder, true
 	case jsonContentType:
 		return jsEncoder, true
+	case ndjsonContentType:
+		return ndjsonEncoder, true
 	default:
 		handleUnmatchedContentType(resp)
 		return nil, false
@@ -191,6 +252,51 @@ func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode in
 	writeStatusResponse(w, encoder, statusCode, s)
 }
 
+// writeExportError encodes an error returned by the pipelines like writeError, with the status code and the
+// Retry-After header configured by the error responses.
+func writeExportError(w http.ResponseWriter, encoder encoder, err error, errCfg *ErrorResponsesConfig) {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = statusutil.NewStatusFromMsgAndHTTPCode(err.Error(), http.StatusInternalServerError)
+	}
+	statusCode := errors.GetHTTPStatusCodeFromStatus(s)
+	retryable := true
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		if errCfg.ThrottledStatusCode != 0 {
+			statusCode = errCfg.ThrottledStatusCode
+		}
+	case statusCode == http.StatusServiceUnavailable:
+		if errCfg.RetryableStatusCode != 0 {
+			statusCode = errCfg.RetryableStatusCode
+		}
+	default:
+		retryable = false
+		if errCfg.PermanentStatusCode != 0 {
+			statusCode = errCfg.PermanentStatusCode
+		}
+	}
+	if retryable {
+		if retryInfo := statusutil.GetRetryInfo(s); retryInfo != nil {
+			// Set like writeStatusResponse does, which only sets it for the default status codes.
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(retryInfo.GetRetryDelay().AsDuration()/time.Second), 10))
+		} else if retryAfter := getRetryAfterHint(err, errCfg); retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+		}
+	}
+	writeStatusResponse(w, encoder, statusCode, s)
+}
+
+// getRetryAfterHint returns the delay the client should wait before retrying when the pipeline did not request
+// one, growing with the fill level of the downstream queue.
+func getRetryAfterHint(err error, errCfg *ErrorResponsesConfig) time.Duration {
+	fill, ok := xconsumererror.BackpressureFill(err)
+	if !ok || errCfg.MaxRetryAfter == 0 {
+		return errCfg.MinRetryAfter
+	}
+	return errCfg.MinRetryAfter + time.Duration(fill*float64(errCfg.MaxRetryAfter-errCfg.MinRetryAfter))
+}
+
 // errorHandler encodes the HTTP error message inside a rpc.Status message as required
 // by the OTLP protocol.
 func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusCode int) {
@@ -203,7 +309,7 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	case pbContentType:
 		writeStatusResponse(w, pbEncoder, statusCode, s)
 		return
-	case jsonContentType:
+	case jsonContentType, ndjsonContentType:
 		writeStatusResponse(w, jsEncoder, statusCode, s)
 		return
 	}
@@ -257,5 +363,5 @@ func handleUnmatchedMethod(resp http.ResponseWriter) {
 
 func handleUnmatchedContentType(resp http.ResponseWriter) {
 	hst := http.StatusUnsupportedMediaType
-	writeResponse(resp, "text/plain", hst, fmt.Appendf(nil, "%v unsupported media type, supported: [%s, %s]", hst, jsonContentType, pbContentType))
+	writeResponse(resp, "text/plain", hst, fmt.Appendf(nil, "%v unsupported media type, supported: [%s, %s, %s]", hst, jsonContentType, pbContentType, ndjsonContentType))
 }
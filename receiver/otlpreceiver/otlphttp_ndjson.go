@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+)
+
+func handleTracesNDJSON(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, errCfg *ErrorResponsesConfig) {
+	otlpResp := ptraceotlp.NewExportResponse()
+	ok := exportNDJSONLines(resp, req, errCfg, ndjsonEncoder.unmarshalTracesRequest, func(otlpReq ptraceotlp.ExportRequest) error {
+		lineResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return err
+		}
+		ps := otlpResp.PartialSuccess()
+		ps.SetRejectedSpans(ps.RejectedSpans() + lineResp.PartialSuccess().RejectedSpans())
+		if msg := lineResp.PartialSuccess().ErrorMessage(); msg != "" {
+			ps.SetErrorMessage(msg)
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := ndjsonEncoder.marshalTracesResponse(otlpResp)
+	if err != nil {
+		writeError(resp, ndjsonEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, ndjsonEncoder.contentType(), http.StatusOK, msg)
+}
+
+func handleMetricsNDJSON(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, errCfg *ErrorResponsesConfig) {
+	otlpResp := pmetricotlp.NewExportResponse()
+	ok := exportNDJSONLines(resp, req, errCfg, ndjsonEncoder.unmarshalMetricsRequest, func(otlpReq pmetricotlp.ExportRequest) error {
+		lineResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return err
+		}
+		ps := otlpResp.PartialSuccess()
+		ps.SetRejectedDataPoints(ps.RejectedDataPoints() + lineResp.PartialSuccess().RejectedDataPoints())
+		if msg := lineResp.PartialSuccess().ErrorMessage(); msg != "" {
+			ps.SetErrorMessage(msg)
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := ndjsonEncoder.marshalMetricsResponse(otlpResp)
+	if err != nil {
+		writeError(resp, ndjsonEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, ndjsonEncoder.contentType(), http.StatusOK, msg)
+}
+
+func handleLogsNDJSON(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, errCfg *ErrorResponsesConfig) {
+	otlpResp := plogotlp.NewExportResponse()
+	ok := exportNDJSONLines(resp, req, errCfg, ndjsonEncoder.unmarshalLogsRequest, func(otlpReq plogotlp.ExportRequest) error {
+		lineResp, err := logsReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return err
+		}
+		ps := otlpResp.PartialSuccess()
+		ps.SetRejectedLogRecords(ps.RejectedLogRecords() + lineResp.PartialSuccess().RejectedLogRecords())
+		if msg := lineResp.PartialSuccess().ErrorMessage(); msg != "" {
+			ps.SetErrorMessage(msg)
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := ndjsonEncoder.marshalLogsResponse(otlpResp)
+	if err != nil {
+		writeError(resp, ndjsonEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, ndjsonEncoder.contentType(), http.StatusOK, msg)
+}
+
+func handleProfilesNDJSON(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver, errCfg *ErrorResponsesConfig) {
+	otlpResp := pprofileotlp.NewExportResponse()
+	ok := exportNDJSONLines(resp, req, errCfg, ndjsonEncoder.unmarshalProfilesRequest, func(otlpReq pprofileotlp.ExportRequest) error {
+		lineResp, err := profilesReceiver.Export(req.Context(), otlpReq)
+		if err != nil {
+			return err
+		}
+		ps := otlpResp.PartialSuccess()
+		ps.SetRejectedProfiles(ps.RejectedProfiles() + lineResp.PartialSuccess().RejectedProfiles())
+		if msg := lineResp.PartialSuccess().ErrorMessage(); msg != "" {
+			ps.SetErrorMessage(msg)
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+
+	msg, err := ndjsonEncoder.marshalProfilesResponse(otlpResp)
+	if err != nil {
+		writeError(resp, ndjsonEncoder, err, http.StatusInternalServerError)
+		return
+	}
+	writeResponse(resp, ndjsonEncoder.contentType(), http.StatusOK, msg)
+}
+
+// exportNDJSONLines reads the body one line at a time, and decodes and exports every non-empty line, so the body
+// is never buffered as a whole. It stops at the first error, the lines before it are already exported, and writes
+// the error, prefixed by the line number. Once a line is exported, the errors are reported as permanent, as
+// retrying the request would export the previous lines again.
+func exportNDJSONLines[T any](resp http.ResponseWriter, req *http.Request, errCfg *ErrorResponsesConfig, unmarshal func([]byte) (T, error), export func(T) error) bool {
+	reader := bufio.NewReader(req.Body)
+	exported := false
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeError(resp, ndjsonEncoder, fmt.Errorf("line %d: %w", lineNum, err), http.StatusBadRequest)
+			return false
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			otlpReq, unmarshalErr := unmarshal(line)
+			if unmarshalErr != nil {
+				writeError(resp, ndjsonEncoder, fmt.Errorf("line %d: %w", lineNum, unmarshalErr), http.StatusBadRequest)
+				return false
+			}
+			if exportErr := export(otlpReq); exportErr != nil {
+				if exported {
+					s, _ := status.FromError(exportErr)
+					exportErr = status.Errorf(codes.InvalidArgument, "%s, the previous lines were exported and the request must not be retried", s.Message())
+				}
+				writeExportError(resp, ndjsonEncoder, fmt.Errorf("line %d: %w", lineNum, exportErr), errCfg)
+				return false
+			}
+			exported = true
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	if err := req.Body.Close(); err != nil {
+		writeError(resp, ndjsonEncoder, err, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
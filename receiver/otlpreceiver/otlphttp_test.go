@@ -5,8 +5,10 @@ package otlpreceiver
 
 import (
 	"context"
+	stderrors "errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -17,13 +19,18 @@ import (
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/admission"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
 )
 
 func TestHTTPRetryAfter(t *testing.T) {
@@ -138,3 +145,242 @@ func TestHTTPRetryAfter(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPNDJSON(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	tests := []struct {
+		name               string
+		body               func(line []byte) string
+		err                error
+		errAfter           int
+		expectedStatusCode int
+		expectedMessage    string
+		expectedCount      int
+	}{
+		{
+			name: "Success",
+			body: func(line []byte) string {
+				return string(line) + "\n\n" + string(line) + "\n" + string(line)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCount:      3,
+		},
+		{
+			name: "InvalidLine",
+			body: func(line []byte) string {
+				return string(line) + "\n{\"invalid\n" + string(line) + "\n"
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "line 2: ",
+			expectedCount:      1,
+		},
+		{
+			name: "ConsumerError",
+			body: func(line []byte) string {
+				return string(line) + "\n"
+			},
+			err:                status.New(codes.Unavailable, "unavailable").Err(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedMessage:    "line 1: ",
+		},
+		{
+			// The first line is exported, retrying the request would duplicate it.
+			name: "ConsumerErrorAfterExportedLine",
+			body: func(line []byte) string {
+				return string(line) + "\n" + string(line) + "\n" + string(line) + "\n"
+			},
+			err:                status.New(codes.Unavailable, "unavailable").Err(),
+			errAfter:           1,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "line 2: rpc error: code = InvalidArgument desc = unavailable, the previous lines were exported and the request must not be retried",
+			expectedCount:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dr := range generateDataRequests(t) {
+				sink.Reset()
+				sink.SetConsumeErrorAfter(tt.err, tt.errAfter)
+
+				req := createHTTPRequest(t, "http://"+addr+dr.path, "", "application/x-ndjson", []byte(tt.body(dr.jsonBytes)))
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				respBytes, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+
+				assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				if tt.expectedMessage != "" {
+					errStatus := &spb.Status{}
+					require.NoError(t, protojson.Unmarshal(respBytes, errStatus))
+					assert.Contains(t, errStatus.Message, tt.expectedMessage)
+				}
+				if tt.err == nil || tt.errAfter > 0 {
+					sink.checkData(t, dr.data, tt.expectedCount)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPErrorResponses(t *testing.T) {
+	throttled, err := status.New(codes.ResourceExhausted, "throttled").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(7 * time.Second),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		errCfg             ErrorResponsesConfig
+		err                error
+		expectedStatusCode int
+		expectedRetryAfter string
+	}{
+		{
+			name:               "DefaultRetryable",
+			err:                xconsumererror.NewBackpressure(stderrors.New("sending queue is full"), 0.5),
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:               "RetryableStatusCode",
+			errCfg:             ErrorResponsesConfig{RetryableStatusCode: http.StatusTooManyRequests},
+			err:                stderrors.New("unavailable"),
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:               "ThrottledStatusCode",
+			errCfg:             ErrorResponsesConfig{ThrottledStatusCode: http.StatusServiceUnavailable},
+			err:                throttled.Err(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedRetryAfter: "7",
+		},
+		{
+			name:               "PermanentStatusCode",
+			errCfg:             ErrorResponsesConfig{PermanentStatusCode: http.StatusUnprocessableEntity},
+			err:                consumererror.NewPermanent(stderrors.New("rejected")),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "MinRetryAfterWithoutFillLevel",
+			errCfg:             ErrorResponsesConfig{MinRetryAfter: 2 * time.Second, MaxRetryAfter: 30 * time.Second},
+			err:                stderrors.New("unavailable"),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:               "RetryAfterFromFillLevel",
+			errCfg:             ErrorResponsesConfig{MinRetryAfter: 2 * time.Second, MaxRetryAfter: 12 * time.Second},
+			err:                xconsumererror.NewBackpressure(stderrors.New("sending queue is full"), 0.75),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedRetryAfter: "10",
+		},
+		{
+			name:               "RetryInfoTakesPrecedence",
+			errCfg:             ErrorResponsesConfig{MinRetryAfter: 2 * time.Second, MaxRetryAfter: 12 * time.Second},
+			err:                throttled.Err(),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "7",
+		},
+		{
+			name:               "NoRetryAfterForPermanentErrors",
+			errCfg:             ErrorResponsesConfig{MinRetryAfter: 2 * time.Second},
+			err:                consumererror.NewPermanent(stderrors.New("rejected")),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			cfg := createDefaultConfig().(*Config)
+			httpCfg := cfg.HTTP.GetOrInsertDefault()
+			httpCfg.ServerConfig.NetAddr.Endpoint = addr
+			httpCfg.ErrorResponses = tt.errCfg
+			sink := newErrOrSinkConsumer()
+			sink.SetConsumeError(tt.err)
+			recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+			require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+			for _, contentType := range []string{"application/x-protobuf", "application/x-ndjson"} {
+				dr := generateTracesRequest(t)
+				body := dr.protoBytes
+				if contentType == "application/x-ndjson" {
+					body = dr.jsonBytes
+				}
+				req := createHTTPRequest(t, "http://"+addr+dr.path, "", contentType, body)
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				_, err = io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+
+				assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, tt.expectedRetryAfter, resp.Header.Get("Retry-After"))
+			}
+		})
+	}
+}
+
+// failingReader fails the test if the body of a rejected request is read.
+type failingReader struct {
+	t *testing.T
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	assert.Fail(r.t, "the body must not be read")
+	return 0, io.EOF
+}
+
+func TestAdmitHTTP(t *testing.T) {
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(tb.Shutdown)
+	ctrl, err := admission.NewController(admission.Settings{
+		Key:            admission.KeyMetadata,
+		Name:           "X-Tenant",
+		BytesPerSecond: 100,
+		MaxClients:     10,
+	}, tb)
+	require.NoError(t, err)
+
+	var served int
+	handler := admitHTTP(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		served++
+		_, err := io.Copy(io.Discard, req.Body)
+		assert.NoError(t, err)
+		resp.WriteHeader(http.StatusOK)
+	}), ctrl, &ErrorResponsesConfig{})
+
+	newRequest := func(tenant string, body io.Reader) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, defaultTracesURLPath, body)
+		req.Header.Set("Content-Type", pbContentType)
+		// The metadata is read from the headers, whether they are included in the client information or not.
+		req.Header.Set("X-Tenant", tenant)
+		return req
+	}
+
+	// The size of a body of unknown length is charged once read.
+	req := newRequest("a", strings.NewReader(strings.Repeat("a", 250)))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// The request is rejected before its body is read.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest("a", failingReader{t: t}))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Equal(t, pbContentType, rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest("b", strings.NewReader("b")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, served)
+}
@@ -181,7 +181,12 @@ func (rec *ObsReport) endOp(
 	numAccepted := numReceivedItems
 	numRefused := 0
 	numFailedErrors := 0
-	if err != nil {
+	if partial, ok := consumererror.AsPartial(err); ok {
+		// The rejected items of a partial error are refused, the rest of the data was accepted.
+		numRefused = min(int(partial.Rejected()), numReceivedItems)
+		numAccepted = numReceivedItems - numRefused
+		err = nil
+	} else if err != nil {
 		numAccepted = 0
 		// If gate is enabled, we distinguish between refused and failed.
 		if metadata.ReceiverhelperNewReceiverMetricsFeatureGate.IsEnabled() {
@@ -532,6 +532,68 @@ func TestReceiveProfilesOp(t *testing.T) {
 	}
 }
 
+func TestReceivePartialOp(t *testing.T) {
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+
+		// Only the rejected items of a partial error are refused, the rest of the data is accepted.
+		partialErr := consumererror.NewPartial(errFake, 3)
+		rec.EndTracesOp(rec.StartTracesOp(context.Background()), format, 10, partialErr)
+		rec.EndMetricsOp(rec.StartMetricsOp(context.Background()), format, 10, partialErr)
+		rec.EndLogsOp(rec.StartLogsOp(context.Background()), format, 10, partialErr)
+		rec.EndProfilesOp(rec.StartProfilesOp(context.Background()), format, 10, partialErr)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 4)
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: internal.AcceptedSpansKey, Value: attribute.Int64Value(7)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: internal.RefusedSpansKey, Value: attribute.Int64Value(3)})
+		for _, span := range spans {
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		}
+
+		attrs := attribute.NewSet(
+			attribute.String(internal.ReceiverKey, receiverID.String()),
+			attribute.String(internal.TransportKey, transport))
+		accepted := []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 7}}
+		refused := []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}}
+		opts := []metricdatatest.Option{metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars()}
+		metadatatest.AssertEqualReceiverAcceptedSpans(t, tt, accepted, opts...)
+		metadatatest.AssertEqualReceiverRefusedSpans(t, tt, refused, opts...)
+		metadatatest.AssertEqualReceiverAcceptedMetricPoints(t, tt, accepted, opts...)
+		metadatatest.AssertEqualReceiverRefusedMetricPoints(t, tt, refused, opts...)
+		metadatatest.AssertEqualReceiverAcceptedLogRecords(t, tt, accepted, opts...)
+		metadatatest.AssertEqualReceiverRefusedLogRecords(t, tt, refused, opts...)
+		metadatatest.AssertEqualReceiverAcceptedProfileSamples(t, tt, accepted, opts...)
+		metadatatest.AssertEqualReceiverRefusedProfileSamples(t, tt, refused, opts...)
+	})
+}
+
+func TestReceivePartialFanoutOp(t *testing.T) {
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+
+		// A pipeline of the fanout failed, the data is not accepted even if another pipeline partially accepted it.
+		fanoutErr := errors.Join(consumererror.NewPartial(errFake, 3), consumererror.NewDownstream(errFake))
+		rec.EndTracesOp(rec.StartTracesOp(context.Background()), format, 10, fanoutErr)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: internal.AcceptedSpansKey, Value: attribute.Int64Value(0)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: internal.RefusedSpansKey, Value: attribute.Int64Value(10)})
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
 func TestReceiveWithLongLivedCtx(t *testing.T) {
 	originalState := metadata.ReceiverhelperNewReceiverMetricsFeatureGate.IsEnabled()
 	t.Cleanup(func() {
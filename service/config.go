@@ -4,6 +4,9 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
@@ -20,6 +23,27 @@ type Config struct {
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
 
+	// Shutdown is the configuration of the shutdown of the service.
+	Shutdown ShutdownConfig `mapstructure:"shutdown,omitempty"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
+
+// ShutdownConfig defines how the service is shut down.
+type ShutdownConfig struct {
+	// DrainTimeout is the maximum duration to wait, once the receivers are stopped, for the data pending in
+	// the other components to be sent before stopping them. The data is not drained if DrainTimeout is zero.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks that the shutdown configuration is valid.
+func (cfg *ShutdownConfig) Validate() error {
+	if cfg.DrainTimeout < 0 {
+		return errors.New("drain_timeout must not be negative")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,15 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`references processor "nop" multiple times`),
 		},
+		{
+			name: "negative-drain-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.DrainTimeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("shutdown: drain_timeout must not be negative"),
+		},
 		{
 			name: "invalid-telemetry-config",
 			cfgFn: func() *Config {
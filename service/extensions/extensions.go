@@ -34,12 +34,17 @@ type Extensions struct {
 	instanceIDs  map[component.ID]*componentstatus.InstanceID
 	extensionIDs []component.ID // start order (and reverse stop order)
 	reporter     status.Reporter
+	// reused are the extensions reused from the previous Extensions on reload, which are already started.
+	reused map[component.ID]struct{}
 }
 
 // Start starts all extensions.
 func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	bes.telemetry.Logger.Info("Starting extensions...")
 	for _, extID := range bes.extensionIDs {
+		if _, reused := bes.reused[extID]; reused {
+			continue
+		}
 		extLogger := componentattribute.LoggerWithAttributes(bes.telemetry.Logger,
 			attribute.Extension(extID).Set().ToSlice())
 		extLogger.Info("Extension is starting...")
@@ -66,9 +71,23 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 
 // Shutdown stops all extensions.
 func (bes *Extensions) Shutdown(ctx context.Context) error {
+	return bes.shutdown(ctx, nil)
+}
+
+// ShutdownRetired stops the extensions which are not reused by next, the Extensions returned by Reload.
+func (bes *Extensions) ShutdownRetired(ctx context.Context, next *Extensions) error {
+	return bes.shutdown(ctx, next)
+}
+
+func (bes *Extensions) shutdown(ctx context.Context, next *Extensions) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
 	for _, extID := range slices.Backward(bes.extensionIDs) {
+		if next != nil {
+			if _, reused := next.reused[extID]; reused {
+				continue
+			}
+		}
 		instanceID := bes.instanceIDs[extID]
 		ext := bes.extMap[extID]
 		bes.reporter.ReportStatus(
@@ -197,12 +216,54 @@ func WithReporter(reporter status.Reporter) Option {
 
 // New creates a new Extensions from Config.
 func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Extensions, error) {
+	return build(ctx, set, cfg, nil, map[component.ID]struct{}{}, options...)
+}
+
+// Reload creates a new Extensions from Config, like New, reusing the running extensions of bes whose
+// configuration is unchanged, according to unchanged, and whose dependencies are all reused.
+//
+// Start only starts the extensions of the returned Extensions which are not reused, and ShutdownRetired shuts down
+// the extensions of bes which are not reused. The Extensions bes are left unchanged, so they keep running if Reload
+// fails.
+func (bes *Extensions) Reload(ctx context.Context, set Settings, cfg Config, unchanged func(component.ID) bool, options ...Option) (*Extensions, error) {
+	reused := make(map[component.ID]struct{})
+	// The extensions are ordered after their dependencies.
+	for _, extID := range bes.extensionIDs {
+		if !slices.Contains(cfg, extID) || !unchanged(extID) {
+			continue
+		}
+		if dep, ok := bes.extMap[extID].(extensioncapabilities.Dependent); ok {
+			if slices.ContainsFunc(dep.Dependencies(), func(depID component.ID) bool {
+				_, ok := reused[depID]
+				return !ok
+			}) {
+				continue
+			}
+		}
+		reused[extID] = struct{}{}
+	}
+	return build(ctx, set, cfg, bes, reused, options...)
+}
+
+// Reused returns the IDs of the extensions reused from the previous Extensions.
+func (bes *Extensions) Reused() []component.ID {
+	var ids []component.ID
+	for _, extID := range bes.extensionIDs {
+		if _, reused := bes.reused[extID]; reused {
+			ids = append(ids, extID)
+		}
+	}
+	return ids
+}
+
+func build(ctx context.Context, set Settings, cfg Config, prev *Extensions, reused map[component.ID]struct{}, options ...Option) (*Extensions, error) {
 	exts := &Extensions{
 		telemetry:    set.Telemetry,
 		extMap:       make(map[component.ID]extension.Extension),
 		instanceIDs:  make(map[component.ID]*componentstatus.InstanceID),
 		extensionIDs: make([]component.ID, 0, len(cfg)),
 		reporter:     status.NewNopStatusReporter(),
+		reused:       reused,
 	}
 
 	for _, opt := range options {
@@ -210,6 +271,11 @@ func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Ext
 	}
 
 	for _, extID := range cfg {
+		if _, ok := reused[extID]; ok {
+			exts.extMap[extID] = prev.extMap[extID]
+			exts.instanceIDs[extID] = prev.instanceIDs[extID]
+			continue
+		}
 		instanceID := componentstatus.NewInstanceID(extID, component.KindExtension)
 		extSet := extension.Settings{
 			ID:                extID,
@@ -204,6 +204,84 @@ func (tc testOrderCase) testOrdering(t *testing.T) {
 	}
 }
 
+func TestReload(t *testing.T) {
+	var started, stopped []string
+	recordingExtensionFactory := newRecordingExtensionFactory(func(set extension.Settings, _ component.Host) error {
+		started = append(started, set.ID.String())
+		return nil
+	}, func(set extension.Settings) error {
+		stopped = append(stopped, set.ID.String())
+		return nil
+	})
+	settings := func(cfgs map[string]recordingExtensionConfig) (Settings, Config) {
+		extCfgs := make(map[component.ID]component.Config)
+		var extIDs Config
+		for name, cfg := range cfgs {
+			extID := component.NewIDWithName(recordingExtensionFactory.Type(), name)
+			extCfgs[extID] = cfg
+			extIDs = append(extIDs, extID)
+		}
+		return Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			Extensions: builders.NewExtension(
+				extCfgs,
+				map[component.Type]extension.Factory{
+					recordingExtensionFactory.Type(): recordingExtensionFactory,
+				}),
+		}, extIDs
+	}
+
+	// bar -> foo, baz
+	set, cfg := settings(map[string]recordingExtensionConfig{
+		"foo": {},
+		"bar": {dependencies: []string{"foo"}},
+		"baz": {},
+	})
+	prev, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, prev.Start(context.Background(), componenttest.NewNopHost()))
+	assert.ElementsMatch(t, []string{"recording/foo", "recording/bar", "recording/baz"}, started)
+
+	// foo is changed, so is bar which depends on it, baz is removed and qux is added.
+	started = nil
+	set, cfg = settings(map[string]recordingExtensionConfig{
+		"foo": {},
+		"bar": {dependencies: []string{"foo"}},
+		"qux": {},
+	})
+	next, err := prev.Reload(context.Background(), set, cfg, func(id component.ID) bool {
+		return id.Name() != "foo"
+	})
+	require.NoError(t, err)
+	assert.Empty(t, next.Reused())
+	require.NoError(t, prev.ShutdownRetired(context.Background(), next))
+	assert.Less(t, slices.Index(stopped, "recording/bar"), slices.Index(stopped, "recording/foo"))
+	assert.ElementsMatch(t, []string{"recording/foo", "recording/bar", "recording/baz"}, stopped)
+	require.NoError(t, next.Start(context.Background(), componenttest.NewNopHost()))
+	assert.ElementsMatch(t, []string{"recording/foo", "recording/bar", "recording/qux"}, started)
+
+	// Only qux is changed.
+	started, stopped = nil, nil
+	last, err := next.Reload(context.Background(), set, cfg, func(id component.ID) bool {
+		return id.Name() != "qux"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []component.ID{
+		component.MustNewIDWithName("recording", "foo"),
+		component.MustNewIDWithName("recording", "bar"),
+	}, last.Reused())
+	assert.Same(t, next.GetExtensions()[component.MustNewIDWithName("recording", "foo")], last.GetExtensions()[component.MustNewIDWithName("recording", "foo")])
+	require.NoError(t, next.ShutdownRetired(context.Background(), last))
+	assert.Equal(t, []string{"recording/qux"}, stopped)
+	require.NoError(t, last.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, []string{"recording/qux"}, started)
+
+	stopped = nil
+	require.NoError(t, last.Shutdown(context.Background()))
+	assert.ElementsMatch(t, []string{"recording/foo", "recording/bar", "recording/qux"}, stopped)
+}
+
 func TestNotifyConfig(t *testing.T) {
 	notificationError := errors.New("Error processing config")
 	nopExtensionFactory := extensiontest.NewNopFactory()
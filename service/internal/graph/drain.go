@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+const (
+	// drainPollInterval is the interval at which the pending data of a component is checked while draining it.
+	drainPollInterval = 10 * time.Millisecond
+	// drainLogInterval is the interval at which the progress of the drain is logged.
+	drainLogInterval = time.Second
+)
+
+// DrainAndShutdownAll stops all components like ShutdownAll, but stops the receivers first so that no new data is
+// accepted, and waits up to drainTimeout for the components implementing xconsumer.Drainable, e.g. the exporters
+// with an in-memory sending queue or the batch processor, to send their pending data before stopping them. The
+// components are stopped with their pending data once the timeout is reached.
+func (g *Graph) DrainAndShutdownAll(ctx context.Context, reporter status.Reporter, drainTimeout time.Duration) error {
+	return g.shutdown(ctx, reporter, nil, drainTimeout)
+}
+
+// receiverRank orders the receivers before the other nodes.
+func receiverRank(node graph.Node) int {
+	if _, ok := node.(*receiverNode); ok {
+		return 0
+	}
+	return 1
+}
+
+// drain waits until the component of the node has no pending data, or until the deadline is reached.
+func (g *Graph) drain(ctx context.Context, instanceID *componentstatus.InstanceID, node graph.Node, deadline time.Time) {
+	var comp component.Component
+	switch n := node.(type) {
+	case *processorNode:
+		comp = n.Component
+	case *exporterNode:
+		comp = n.Component
+	case *connectorNode:
+		comp = n.Component
+	default:
+		return
+	}
+	drainable, ok := comp.(xconsumer.Drainable)
+	if !ok {
+		return
+	}
+	pending := drainable.Pending()
+	if pending == 0 {
+		return
+	}
+
+	logger := g.telemetry.Logger.With(
+		zap.String("type", strings.ToLower(instanceID.Kind().String())),
+		zap.String("id", instanceID.ComponentID().String()),
+	)
+	logger.Info("Draining component...", zap.Int64("pending", pending))
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	lastLog := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warn("Shutdown canceled before the component is drained, its pending data may be lost",
+				zap.Int64("pending", drainable.Pending()))
+			return
+		case <-timer.C:
+			logger.Warn("Drain timeout reached, the pending data of the component may be lost",
+				zap.Int64("pending", drainable.Pending()))
+			return
+		case now := <-ticker.C:
+			if pending = drainable.Pending(); pending == 0 {
+				logger.Info("Component drained.")
+				return
+			}
+			if now.Sub(lastLog) >= drainLogInterval {
+				logger.Info("Draining component...", zap.Int64("pending", pending))
+				lastLog = now
+			}
+		}
+	}
+}
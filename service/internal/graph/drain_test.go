@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var drainableType = component.MustNewType("drainable")
+
+// drainableExporter is an exporter holding pending data, which is sent at each call to Pending if sending is set.
+type drainableExporter struct {
+	component.StartFunc
+	consumer.ConsumeTracesFunc
+	pending atomic.Int64
+	sending bool
+	// onShutdown is called with the pending data when the exporter is shut down.
+	onShutdown func(pending int64)
+}
+
+var _ xconsumer.Drainable = (*drainableExporter)(nil)
+
+func (e *drainableExporter) Pending() int64 {
+	if e.sending && e.pending.Load() > 0 {
+		return e.pending.Add(-1)
+	}
+	return e.pending.Load()
+}
+
+func (*drainableExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (e *drainableExporter) Shutdown(context.Context) error {
+	e.onShutdown(e.pending.Load())
+	return nil
+}
+
+func newDrainGraph(t *testing.T, logger *zap.Logger, exp *drainableExporter) *Graph {
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = logger
+	g, err := Build(context.Background(), Settings{
+		Telemetry: set,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.NewID(testcomponents.ExampleReceiverFactory.Type()): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(nil, nil),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.NewID(drainableType): &struct{}{},
+			},
+			map[component.Type]exporter.Factory{
+				drainableType: exporter.NewFactory(drainableType, func() component.Config { return &struct{}{} },
+					exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+						return exp, nil
+					}, component.StabilityLevelDevelopment)),
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{component.NewID(testcomponents.ExampleReceiverFactory.Type())},
+				Exporters: []component.ID{component.NewID(drainableType)},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), &Host{Reporter: statusReporter()}))
+	return g
+}
+
+func TestGraphDrainAndShutdownAll(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	exp := &drainableExporter{sending: true}
+	exp.pending.Store(5)
+	var receiverStopped bool
+	g := newDrainGraph(t, zap.New(core), exp)
+	exp.onShutdown = func(pending int64) {
+		assert.Equal(t, int64(0), pending)
+		receiverStopped = g.getReceivers()[pipeline.SignalTraces][component.NewID(testcomponents.ExampleReceiverFactory.Type())].(*testcomponents.ExampleReceiver).Stopped()
+	}
+
+	require.NoError(t, g.DrainAndShutdownAll(context.Background(), statusReporter(), time.Minute))
+	assert.True(t, receiverStopped)
+	assert.Equal(t, 1, logs.FilterMessage("Draining component...").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Component drained.").Len())
+}
+
+func TestGraphDrainAndShutdownAllTimeout(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	exp := &drainableExporter{}
+	exp.pending.Store(5)
+	var shutdownPending int64
+	exp.onShutdown = func(pending int64) {
+		shutdownPending = pending
+	}
+	g := newDrainGraph(t, zap.New(core), exp)
+
+	require.NoError(t, g.DrainAndShutdownAll(context.Background(), statusReporter(), 50*time.Millisecond))
+	// The exporter is shut down with its pending data once the timeout is reached.
+	assert.Equal(t, int64(5), shutdownPending)
+	warnings := logs.FilterMessage("Drain timeout reached, the pending data of the component may be lost")
+	require.Equal(t, 1, warnings.Len())
+	assert.Equal(t, int64(5), warnings.All()[0].ContextMap()["pending"])
+	assert.Equal(t, "drainable", warnings.All()[0].ContextMap()["id"])
+}
+
+func TestGraphShutdownAllWithoutDrain(t *testing.T) {
+	exp := &drainableExporter{sending: true}
+	exp.pending.Store(5)
+	var shutdownPending int64
+	exp.onShutdown = func(pending int64) {
+		shutdownPending = pending
+	}
+	g := newDrainGraph(t, zap.NewNop(), exp)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), statusReporter()))
+	assert.Equal(t, int64(5), shutdownPending)
+}
+
+func statusReporter() status.Reporter {
+	return status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})
+}
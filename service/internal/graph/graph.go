@@ -10,6 +10,8 @@
 // [Graph.StartAll] starts all components in each pipeline.
 //
 // [Graph.ShutdownAll] stops all components in each pipeline.
+//
+// [Graph.DrainAndShutdownAll] stops all components in each pipeline, after draining the data they hold.
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
@@ -18,6 +20,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -67,16 +70,30 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*componentstatus.InstanceID
 
+	// Keep track of the nodes reused from the previous graph on reload, which are already started.
+	reused map[int64]struct{}
+
 	telemetry component.TelemetrySettings
 }
 
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
+	pipelines, err := newGraph(set)
+	if err != nil {
+		return nil, err
+	}
+	err = pipelines.buildComponents(ctx, set)
+	return pipelines, err
+}
+
+// newGraph creates the nodes and the edges of the pipeline graph, without building the components.
+func newGraph(set Settings) (*Graph, error) {
 	pipelines := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		reused:         make(map[int64]struct{}),
 		telemetry:      set.Telemetry,
 	}
 	for pipelineID := range set.PipelineConfigs {
@@ -89,8 +106,7 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		return nil, err
 	}
 	pipelines.createEdges()
-	err := pipelines.buildComponents(ctx, set)
-	return pipelines, err
+	return pipelines, nil
 }
 
 // Creates a node for each instance of a component and adds it to the graph.
@@ -298,6 +314,9 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	}
 
 	for _, node := range slices.Backward(nodes) {
+		if _, ok := g.reused[node.ID()]; ok {
+			continue
+		}
 		switch n := node.(type) {
 		case *receiverNode:
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
@@ -420,6 +439,10 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 			// Skip capabilities/fanout nodes
 			continue
 		}
+		if _, reused := g.reused[node.ID()]; reused {
+			// Skip the nodes reused from the previous graph, already started
+			continue
+		}
 
 		instanceID := g.instanceIDs[node.ID()]
 		host.Reporter.ReportStatus(
@@ -448,11 +471,27 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 }
 
 func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error {
+	return g.shutdown(ctx, reporter, nil, 0)
+}
+
+// shutdown stops the components of the graph, except the ones kept by the next graph, if any. If drainTimeout is
+// positive, the receivers are stopped first and the other components are drained before being stopped.
+func (g *Graph) shutdown(ctx context.Context, reporter status.Reporter, next *Graph, drainTimeout time.Duration) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return err
 	}
 
+	var deadline time.Time
+	if drainTimeout > 0 {
+		// The receivers are moved first, keeping the topological order, so that no data enters the
+		// pipelines while the other components are drained.
+		slices.SortStableFunc(nodes, func(a, b graph.Node) int {
+			return receiverRank(a) - receiverRank(b)
+		})
+		deadline = time.Now().Add(drainTimeout)
+	}
+
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
@@ -466,8 +505,17 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 			// Skip capabilities/fanout nodes
 			continue
 		}
+		if next != nil {
+			if _, reused := next.reused[node.ID()]; reused {
+				// Skip the nodes reused by the next graph
+				continue
+			}
+		}
 
 		instanceID := g.instanceIDs[node.ID()]
+		if drainTimeout > 0 {
+			g.drain(ctx, instanceID, node, deadline)
+		}
 		reporter.ReportStatus(
 			instanceID,
 			componentstatus.NewEvent(componentstatus.StatusStopping),
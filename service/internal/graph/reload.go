@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"slices"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+// Reload builds the pipeline graph of the given settings, like Build, reusing the running components of g
+// which are not affected by the changes. A component is reused when its configuration is unchanged, according to
+// unchanged, it belongs to the same pipelines, and all the components it sends data to are reused, as they are
+// bound to it when it is built. The components of the same ID are all reused or all rebuilt, since they may share
+// their state, e.g. a server listening on a port.
+//
+// StartAll only starts the components of the returned graph which are not reused, and ShutdownRetired shuts down
+// the components of g which are not reused. The graph g is left unchanged, so it keeps running if Reload fails.
+func (g *Graph) Reload(ctx context.Context, set Settings, unchanged func(component.Kind, component.ID) bool) (*Graph, error) {
+	next, err := newGraph(set)
+	if err != nil {
+		return nil, err
+	}
+	if err = next.reuseNodes(g, unchanged); err != nil {
+		return nil, err
+	}
+	err = next.buildComponents(ctx, set)
+	return next, err
+}
+
+// ShutdownRetired stops the components of g which are not reused by next, the graph returned by Reload.
+func (g *Graph) ShutdownRetired(ctx context.Context, reporter status.Reporter, next *Graph) error {
+	return g.shutdown(ctx, reporter, next, 0)
+}
+
+// Reused returns the number of component instances reused from the previous graph.
+func (g *Graph) Reused() int {
+	count := 0
+	for id := range g.reused {
+		if _, ok := g.componentGraph.Node(id).(component.Component); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// reuseNodes finds the nodes of g which can reuse the built nodes of prev, and copies them.
+func (g *Graph) reuseNodes(prev *Graph, unchanged func(component.Kind, component.ID) bool) error {
+	nodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
+	}
+
+	// Nodes are discarded until a fixed point is reached: discarding a node discards the nodes sending data to it,
+	// and the other nodes of the same receiver, exporter or connector. The processors are not shared between the
+	// pipelines.
+	reusable := make(map[int64]bool, len(nodes))
+	for _, node := range nodes {
+		reusable[node.ID()] = g.canReuse(prev, node, unchanged)
+	}
+	nodesByComponent, prevNodesByComponent := componentNodes(g), componentNodes(prev)
+	for changed := true; changed; {
+		changed = false
+		for _, node := range slices.Backward(nodes) {
+			if !reusable[node.ID()] {
+				continue
+			}
+			for nexts := g.componentGraph.From(node.ID()); nexts.Next(); {
+				if !reusable[nexts.Node().ID()] {
+					reusable[node.ID()] = false
+					changed = true
+					break
+				}
+			}
+		}
+		for key, ids := range nodesByComponent {
+			if !slices.Equal(ids, prevNodesByComponent[key]) || slices.ContainsFunc(ids, func(id int64) bool { return !reusable[id] }) {
+				for _, id := range ids {
+					if reusable[id] {
+						reusable[id] = false
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if !reusable[node.ID()] {
+			continue
+		}
+		copyNode(node, prev.componentGraph.Node(node.ID()))
+		if instanceID, ok := prev.instanceIDs[node.ID()]; ok {
+			// The components report their status with the instance ID they were started with.
+			g.instanceIDs[node.ID()] = instanceID
+		}
+		g.reused[node.ID()] = struct{}{}
+	}
+	return nil
+}
+
+// canReuse returns whether the node can reuse the node of the same ID in prev, regardless of the nodes it sends
+// data to.
+func (g *Graph) canReuse(prev *Graph, node graph.Node, unchanged func(component.Kind, component.ID) bool) bool {
+	prevNode := prev.componentGraph.Node(node.ID())
+	if prevNode == nil {
+		return false
+	}
+	if !slices.Equal(nextIDs(g, node.ID()), nextIDs(prev, node.ID())) {
+		return false
+	}
+	instanceID, ok := g.instanceIDs[node.ID()]
+	if !ok {
+		// Capabilities and fanout nodes have no configuration.
+		return true
+	}
+	return unchanged(instanceID.Kind(), instanceID.ComponentID()) &&
+		slices.Equal(pipelineIDs(instanceID), pipelineIDs(prev.instanceIDs[node.ID()]))
+}
+
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+// componentNodes returns the sorted IDs of the nodes of every receiver, exporter and connector of the graph.
+func componentNodes(g *Graph) map[componentKey][]int64 {
+	nodes := make(map[componentKey][]int64)
+	for id, instanceID := range g.instanceIDs {
+		if instanceID.Kind() == component.KindProcessor {
+			continue
+		}
+		key := componentKey{kind: instanceID.Kind(), id: instanceID.ComponentID()}
+		nodes[key] = append(nodes[key], id)
+	}
+	for _, ids := range nodes {
+		slices.Sort(ids)
+	}
+	return nodes
+}
+
+// nextIDs returns the sorted IDs of the nodes the given node sends data to.
+func nextIDs(g *Graph, nodeID int64) []int64 {
+	var ids []int64
+	for nexts := g.componentGraph.From(nodeID); nexts.Next(); {
+		ids = append(ids, nexts.Node().ID())
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// pipelineIDs returns the sorted pipelines of the instance ID.
+func pipelineIDs(instanceID *componentstatus.InstanceID) []string {
+	var ids []string
+	instanceID.AllPipelineIDs(func(id pipeline.ID) bool {
+		ids = append(ids, id.String())
+		return true
+	})
+	slices.Sort(ids)
+	return ids
+}
+
+// copyNode copies the built components and consumers of the node prev into the node of the same ID.
+func copyNode(node, prev graph.Node) {
+	switch n := node.(type) {
+	case *receiverNode:
+		*n = *prev.(*receiverNode)
+	case *processorNode:
+		*n = *prev.(*processorNode)
+	case *exporterNode:
+		*n = *prev.(*exporterNode)
+	case *connectorNode:
+		*n = *prev.(*connectorNode)
+	case *capabilitiesNode:
+		*n = *prev.(*capabilitiesNode)
+	case *fanOutNode:
+		*n = *prev.(*fanOutNode)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var (
+	reloadR1 = component.MustNewIDWithName("examplereceiver", "1")
+	reloadR2 = component.MustNewIDWithName("examplereceiver", "2")
+	reloadP1 = component.MustNewIDWithName("exampleprocessor", "1")
+	reloadP2 = component.MustNewIDWithName("exampleprocessor", "2")
+	reloadE1 = component.MustNewIDWithName("exampleexporter", "1")
+	reloadE2 = component.MustNewIDWithName("exampleexporter", "2")
+	reloadC1 = component.MustNewIDWithName("exampleconnector", "1")
+
+	reloadTraces1 = pipeline.NewIDWithName(pipeline.SignalTraces, "1")
+	reloadTraces2 = pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	reloadMetrics = pipeline.NewID(pipeline.SignalMetrics)
+)
+
+// reloadConfig is the configuration of the test components, the example components are shared by configuration
+// and the configurations of the settings of each graph must be distinct.
+type reloadConfig struct {
+	id component.ID
+}
+
+func reloadSettings(pipelineConfigs pipelines.Config) Settings {
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				reloadR1: &reloadConfig{id: reloadR1},
+				reloadR2: &reloadConfig{id: reloadR2},
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				reloadP1: &reloadConfig{id: reloadP1},
+				reloadP2: &reloadConfig{id: reloadP2},
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				reloadE1: &reloadConfig{id: reloadE1},
+				reloadE2: &reloadConfig{id: reloadE2},
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				reloadC1: &reloadConfig{id: reloadC1},
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			},
+		),
+		PipelineConfigs: pipelineConfigs,
+	}
+}
+
+// graphComponents returns the components of the graph by node ID.
+func graphComponents(g *Graph) map[int64]component.Component {
+	comps := map[int64]component.Component{}
+	for nodes := g.componentGraph.Nodes(); nodes.Next(); {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			comps[n.ID()] = n.Component
+		case *processorNode:
+			comps[n.ID()] = n.Component
+		case *exporterNode:
+			comps[n.ID()] = n.Component
+		case *connectorNode:
+			comps[n.ID()] = n.Component
+		}
+	}
+	return comps
+}
+
+func TestGraphReload(t *testing.T) {
+	basePipelines := pipelines.Config{
+		reloadTraces1: {
+			Receivers:  []component.ID{reloadR1},
+			Processors: []component.ID{reloadP1},
+			Exporters:  []component.ID{reloadE1},
+		},
+		reloadTraces2: {
+			Receivers:  []component.ID{reloadR2},
+			Processors: []component.ID{reloadP2},
+			Exporters:  []component.ID{reloadE2},
+		},
+		reloadMetrics: {
+			Receivers:  []component.ID{reloadR1},
+			Processors: []component.ID{reloadP1},
+			Exporters:  []component.ID{reloadE1},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		pipelines pipelines.Config
+		changed   []component.ID
+		// rebuilt are the nodes expected to be rebuilt, the other nodes are expected to be reused.
+		rebuilt []graphNode
+	}{
+		{
+			name:      "unchanged",
+			pipelines: basePipelines,
+		},
+		{
+			name:      "processor changed",
+			pipelines: basePipelines,
+			changed:   []component.ID{reloadP2},
+			rebuilt: []graphNode{
+				newReceiverNode(pipeline.SignalTraces, reloadR2),
+				newProcessorNode(reloadTraces2, reloadP2),
+			},
+		},
+		{
+			name:      "exporter changed",
+			pipelines: basePipelines,
+			changed:   []component.ID{reloadE1},
+			rebuilt: []graphNode{
+				newReceiverNode(pipeline.SignalTraces, reloadR1),
+				newReceiverNode(pipeline.SignalMetrics, reloadR1),
+				newProcessorNode(reloadTraces1, reloadP1),
+				newProcessorNode(reloadMetrics, reloadP1),
+				newExporterNode(pipeline.SignalTraces, reloadE1),
+				newExporterNode(pipeline.SignalMetrics, reloadE1),
+			},
+		},
+		{
+			name: "receiver added to a pipeline",
+			pipelines: pipelines.Config{
+				reloadTraces1: basePipelines[reloadTraces1],
+				reloadTraces2: {
+					Receivers:  []component.ID{reloadR1, reloadR2},
+					Processors: []component.ID{reloadP2},
+					Exporters:  []component.ID{reloadE2},
+				},
+				reloadMetrics: basePipelines[reloadMetrics],
+			},
+			// Both signals of the receiver are rebuilt, they may share their state.
+			rebuilt: []graphNode{
+				newReceiverNode(pipeline.SignalTraces, reloadR1),
+				newReceiverNode(pipeline.SignalMetrics, reloadR1),
+			},
+		},
+		{
+			name: "pipeline removed",
+			pipelines: pipelines.Config{
+				reloadTraces1: basePipelines[reloadTraces1],
+				reloadMetrics: basePipelines[reloadMetrics],
+			},
+		},
+		{
+			name: "connector added",
+			pipelines: pipelines.Config{
+				reloadTraces1: {
+					Receivers:  []component.ID{reloadR1},
+					Processors: []component.ID{reloadP1},
+					Exporters:  []component.ID{reloadE1, reloadC1},
+				},
+				reloadTraces2: {
+					Receivers:  []component.ID{reloadR2, reloadC1},
+					Processors: []component.ID{reloadP2},
+					Exporters:  []component.ID{reloadE2},
+				},
+				reloadMetrics: basePipelines[reloadMetrics],
+			},
+			rebuilt: []graphNode{
+				newReceiverNode(pipeline.SignalTraces, reloadR1),
+				newReceiverNode(pipeline.SignalMetrics, reloadR1),
+				newProcessorNode(reloadTraces1, reloadP1),
+				newConnectorNode(pipeline.SignalTraces, pipeline.SignalTraces, reloadC1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+			prev, err := Build(context.Background(), reloadSettings(basePipelines))
+			require.NoError(t, err)
+			require.NoError(t, prev.StartAll(context.Background(), host))
+			prevComps := graphComponents(prev)
+
+			next, err := prev.Reload(context.Background(), reloadSettings(tt.pipelines), func(_ component.Kind, id component.ID) bool {
+				for _, changed := range tt.changed {
+					if id == changed {
+						return false
+					}
+				}
+				return true
+			})
+			require.NoError(t, err)
+			require.NoError(t, prev.ShutdownRetired(context.Background(), host.Reporter, next))
+			require.NoError(t, next.StartAll(context.Background(), host))
+			nextComps := graphComponents(next)
+
+			rebuilt := map[int64]bool{}
+			for _, n := range tt.rebuilt {
+				rebuilt[n.ID()] = true
+			}
+			reused := 0
+			for id, comp := range nextComps {
+				assert.True(t, comp.(startedComponent).Started())
+				assert.False(t, comp.(startedComponent).Stopped())
+				prevComp, existed := prevComps[id]
+				if !existed {
+					continue
+				}
+				if rebuilt[id] {
+					assert.NotSame(t, prevComp, comp)
+					assert.True(t, prevComp.(startedComponent).Stopped())
+				} else {
+					assert.Same(t, prevComp, comp)
+					reused++
+				}
+			}
+			for id, prevComp := range prevComps {
+				if _, kept := nextComps[id]; !kept {
+					assert.True(t, prevComp.(startedComponent).Stopped())
+				}
+			}
+			assert.Equal(t, reused, next.Reused())
+
+			require.NoError(t, next.ShutdownAll(context.Background(), host.Reporter))
+			for _, comp := range nextComps {
+				assert.True(t, comp.(startedComponent).Stopped())
+			}
+		})
+	}
+}
+
+func TestGraphReloadError(t *testing.T) {
+	prev, err := Build(context.Background(), reloadSettings(pipelines.Config{
+		reloadTraces1: {
+			Receivers: []component.ID{reloadR1},
+			Exporters: []component.ID{reloadE1},
+		},
+	}))
+	require.NoError(t, err)
+
+	_, err = prev.Reload(context.Background(), reloadSettings(pipelines.Config{
+		reloadTraces1: {
+			Receivers: []component.ID{component.MustNewID("unknown")},
+			Exporters: []component.ID{reloadE1},
+		},
+	}), func(component.Kind, component.ID) bool { return true })
+	require.ErrorContains(t, err, `receiver "unknown" is not configured`)
+}
+
+type graphNode interface {
+	ID() int64
+}
+
+type startedComponent interface {
+	Started() bool
+	Stopped() bool
+}
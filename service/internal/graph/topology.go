@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	capabilitiesKind = "capabilities"
+	fanoutKind       = "fanout"
+)
+
+// TopologyNode describes a node of the graph.
+type TopologyNode struct {
+	// Name uniquely identifies the node in the graph.
+	Name string
+	// Kind is the kind of the component, or "capabilities" or "fanout" for the nodes internal to a pipeline.
+	Kind string
+	// ComponentID is the ID of the component, empty for the nodes internal to a pipeline.
+	ComponentID component.ID
+	// Signal is the signal consumed or emitted by the node.
+	Signal pipeline.Signal
+	// OutputSignal is the signal emitted by a connector, the zero value for the other nodes.
+	OutputSignal pipeline.Signal
+	// Pipelines are the pipelines the node belongs to, sorted by ID.
+	Pipelines []pipeline.ID
+}
+
+// TopologyEdge describes an edge of the graph, by the names of the nodes it connects.
+type TopologyEdge struct {
+	From   string
+	To     string
+	Signal pipeline.Signal
+}
+
+// Topology returns the nodes of the graph, sorted by name, and its edges, sorted by the names of the nodes they
+// connect.
+func (g *Graph) Topology() ([]TopologyNode, []TopologyEdge) {
+	pipelinesByNode := make(map[int64][]pipeline.ID)
+	for pipelineID, pg := range g.pipelines {
+		nodes := []graph.Node{pg.capabilitiesNode, pg.fanOutNode}
+		nodes = append(nodes, pg.processors...)
+		for _, n := range pg.receivers {
+			nodes = append(nodes, n)
+		}
+		for _, n := range pg.exporters {
+			nodes = append(nodes, n)
+		}
+		for _, n := range nodes {
+			pipelinesByNode[n.ID()] = append(pipelinesByNode[n.ID()], pipelineID)
+		}
+	}
+
+	names := make(map[int64]string)
+	var nodes []TopologyNode
+	for it := g.componentGraph.Nodes(); it.Next(); {
+		node := topologyNode(it.Node())
+		node.Pipelines = pipelinesByNode[it.Node().ID()]
+		slices.SortFunc(node.Pipelines, func(a, b pipeline.ID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		names[it.Node().ID()] = node.Name
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b TopologyNode) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var edges []TopologyEdge
+	for it := g.componentGraph.Edges(); it.Next(); {
+		edge := it.Edge()
+		edges = append(edges, TopologyEdge{
+			From:   names[edge.From().ID()],
+			To:     names[edge.To().ID()],
+			Signal: topologyNode(edge.To()).Signal,
+		})
+	}
+	slices.SortFunc(edges, func(a, b TopologyEdge) int {
+		return cmp.Or(strings.Compare(a.From, b.From), strings.Compare(a.To, b.To))
+	})
+	return nodes, edges
+}
+
+// topologyNode describes the node, without its pipelines. The signal of a connector is the signal it consumes.
+func topologyNode(node graph.Node) TopologyNode {
+	switch n := node.(type) {
+	case *receiverNode:
+		return TopologyNode{
+			Name:        kindName(component.KindReceiver) + ":" + n.componentID.String() + ":" + n.pipelineType.String(),
+			Kind:        kindName(component.KindReceiver),
+			ComponentID: n.componentID,
+			Signal:      n.pipelineType,
+		}
+	case *processorNode:
+		return TopologyNode{
+			Name:        kindName(component.KindProcessor) + ":" + n.pipelineID.String() + ":" + n.componentID.String(),
+			Kind:        kindName(component.KindProcessor),
+			ComponentID: n.componentID,
+			Signal:      n.pipelineID.Signal(),
+		}
+	case *exporterNode:
+		return TopologyNode{
+			Name:        kindName(component.KindExporter) + ":" + n.componentID.String() + ":" + n.pipelineType.String(),
+			Kind:        kindName(component.KindExporter),
+			ComponentID: n.componentID,
+			Signal:      n.pipelineType,
+		}
+	case *connectorNode:
+		return TopologyNode{
+			Name:         kindName(component.KindConnector) + ":" + n.componentID.String() + ":" + n.exprPipelineType.String() + ":" + n.rcvrPipelineType.String(),
+			Kind:         kindName(component.KindConnector),
+			ComponentID:  n.componentID,
+			Signal:       n.exprPipelineType,
+			OutputSignal: n.rcvrPipelineType,
+		}
+	case *capabilitiesNode:
+		return TopologyNode{
+			Name:   capabilitiesKind + ":" + n.pipelineID.String(),
+			Kind:   capabilitiesKind,
+			Signal: n.pipelineID.Signal(),
+		}
+	case *fanOutNode:
+		return TopologyNode{
+			Name:   fanoutKind + ":" + n.pipelineID.String(),
+			Kind:   fanoutKind,
+			Signal: n.pipelineID.Signal(),
+		}
+	}
+	return TopologyNode{}
+}
+
+func kindName(kind component.Kind) string {
+	return strings.ToLower(kind.String())
+}
+
+// Rebuilt returns the receivers, processors, exporters and connectors of each pipeline of a graph returned by
+// Reload which are not reused from the previous graph, sorted by name. The pipelines without rebuilt components
+// are omitted.
+func (g *Graph) Rebuilt() map[pipeline.ID][]TopologyNode {
+	rebuilt := make(map[pipeline.ID][]TopologyNode)
+	for pipelineID, pg := range g.pipelines {
+		nodes := slices.Clone(pg.processors)
+		for _, n := range pg.receivers {
+			nodes = append(nodes, n)
+		}
+		for _, n := range pg.exporters {
+			nodes = append(nodes, n)
+		}
+		for _, n := range nodes {
+			if _, reused := g.reused[n.ID()]; !reused {
+				rebuilt[pipelineID] = append(rebuilt[pipelineID], topologyNode(n))
+			}
+		}
+		slices.SortFunc(rebuilt[pipelineID], func(a, b TopologyNode) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return rebuilt
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// ReloadPlan describes how Reload applies a configuration to a service running another configuration.
+type ReloadPlan struct {
+	// RestartRequired is set when Reload cannot apply the configuration, e.g. when the telemetry configuration
+	// changes. The service must be restarted with all its components, Rebuilt is empty.
+	RestartRequired bool
+	// Rebuilt are the components of each pipeline of the new configuration which Reload starts, and stops first
+	// if they were running, sorted by name. The pipelines whose components all keep running are omitted. The
+	// Pipelines of the nodes are not set.
+	Rebuilt map[pipeline.ID][]TopologyNode
+}
+
+// PlanReload returns how Reload would apply the next configuration to a service running the previous one, without
+// running any service. The pipeline components of both configurations are built, like Validate, but not started.
+func PlanReload(ctx context.Context, prevSet Settings, prevCfg Config, nextSet Settings, nextCfg Config) (*ReloadPlan, error) {
+	if !reflect.DeepEqual(prevCfg.Telemetry, nextCfg.Telemetry) {
+		return &ReloadPlan{RestartRequired: true}, nil
+	}
+
+	prev, err := buildGraph(ctx, prevSet, prevCfg)
+	if err != nil {
+		return nil, err
+	}
+	// The pipeline components are all rebuilt when an extension is changed or removed, like in Reload.
+	extensionsReplaced := slices.ContainsFunc(prevCfg.Extensions, func(id component.ID) bool {
+		return !slices.Contains(nextCfg.Extensions, id) || !configUnchanged(prevSet.ExtensionsConfigs, nextSet.ExtensionsConfigs, id)
+	})
+	next, err := prev.Reload(ctx, dryRunGraphSettings(nextSet, nextCfg), unchangedComponents(prevSet, nextSet, extensionsReplaced))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pipelines: %w", err)
+	}
+
+	plan := &ReloadPlan{Rebuilt: make(map[pipeline.ID][]TopologyNode)}
+	for pipelineID, nodes := range next.Rebuilt() {
+		for _, n := range nodes {
+			plan.Rebuilt[pipelineID] = append(plan.Rebuilt[pipelineID], newTopologyNode(n))
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestPlanReload(t *testing.T) {
+	nop2 := component.MustNewIDWithName(nopType.String(), "2")
+	traces2 := pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	newSettings := func() Settings {
+		set := newNopSettings()
+		set.ReceiversConfigs[nop2] = set.ReceiversConfigs[component.NewID(nopType)]
+		set.ExportersConfigs[nop2] = set.ExportersConfigs[component.NewID(nopType)]
+		return set
+	}
+	newConfig := func() Config {
+		cfg := newNopConfig()
+		cfg.Pipelines[traces2] = &pipelines.PipelineConfig{
+			Receivers:  []component.ID{nop2},
+			Processors: []component.ID{component.NewID(nopType)},
+			Exporters:  []component.ID{nop2},
+		}
+		return cfg
+	}
+
+	// The unchanged components keep running.
+	plan, err := PlanReload(context.Background(), newNopSettings(), newNopConfig(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	assert.False(t, plan.RestartRequired)
+	assert.Empty(t, plan.Rebuilt)
+
+	// Only the components of the added pipeline are started.
+	plan, err = PlanReload(context.Background(), newNopSettings(), newNopConfig(), newSettings(), newConfig())
+	require.NoError(t, err)
+	assert.Equal(t, map[pipeline.ID][]TopologyNode{
+		traces2: {
+			{Name: "exporter:nop/2:traces", Kind: "exporter", ComponentID: nop2, Signal: pipeline.SignalTraces},
+			{Name: "processor:traces/2:nop", Kind: "processor", ComponentID: component.NewID(nopType), Signal: pipeline.SignalTraces},
+			{Name: "receiver:nop/2:traces", Kind: "receiver", ComponentID: nop2, Signal: pipeline.SignalTraces},
+		},
+	}, plan.Rebuilt)
+
+	// The components are all rebuilt when an extension changes.
+	set := newSettings()
+	set.ExtensionsConfigs[component.NewID(nopType)] = &struct{ changed bool }{changed: true}
+	plan, err = PlanReload(context.Background(), newSettings(), newConfig(), set, newConfig())
+	require.NoError(t, err)
+	assert.Len(t, plan.Rebuilt, 5)
+	assert.Len(t, plan.Rebuilt[traces2], 3)
+
+	// The changes of the telemetry configuration require a restart.
+	cfg := newConfig()
+	cfg.Telemetry = &struct{ changed bool }{changed: true}
+	plan, err = PlanReload(context.Background(), newSettings(), newConfig(), newSettings(), cfg)
+	require.NoError(t, err)
+	assert.True(t, plan.RestartRequired)
+	assert.Empty(t, plan.Rebuilt)
+}
+
+func TestPlanReloadBuildError(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Receivers = []component.ID{component.MustNewID("unknown")}
+	_, err := PlanReload(context.Background(), newNopSettings(), newNopConfig(), newNopSettings(), cfg)
+	require.ErrorContains(t, err, "failed to build pipelines")
+	_, err = PlanReload(context.Background(), newNopSettings(), cfg, newNopSettings(), newNopConfig())
+	require.ErrorContains(t, err, "failed to build pipelines")
+}
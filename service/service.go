@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
 
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
@@ -105,6 +106,10 @@ type Service struct {
 	loggerShutdownFunc component.ShutdownFunc
 	meterProvider      telemetry.MeterProvider
 	tracerProvider     telemetry.TracerProvider
+
+	// The settings and the configuration the service is running, compared to the new ones on Reload.
+	settings Settings
+	config   Config
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -123,6 +128,8 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 			AsyncErrorChannel: set.AsyncErrorChannel,
 		},
 		collectorConf: set.CollectorConf,
+		settings:      set,
+		config:        cfg,
 	}
 
 	if set.TelemetryFactory == nil {
@@ -267,7 +274,8 @@ func (srv *Service) Start(ctx context.Context) error {
 
 // Shutdown the service. Shutdown will do the following steps in order:
 // 1. Notify extensions that the pipeline is shutting down.
-// 2. Shutdown all pipelines.
+// 2. Shutdown all pipelines. If a drain timeout is configured, the receivers are stopped first and the other
+// components are drained before being stopped.
 // 3. Shutdown all extensions.
 // 4. Shutdown telemetry.
 func (srv *Service) Shutdown(ctx context.Context) error {
@@ -281,7 +289,12 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
-	if err := srv.host.Pipelines.ShutdownAll(ctx, srv.host.Reporter); err != nil {
+	if drainTimeout := srv.config.Shutdown.DrainTimeout; drainTimeout > 0 {
+		srv.telemetrySettings.Logger.Info("Draining pipelines...", zap.Duration("drain_timeout", drainTimeout))
+		if err := srv.host.Pipelines.DrainAndShutdownAll(ctx, srv.host.Reporter, drainTimeout); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
+		}
+	} else if err := srv.host.Pipelines.ShutdownAll(ctx, srv.host.Reporter); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
@@ -306,6 +319,145 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// ErrRestartRequired is returned by Reload when the changes of the configuration cannot be applied to the running
+// service, e.g. the changes of the telemetry configuration. The service is left unchanged, it must be shut down and
+// a new service created from the new configuration.
+var ErrRestartRequired = errors.New("the configuration changes require a restart of the service")
+
+// Reload applies the new configuration to the running service, only stopping and starting the components affected
+// by the changes: the extensions and the pipeline components whose configuration changed, or which are connected
+// differently. The other components keep running. The components are all rebuilt when an extension is changed or
+// removed, as they may hold a reference to it.
+//
+// The service keeps running unchanged if the new components cannot be built. If Reload fails afterwards, Shutdown
+// should be called to ensure a clean state. Reload does the following steps in order:
+// 1. Build the new extensions and pipelines, reusing the unchanged ones.
+// 2. Notify extensions that the pipeline is not ready.
+// 3. Shutdown the pipeline components and the extensions which are not reused.
+// 4. Start the new extensions.
+// 5. Notify extensions about Collector configuration.
+// 6. Start the new pipeline components.
+// 7. Notify extensions that the pipeline is ready.
+func (srv *Service) Reload(ctx context.Context, set Settings, cfg Config) error {
+	if !reflect.DeepEqual(srv.config.Telemetry, cfg.Telemetry) {
+		return ErrRestartRequired
+	}
+
+	extensionsBuilder := builders.NewExtension(set.ExtensionsConfigs, set.ExtensionsFactories)
+	serviceExtensions, err := srv.host.ServiceExtensions.Reload(ctx, extensions.Settings{
+		Telemetry:  srv.telemetrySettings,
+		BuildInfo:  srv.buildInfo,
+		Extensions: extensionsBuilder,
+	}, cfg.Extensions, func(id component.ID) bool {
+		return configUnchanged(srv.settings.ExtensionsConfigs, set.ExtensionsConfigs, id)
+	}, extensions.WithReporter(srv.host.Reporter))
+	if err != nil {
+		return fmt.Errorf("failed to build extensions: %w", err)
+	}
+	extensionsReplaced := len(serviceExtensions.Reused()) < len(srv.config.Extensions)
+
+	receivers := builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	processors := builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	exporters := builders.NewExporter(set.ExportersConfigs, set.ExportersFactories)
+	connectors := builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+	pipelines, err := srv.host.Pipelines.Reload(ctx, graph.Settings{
+		Telemetry:        srv.telemetrySettings,
+		BuildInfo:        srv.buildInfo,
+		ReceiverBuilder:  receivers,
+		ProcessorBuilder: processors,
+		ExporterBuilder:  exporters,
+		ConnectorBuilder: connectors,
+		PipelineConfigs:  cfg.Pipelines,
+		ReportStatus:     srv.host.Reporter.ReportStatus,
+	}, unchangedComponents(srv.settings, set, extensionsReplaced))
+	if err != nil {
+		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+
+	srv.telemetrySettings.Logger.Info("Reloading configuration...",
+		zap.Int("reused_extensions", len(serviceExtensions.Reused())),
+		zap.Int("reused_components", pipelines.Reused()),
+	)
+
+	var errs error
+	if err = srv.host.ServiceExtensions.NotifyPipelineNotReady(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
+	}
+	if err = srv.host.Pipelines.ShutdownRetired(ctx, srv.host.Reporter, pipelines); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
+	}
+	if err = srv.host.ServiceExtensions.ShutdownRetired(ctx, serviceExtensions); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
+	}
+
+	srv.host.Receivers = receivers
+	srv.host.Processors = processors
+	srv.host.Exporters = exporters
+	srv.host.Connectors = connectors
+	srv.host.Extensions = extensionsBuilder
+	srv.host.ModuleInfos = set.ModuleInfos
+	srv.host.ServiceExtensions = serviceExtensions
+	srv.host.Pipelines = pipelines
+	srv.collectorConf = set.CollectorConf
+	srv.settings = set
+	srv.config = cfg
+	if errs != nil {
+		return errs
+	}
+
+	if err = srv.host.ServiceExtensions.Start(ctx, srv.host); err != nil {
+		return fmt.Errorf("failed to start extensions: %w", err)
+	}
+
+	if srv.collectorConf != nil {
+		if err = srv.host.ServiceExtensions.NotifyConfig(ctx, srv.collectorConf); err != nil {
+			return err
+		}
+	}
+
+	if err = srv.host.Pipelines.StartAll(ctx, srv.host); err != nil {
+		return fmt.Errorf("cannot start pipelines: %w", err)
+	}
+
+	if err = srv.host.ServiceExtensions.NotifyPipelineReady(); err != nil {
+		return err
+	}
+
+	srv.telemetrySettings.Logger.Info("Configuration reloaded. Begin running and processing data.")
+	return nil
+}
+
+// unchangedComponents returns whether the pipeline components have the same configuration in both settings. The
+// components are all considered changed if the extensions are replaced, as they may hold a reference to them.
+func unchangedComponents(prev, next Settings, extensionsReplaced bool) func(component.Kind, component.ID) bool {
+	return func(kind component.Kind, id component.ID) bool {
+		if extensionsReplaced {
+			return false
+		}
+		switch kind {
+		case component.KindReceiver:
+			return configUnchanged(prev.ReceiversConfigs, next.ReceiversConfigs, id)
+		case component.KindProcessor:
+			return configUnchanged(prev.ProcessorsConfigs, next.ProcessorsConfigs, id)
+		case component.KindExporter:
+			return configUnchanged(prev.ExportersConfigs, next.ExportersConfigs, id)
+		case component.KindConnector:
+			return configUnchanged(prev.ConnectorsConfigs, next.ConnectorsConfigs, id)
+		}
+		return false
+	}
+}
+
+// configUnchanged returns whether the component has the same configuration in both maps.
+func configUnchanged(prev, next map[component.ID]component.Config, id component.ID) bool {
+	prevCfg, ok := prev[id]
+	if !ok {
+		return false
+	}
+	nextCfg, ok := next[id]
+	return ok && reflect.DeepEqual(prevCfg, nextCfg)
+}
+
 // Creates extensions.
 func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) error {
 	var err error
@@ -344,27 +496,43 @@ func (srv *Service) Logger() *zap.Logger {
 	return srv.telemetrySettings.Logger
 }
 
+// TelemetrySettings returns the telemetry settings created for this service, to report the telemetry of the
+// Collector running it.
+func (srv *Service) TelemetrySettings() component.TelemetrySettings {
+	return srv.telemetrySettings
+}
+
 // Validate verifies the graph by calling the internal graph.Build.
 func Validate(ctx context.Context, set Settings, cfg Config) error {
-	tel := component.TelemetrySettings{
-		Logger:         zap.NewNop(),
-		TracerProvider: nooptrace.NewTracerProvider(),
-		MeterProvider:  noopmetric.NewMeterProvider(),
-		Resource:       pcommon.NewResource(),
-	}
-	_, err := graph.Build(ctx, graph.Settings{
-		Telemetry:        tel,
+	_, err := buildGraph(ctx, set, cfg)
+	return err
+}
+
+// buildGraph builds the graph of the pipelines, without starting its components, with a no-op telemetry.
+func buildGraph(ctx context.Context, set Settings, cfg Config) (*graph.Graph, error) {
+	pipelines, err := graph.Build(ctx, dryRunGraphSettings(set, cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pipelines: %w", err)
+	}
+	return pipelines, nil
+}
+
+// dryRunGraphSettings returns the settings to build the graph of the pipelines with a no-op telemetry.
+func dryRunGraphSettings(set Settings, cfg Config) graph.Settings {
+	return graph.Settings{
+		Telemetry: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			TracerProvider: nooptrace.NewTracerProvider(),
+			MeterProvider:  noopmetric.NewMeterProvider(),
+			Resource:       pcommon.NewResource(),
+		},
 		BuildInfo:        set.BuildInfo,
 		ReceiverBuilder:  builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories),
 		ProcessorBuilder: builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories),
 		ExporterBuilder:  builders.NewExporter(set.ExportersConfigs, set.ExportersFactories),
 		ConnectorBuilder: builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories),
 		PipelineConfigs:  cfg.Pipelines,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
-	return nil
 }
 
 // registerProcessMetrics registers process metrics on supported operating systems.
@@ -408,6 +408,84 @@ func TestNilCollectorEffectiveConfig(t *testing.T) {
 	require.NoError(t, srv.Shutdown(context.Background()))
 }
 
+func TestServiceReload(t *testing.T) {
+	set := newNopSettings()
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+	prevExtensions := srv.host.GetExtensions()
+
+	// Add a pipeline, the components of the other pipelines keep running.
+	nop2 := component.MustNewIDWithName(nopType.String(), "2")
+	newSettings := func() Settings {
+		set := newNopSettings()
+		set.ReceiversConfigs[nop2] = set.ReceiversConfigs[component.NewID(nopType)]
+		set.ExportersConfigs[nop2] = set.ExportersConfigs[component.NewID(nopType)]
+		return set
+	}
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "2")] = &pipelines.PipelineConfig{
+		Receivers:  []component.ID{nop2},
+		Processors: []component.ID{component.NewID(nopType)},
+		Exporters:  []component.ID{nop2},
+	}
+	require.NoError(t, srv.Reload(context.Background(), newSettings(), cfg))
+	assert.Equal(t, 12, srv.host.Pipelines.Reused())
+	assert.Equal(t, []component.ID{component.NewID(nopType)}, srv.host.ServiceExtensions.Reused())
+	assert.Same(t, prevExtensions[component.NewID(nopType)], srv.host.GetExtensions()[component.NewID(nopType)])
+
+	// Change the configuration of the extension, all the components are rebuilt.
+	set = newSettings()
+	set.ExtensionsConfigs[component.NewID(nopType)] = &struct{ changed bool }{changed: true}
+	require.NoError(t, srv.Reload(context.Background(), set, cfg))
+	assert.Zero(t, srv.host.Pipelines.Reused())
+	assert.Empty(t, srv.host.ServiceExtensions.Reused())
+
+	// The changes of the telemetry configuration require a restart.
+	cfg.Telemetry = &struct{ changed bool }{changed: true}
+	require.ErrorIs(t, srv.Reload(context.Background(), set, cfg), ErrRestartRequired)
+}
+
+func TestServiceReloadBuildError(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+	prevPipelines := srv.host.Pipelines
+
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Receivers = []component.ID{component.MustNewID("unknown")}
+	require.ErrorContains(t, srv.Reload(context.Background(), newNopSettings(), cfg), "failed to build pipelines")
+	// The service keeps running the previous configuration.
+	assert.Same(t, prevPipelines, srv.host.Pipelines)
+}
+
+func TestServiceShutdownDrain(t *testing.T) {
+	observerCore, observedLogs := observer.New(zapcore.InfoLevel)
+	set := newNopSettings()
+	set.TelemetryFactory = telemetry.NewFactory(
+		func() component.Config { return nil },
+		telemetrytest.WithLogger(zap.New(observerCore), nil),
+	)
+
+	cfg := newNopConfig()
+	cfg.Shutdown.DrainTimeout = time.Minute
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	// The nop components have no pending data, the shutdown does not wait for the drain timeout.
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	entries := observedLogs.FilterMessage("Draining pipelines...").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, time.Minute, entries[0].ContextMap()["drain_timeout"])
+}
+
 func TestServiceTelemetryLogger(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
@@ -179,3 +179,9 @@ replace go.opentelemetry.io/collector/internal/testutil => ../../../internal/tes
 replace go.opentelemetry.io/collector/internal/componentalias => ../../../internal/componentalias
 
 replace go.opentelemetry.io/collector/config/confignet => ../../../config/confignet
+
+replace go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper => ../../../exporter/exporterhelper/xexporterhelper
+
+replace go.opentelemetry.io/collector/consumer/consumererror/xconsumererror => ../../../consumer/consumererror/xconsumererror
+
+replace go.opentelemetry.io/collector/internal/persistentqueue => ../../../internal/persistentqueue
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/graph"
+)
+
+// Topology describes the graph of the pipeline components built from a configuration, with the internal nodes of
+// each pipeline: the capabilities node receiving the data of the receivers, and the fanout node sending it to
+// the exporters.
+type Topology struct {
+	// Nodes are the nodes of the graph, sorted by name.
+	Nodes []TopologyNode
+	// Edges are the edges of the graph, in the direction of the data flow, sorted by the names of the nodes
+	// they connect.
+	Edges []TopologyEdge
+}
+
+// TopologyNode is a node of the pipeline graph. A receiver or an exporter has a node per signal, a connector
+// has a node per pair of exported and received signals, and a processor has a node per pipeline.
+type TopologyNode struct {
+	// Name uniquely identifies the node in the topology, e.g. "receiver:otlp:traces".
+	Name string
+	// Kind is "receiver", "processor", "exporter" or "connector", or "capabilities" or "fanout" for the internal
+	// nodes of a pipeline.
+	Kind string
+	// ComponentID is the ID of the component, the zero value for the internal nodes of a pipeline.
+	ComponentID component.ID
+	// Signal is the signal consumed by the node, or emitted by a receiver.
+	Signal pipeline.Signal
+	// OutputSignal is the signal emitted by a connector, the zero value for the other nodes.
+	OutputSignal pipeline.Signal
+	// Pipelines are the pipelines the node belongs to. A connector belongs to the pipelines it exports data
+	// from and to the pipelines it receives data for.
+	Pipelines []pipeline.ID
+}
+
+// TopologyEdge is an edge of the pipeline graph.
+type TopologyEdge struct {
+	// From is the name of the node sending the data.
+	From string
+	// To is the name of the node receiving the data.
+	To string
+	// Signal is the signal of the data.
+	Signal pipeline.Signal
+}
+
+// BuildTopology builds the pipeline components of the configuration, like Validate, and returns the topology of
+// their graph. The components are not started.
+func BuildTopology(ctx context.Context, set Settings, cfg Config) (*Topology, error) {
+	pipelines, err := buildGraph(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, edges := pipelines.Topology()
+	topology := &Topology{
+		Nodes: make([]TopologyNode, 0, len(nodes)),
+		Edges: make([]TopologyEdge, 0, len(edges)),
+	}
+	for _, n := range nodes {
+		topology.Nodes = append(topology.Nodes, newTopologyNode(n))
+	}
+	for _, e := range edges {
+		topology.Edges = append(topology.Edges, TopologyEdge{
+			From:   e.From,
+			To:     e.To,
+			Signal: e.Signal,
+		})
+	}
+	return topology, nil
+}
+
+func newTopologyNode(n graph.TopologyNode) TopologyNode {
+	return TopologyNode{
+		Name:         n.Name,
+		Kind:         n.Kind,
+		ComponentID:  n.ComponentID,
+		Signal:       n.Signal,
+		OutputSignal: n.OutputSignal,
+		Pipelines:    n.Pipelines,
+	}
+}
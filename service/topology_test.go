@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestBuildTopology(t *testing.T) {
+	traces := pipeline.NewID(pipeline.SignalTraces)
+	traces2 := pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	nopID := component.NewID(nopType)
+	connID := component.NewIDWithName(nopType, "conn")
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		traces: {
+			Receivers:  []component.ID{nopID},
+			Processors: []component.ID{nopID},
+			Exporters:  []component.ID{nopID, connID},
+		},
+		traces2: {
+			Receivers: []component.ID{connID},
+			Exporters: []component.ID{nopID},
+		},
+	})
+
+	topology, err := BuildTopology(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(topology.Nodes))
+	for _, n := range topology.Nodes {
+		names = append(names, n.Name)
+	}
+	assert.Equal(t, []string{
+		"capabilities:traces",
+		"capabilities:traces/2",
+		"connector:nop/conn:traces:traces",
+		"exporter:nop:traces",
+		"fanout:traces",
+		"fanout:traces/2",
+		"processor:traces:nop",
+		"receiver:nop:traces",
+	}, names)
+	assert.Equal(t, TopologyNode{
+		Name:         "connector:nop/conn:traces:traces",
+		Kind:         "connector",
+		ComponentID:  connID,
+		Signal:       pipeline.SignalTraces,
+		OutputSignal: pipeline.SignalTraces,
+		Pipelines:    []pipeline.ID{traces, traces2},
+	}, topology.Nodes[2])
+
+	assert.Equal(t, []TopologyEdge{
+		{From: "capabilities:traces", To: "processor:traces:nop", Signal: pipeline.SignalTraces},
+		{From: "capabilities:traces/2", To: "fanout:traces/2", Signal: pipeline.SignalTraces},
+		{From: "connector:nop/conn:traces:traces", To: "capabilities:traces/2", Signal: pipeline.SignalTraces},
+		{From: "fanout:traces", To: "connector:nop/conn:traces:traces", Signal: pipeline.SignalTraces},
+		{From: "fanout:traces", To: "exporter:nop:traces", Signal: pipeline.SignalTraces},
+		{From: "fanout:traces/2", To: "exporter:nop:traces", Signal: pipeline.SignalTraces},
+		{From: "processor:traces:nop", To: "fanout:traces", Signal: pipeline.SignalTraces},
+		{From: "receiver:nop:traces", To: "capabilities:traces", Signal: pipeline.SignalTraces},
+	}, topology.Edges)
+}
+
+func TestBuildTopologyError(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Receivers = []component.ID{component.MustNewID("unknown")}
+	_, err := BuildTopology(context.Background(), newNopSettings(), cfg)
+	require.ErrorContains(t, err, "failed to build pipelines")
+}